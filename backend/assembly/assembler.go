@@ -10,8 +10,12 @@ import (
 	"avito/repository/cache"
 	"avito/server"
 	"avito/service"
+	"avito/utils"
 	"context"
+	"encoding/base64"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 type Close func() error
@@ -50,6 +54,84 @@ func (a *Assembler) Assemble(ctx context.Context, conf *config.Config) (http.Han
 
 	a.closers = append(a.closers, cli.Close)
 
+	schedulerInterval, err := time.ParseDuration(conf.SchedulerInterval)
+	if err != nil {
+		a.logger.Fatal(ctx, err.Error())
+		return nil, err
+	}
+
+	complaintWindow, err := time.ParseDuration(conf.ComplaintWindow)
+	if err != nil {
+		a.logger.Fatal(ctx, err.Error())
+		return nil, err
+	}
+
+	outboxInterval, err := time.ParseDuration(conf.OutboxInterval)
+	if err != nil {
+		a.logger.Fatal(ctx, err.Error())
+		return nil, err
+	}
+
+	webhookInterval, err := time.ParseDuration(conf.WebhookInterval)
+	if err != nil {
+		a.logger.Fatal(ctx, err.Error())
+		return nil, err
+	}
+
+	webhookBackoff, err := time.ParseDuration(conf.WebhookBackoff)
+	if err != nil {
+		a.logger.Fatal(ctx, err.Error())
+		return nil, err
+	}
+
+	webhookMaxAttempts, err := strconv.Atoi(conf.WebhookMaxAttempts)
+	if err != nil {
+		a.logger.Fatal(ctx, err.Error())
+		return nil, err
+	}
+
+	emailInterval, err := time.ParseDuration(conf.EmailInterval)
+	if err != nil {
+		a.logger.Fatal(ctx, err.Error())
+		return nil, err
+	}
+
+	emailBackoff, err := time.ParseDuration(conf.EmailBackoff)
+	if err != nil {
+		a.logger.Fatal(ctx, err.Error())
+		return nil, err
+	}
+
+	emailMaxAttempts, err := strconv.Atoi(conf.EmailMaxAttempts)
+	if err != nil {
+		a.logger.Fatal(ctx, err.Error())
+		return nil, err
+	}
+
+	inboxRetention, err := time.ParseDuration(conf.InboxRetention)
+	if err != nil {
+		a.logger.Fatal(ctx, err.Error())
+		return nil, err
+	}
+
+	inboxCleanupInterval, err := time.ParseDuration(conf.InboxCleanupInterval)
+	if err != nil {
+		a.logger.Fatal(ctx, err.Error())
+		return nil, err
+	}
+
+	masterKey, err := base64.StdEncoding.DecodeString(conf.SealMasterKey)
+	if err != nil {
+		a.logger.Fatal(ctx, err.Error())
+		return nil, err
+	}
+
+	masterCipher, err := utils.NewCipher(masterKey)
+	if err != nil {
+		a.logger.Fatal(ctx, err.Error())
+		return nil, err
+	}
+
 	mgRunner := db.NewRunner(db.DialectPostgreSQL, conf.MigrationDir, a.logger)
 	err = mgRunner.Run(ctx, cli.DB.DB)
 	if err != nil {
@@ -74,15 +156,66 @@ func (a *Assembler) Assemble(ctx context.Context, conf *config.Config) (http.Han
 	userService := service.NewUserService(userRep)
 	userController := controllers.NewUserController(a.logger, userService)
 
+	serviceTypeRep := repository.NewServiceTypeRep(a.logger, cli)
+	serviceTypeService := service.NewServiceTypeService(serviceTypeRep, conf.Admins())
+	serviceTypeController := controllers.NewServiceTypeController(a.logger, serviceTypeService)
+
+	templateRep := repository.NewTemplateRep(a.logger, cli)
+	templateService := service.NewTemplateService(templateRep, orgRep)
+	templateController := controllers.NewTemplateController(a.logger, templateService)
+
 	tenderRep := repository.NewTenderRep(a.logger, cli, tenderIdStorage, usernameIdMatchStorage)
-	tenderService := service.NewTenderService(tenderRep, orgRep)
+	tenderService := service.NewTenderService(tenderRep, orgRep, templateRep, serviceTypeRep, txManager)
 	tenderController := controllers.NewTenderController(a.logger, tenderService)
 
+	envelopeRep := repository.NewEnvelopeRep(a.logger, cli)
+	envelopeService := service.NewEnvelopeService(envelopeRep, tenderRep, txManager, masterCipher)
+	envelopeController := controllers.NewEnvelopeController(a.logger, envelopeService)
+
 	bidRep := repository.NewBidRep(a.logger, cli, bidIdStorage, usernameIdMatchStorage)
+
+	auctionRep := repository.NewAuctionRep(a.logger, cli)
+	auctionService := service.NewAuctionService(auctionRep, tenderRep, bidRep, txManager, service.NewAuctionHub())
+	auctionController := controllers.NewAuctionController(a.logger, auctionService)
+
 	feedbackRep := repository.NewFeedbackRep(a.logger, cli, usernameIdMatchStorage)
-	bidService := service.NewBidService(bidRep, feedbackRep, tenderRep, orgRep, txManager)
+	bidService := service.NewBidService(bidRep, feedbackRep, tenderRep, orgRep, envelopeService, auctionService, txManager)
 	bidController := controllers.NewBidController(a.logger, bidService)
 
+	complaintRep := repository.NewComplaintRep(a.logger, cli)
+	complaintService := service.NewComplaintService(complaintRep, tenderRep, bidRep, txManager, complaintWindow)
+	complaintController := controllers.NewComplaintController(a.logger, complaintService)
+
+	frameworkRep := repository.NewFrameworkRep(a.logger, cli)
+	frameworkService := service.NewFrameworkService(frameworkRep, tenderRep, bidRep, complaintService)
+	frameworkController := controllers.NewFrameworkController(a.logger, frameworkService)
+
+	contractRep := repository.NewContractRep(a.logger, cli)
+	contractService := service.NewContractService(contractRep, tenderRep, bidRep, complaintService)
+	contractController := controllers.NewContractController(a.logger, contractService)
+	milestoneService := service.NewMilestoneService(contractRep, tenderRep, bidRep, orgRep, complaintService)
+	milestoneController := controllers.NewMilestoneController(a.logger, milestoneService)
+
+	webhookRep := repository.NewWebhookRep(a.logger, cli)
+	webhookService := service.NewWebhookService(webhookRep, tenderRep, orgRep, masterCipher)
+	webhookController := controllers.NewWebhookController(a.logger, webhookService)
+
+	notificationRep := repository.NewNotificationRep(a.logger, cli)
+	notificationService := service.NewNotificationService(notificationRep, tenderRep)
+	notificationController := controllers.NewNotificationController(a.logger, notificationService)
+
+	inboxRep := repository.NewInboxRep(a.logger, cli)
+	inboxService := service.NewInboxService(inboxRep, tenderRep)
+	inboxController := controllers.NewInboxController(a.logger, inboxService)
+
+	savedSearchRep := repository.NewSavedSearchRep(a.logger, cli)
+	savedSearchService := service.NewSavedSearchService(savedSearchRep, tenderRep, serviceTypeRep)
+	savedSearchController := controllers.NewSavedSearchController(a.logger, savedSearchService)
+
+	criterionRep := repository.NewCriterionRep(a.logger, cli)
+	evaluationService := service.NewEvaluationService(criterionRep, tenderRep, bidRep, envelopeService, txManager)
+	evaluationController := controllers.NewEvaluationController(a.logger, evaluationService)
+
 	ids, err := bidRep.GetBidIds(ctx)
 	if err != nil {
 		return nil, err
@@ -101,6 +234,67 @@ func (a *Assembler) Assemble(ctx context.Context, conf *config.Config) (http.Han
 	}
 	usernameIdMatchStorage.WarmUp(kvPairs)
 
+	schedulerCtx, stopScheduler := context.WithCancel(ctx)
+	scheduler := service.NewTenderScheduler(a.logger, tenderRep, envelopeService, schedulerInterval)
+	go scheduler.Run(schedulerCtx)
+	a.closers = append([]Close{func() error {
+		stopScheduler()
+		return nil
+	}}, a.closers...)
+
+	sinks := []service.EventSink{service.NewLogSink(a.logger), service.NewWebhookSink(webhookRep), service.NewInboxSink(inboxRep),
+		service.NewSavedSearchSink(savedSearchRep)}
+	if conf.SmtpAddress != "" {
+		sinks = append(sinks, service.NewEmailSink(notificationRep))
+
+		emailCtx, stopEmails := context.WithCancel(ctx)
+		mailer := service.NewSMTPMailer(conf.SmtpAddress, conf.SmtpUsername, conf.SmtpPassword, conf.SmtpFrom)
+		emailDispatcher := service.NewEmailDispatcher(a.logger, notificationRep, mailer, emailInterval, emailBackoff, emailMaxAttempts)
+		go emailDispatcher.Run(emailCtx)
+		a.closers = append([]Close{func() error {
+			stopEmails()
+			return nil
+		}}, a.closers...)
+	}
+
+	dispatcherCtx, stopDispatcher := context.WithCancel(ctx)
+	outboxRep := repository.NewOutboxRep(a.logger, cli)
+	dispatcher := service.NewOutboxDispatcher(a.logger, outboxRep, outboxInterval, sinks...)
+	go dispatcher.Run(dispatcherCtx)
+	a.closers = append([]Close{func() error {
+		stopDispatcher()
+		return nil
+	}}, a.closers...)
+
+	streamHub := service.NewStreamHub(a.logger, outboxRep, outboxInterval)
+	if err = streamHub.Init(ctx); err != nil {
+		return nil, err
+	}
+	streamCtx, stopStream := context.WithCancel(ctx)
+	go streamHub.Run(streamCtx)
+	a.closers = append([]Close{func() error {
+		stopStream()
+		return nil
+	}}, a.closers...)
+	streamService := service.NewStreamService(streamHub, outboxRep, tenderRep, bidRep, orgRep)
+	streamController := controllers.NewStreamController(a.logger, streamService)
+
+	cleanerCtx, stopCleaner := context.WithCancel(ctx)
+	inboxCleaner := service.NewInboxCleaner(a.logger, inboxRep, inboxCleanupInterval, inboxRetention)
+	go inboxCleaner.Run(cleanerCtx)
+	a.closers = append([]Close{func() error {
+		stopCleaner()
+		return nil
+	}}, a.closers...)
+
+	webhookCtx, stopWebhooks := context.WithCancel(ctx)
+	webhookDispatcher := service.NewWebhookDispatcher(a.logger, webhookRep, masterCipher, webhookInterval, webhookBackoff, webhookMaxAttempts)
+	go webhookDispatcher.Run(webhookCtx)
+	a.closers = append([]Close{func() error {
+		stopWebhooks()
+		return nil
+	}}, a.closers...)
+
 	r := server.NewRouter(a.logger)
 	middlewares := server.NewMiddleware(a.logger)
 	r.AddRoutes(middlewares, server.Controllers{
@@ -108,7 +302,21 @@ func (a *Assembler) Assemble(ctx context.Context, conf *config.Config) (http.Han
 		UserCnt:   userController,
 		TenderCnt: tenderController,
 		OrgCnt:    orgController,
-		BidCnt:    bidController})
+		BidCnt:    bidController,
+		EvalCnt:   evaluationController,
+		TplCnt:    templateController,
+		StCnt:     serviceTypeController,
+		EnvCnt:    envelopeController,
+		AucCnt:    auctionController,
+		CtrCnt:    contractController,
+		MlsCnt:    milestoneController,
+		CmpCnt:    complaintController,
+		FrmCnt:    frameworkController,
+		WhkCnt:    webhookController,
+		StrCnt:    streamController,
+		NtfCnt:    notificationController,
+		InbCnt:    inboxController,
+		SrhCnt:    savedSearchController})
 
 	return r.Router, nil
 }
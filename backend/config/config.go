@@ -9,6 +9,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/joho/godotenv"
 	"os"
+	"strings"
 )
 
 type Config struct {
@@ -24,6 +25,35 @@ type Config struct {
 	ConnString    string `validate:"required" body:"conn_string"`
 	UrlJDBC       string `validate:"required" body:"jdbc_url"`
 	MigrationDir  string `validate:"required" body:"migration_dir"`
+
+	SchedulerInterval string `validate:"required" body:"scheduler_interval"`
+	AdminUsernames    string `body:"admin_usernames"`
+	// SealMasterKey is the base64 AES-256 key that encrypts the keys of the sealed tenders,
+	// e.g. the output of openssl rand -base64 32.
+	SealMasterKey string `validate:"required" body:"seal_master_key"`
+	// ComplaintWindow is how long after the tender is closed the bid authors may complain.
+	ComplaintWindow string `validate:"required" body:"complaint_window"`
+	// OutboxInterval is how often the outbox dispatcher delivers the new events to the sinks.
+	OutboxInterval string `validate:"required" body:"outbox_interval"`
+	// WebhookInterval is how often the due webhook deliveries are sent.
+	WebhookInterval string `validate:"required" body:"webhook_interval"`
+	// WebhookBackoff is the delay after the first failed delivery, it doubles with every attempt.
+	WebhookBackoff string `validate:"required" body:"webhook_backoff"`
+	// WebhookMaxAttempts is the number of attempts before the delivery goes to the dead letters.
+	WebhookMaxAttempts string `validate:"required" body:"webhook_max_attempts"`
+	// SmtpAddress is the host:port of the SMTP server sending the email notifications, no emails without it.
+	SmtpAddress  string `body:"smtp_address"`
+	SmtpUsername string `body:"smtp_username"`
+	SmtpPassword string `body:"smtp_password"`
+	SmtpFrom     string `body:"smtp_from"`
+	// EmailInterval, EmailBackoff and EmailMaxAttempts schedule the emails as the webhook settings do the deliveries.
+	EmailInterval    string `validate:"required" body:"email_interval"`
+	EmailBackoff     string `validate:"required" body:"email_backoff"`
+	EmailMaxAttempts string `validate:"required" body:"email_max_attempts"`
+	// InboxRetention is how long the notifications are kept in the inbox, the expired ones
+	// are removed every InboxCleanupInterval.
+	InboxRetention       string `validate:"required" body:"inbox_retention"`
+	InboxCleanupInterval string `validate:"required" body:"inbox_cleanup_interval"`
 }
 
 func (c *Config) WithSchema(schema string) *Config {
@@ -31,6 +61,17 @@ func (c *Config) WithSchema(schema string) *Config {
 	return c
 }
 
+// Admins returns the usernames listed in ADMIN_USERNAMES separated by commas.
+func (c *Config) Admins() []string {
+	admins := make([]string, 0)
+	for _, username := range strings.Split(c.AdminUsernames, ",") {
+		if username = strings.TrimSpace(username); username != "" {
+			admins = append(admins, username)
+		}
+	}
+	return admins
+}
+
 func (c *Config) Dsn() string {
 	return fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s sslmode=allow",
 		c.DbUsername, c.DbPassword, c.DbHost, c.DbPort, c.DbName)
@@ -51,6 +92,24 @@ func LoadFromEnv(envFilePath string) *Config {
 		ConnString:    getEnv("POSTGRES_CONN", ""),
 		UrlJDBC:       getEnv("POSTGRES_JDBC_URL", ""),
 		MigrationDir:  getEnv("MIGRATIONS_DIR", "migrations"),
+
+		SchedulerInterval:    getEnv("SCHEDULER_INTERVAL", "1s"),
+		AdminUsernames:       getEnv("ADMIN_USERNAMES", ""),
+		SealMasterKey:        getEnv("SEAL_MASTER_KEY", ""),
+		ComplaintWindow:      getEnv("COMPLAINT_WINDOW", "72h"),
+		OutboxInterval:       getEnv("OUTBOX_INTERVAL", "1s"),
+		WebhookInterval:      getEnv("WEBHOOK_INTERVAL", "1s"),
+		WebhookBackoff:       getEnv("WEBHOOK_BACKOFF", "1s"),
+		WebhookMaxAttempts:   getEnv("WEBHOOK_MAX_ATTEMPTS", "8"),
+		SmtpAddress:          getEnv("SMTP_ADDRESS", ""),
+		SmtpUsername:         getEnv("SMTP_USERNAME", ""),
+		SmtpPassword:         getEnv("SMTP_PASSWORD", ""),
+		SmtpFrom:             getEnv("SMTP_FROM", "noreply@tenders.local"),
+		EmailInterval:        getEnv("EMAIL_INTERVAL", "5s"),
+		EmailBackoff:         getEnv("EMAIL_BACKOFF", "30s"),
+		EmailMaxAttempts:     getEnv("EMAIL_MAX_ATTEMPTS", "6"),
+		InboxRetention:       getEnv("INBOX_RETENTION", "720h"),
+		InboxCleanupInterval: getEnv("INBOX_CLEANUP_INTERVAL", "1h"),
 	}
 }
 
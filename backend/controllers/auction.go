@@ -0,0 +1,272 @@
+//nolint:lll,gosimple
+package controllers
+
+import (
+	"avito/domain"
+	"avito/log"
+	"avito/utils"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+)
+
+// streamHeartbeat keeps the idle event streams alive behind the proxies.
+const streamHeartbeat = 15 * time.Second
+
+type AuctionService interface {
+	Set(ctx context.Context, username, tenderId string, req *domain.SetAuctionReq) (*domain.AuctionResp, error)
+	Get(ctx context.Context, username, tenderId string) (*domain.AuctionResp, error)
+	PlacePrice(ctx context.Context, username, bidId string, req *domain.PlacePriceReq) (*domain.AuctionPriceResp, error)
+	Ranking(ctx context.Context, username, tenderId string) ([]domain.AuctionRankingResp, error)
+	Subscribe(ctx context.Context, username, tenderId string) (<-chan domain.AuctionResp, func(), error)
+}
+
+type AuctionController struct {
+	log            log.Logger
+	auctionService AuctionService
+}
+
+func NewAuctionController(log log.Logger, auctionService AuctionService) *AuctionController {
+	return &AuctionController{log: log, auctionService: auctionService}
+}
+
+func (a *AuctionController) Set(ctx context.Context, req domain.SetAuctionReq, rd domain.RequestData) (*domain.AuctionResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	a.log.Info(ctx, "auction Set handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	auction, err := a.auctionService.Set(ctx, username, tenderId, &req)
+	if err == nil {
+		return auction, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user with this name does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrTenderIsNotPublished):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is closed", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidAuction):
+		return nil, &domain.HTTPError{Cause: err, Reason: "auction must end after it starts and in the future, sealed tenders can not be auctioned", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrAuctionStarted):
+		return nil, &domain.HTTPError{Cause: err, Reason: "auction can not be changed after the first price", Status: domain.BadRequestCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (a *AuctionController) Get(ctx context.Context, rd domain.RequestData) (*domain.AuctionResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	a.log.Info(ctx, "auction Get handler")
+
+	username, _ = ExtractQuery(rd.Request, "username", "")
+
+	auction, err := a.auctionService.Get(ctx, username, tenderId)
+	if err == nil {
+		return auction, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderNotAccessible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is available by invitation only", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrAuctionDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender has no auction", Status: domain.BadRequestCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (a *AuctionController) PlacePrice(ctx context.Context, req domain.PlacePriceReq, rd domain.RequestData) (*domain.AuctionPriceResp, *domain.HTTPError) {
+	var (
+		username, bidId string
+		ok              bool
+	)
+
+	if bidId, ok = ExtractParam(rd.Request, "bidId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "bidId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "bidId", bidId)
+	a.log.Info(ctx, "auction PlacePrice handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	price, err := a.auctionService.PlacePrice(ctx, username, bidId, &req)
+	if err == nil {
+		return price, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrBidDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user with this name does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrNotBidAuthor):
+		return nil, &domain.HTTPError{Cause: err, Reason: "you must be the bid author to place a price", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrBidIsNotPublished):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid is not published", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTechnicalStage):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is still at the technical stage", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrBidNotShortlisted):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid is not shortlisted for the commercial stage", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderIsNotPublished):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is not published", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrAuctionDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender has no auction", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrAuctionNotRunning):
+		return nil, &domain.HTTPError{Cause: err, Reason: "auction is not running", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidPrice):
+		return nil, &domain.HTTPError{Cause: err, Reason: "price must be positive", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrPriceStepTooSmall):
+		return nil, &domain.HTTPError{Cause: err, Reason: "price must beat the best price by the minimum step", Status: domain.BadRequestCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (a *AuctionController) Ranking(ctx context.Context, rd domain.RequestData) ([]domain.AuctionRankingResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	a.log.Info(ctx, "auction Ranking handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	ranking, err := a.auctionService.Ranking(ctx, username, tenderId)
+	if err == nil {
+		return ranking, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrAuctionDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender has no auction", Status: domain.BadRequestCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+// Stream sends the auction states as Server-Sent Events until the client goes away.
+// It is a plain handler because the response is written incrementally.
+func (a *AuctionController) Stream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenderId, ok := ExtractParam(r, "tenderId", "")
+	if !ok {
+		a.writeError(ctx, w, domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode})
+		return
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	a.log.Info(ctx, "auction Stream handler")
+
+	username, ok := ExtractQuery(r, "username", "")
+	if !ok {
+		a.writeError(ctx, w, domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode})
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		a.writeError(ctx, w, domain.HTTPError{Cause: nil, Reason: "streaming is not supported", Status: domain.ServerFailureCode})
+		return
+	}
+
+	updates, unsubscribe, err := a.auctionService.Subscribe(ctx, username, tenderId)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTenderDoesNotExist):
+			a.writeError(ctx, w, domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode})
+		case errors.Is(err, domain.ErrUserWithNameNotFound):
+			a.writeError(ctx, w, domain.HTTPError{Cause: err, Reason: "user with this name does not exist", Status: domain.BadRequestCode})
+		case errors.Is(err, domain.ErrBidsNotVisible):
+			a.writeError(ctx, w, domain.HTTPError{Cause: err, Reason: "only the tender organization and bidders can follow the auction", Status: domain.ForbiddenCode})
+		case errors.Is(err, domain.ErrAuctionDoesNotExist):
+			a.writeError(ctx, w, domain.HTTPError{Cause: err, Reason: "tender has no auction", Status: domain.BadRequestCode})
+		default:
+			a.writeError(ctx, w, domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode})
+		}
+		return
+	}
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(int(domain.SuccessCode))
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case state := <-updates:
+			if _, err := fmt.Fprint(w, "event: auction\ndata: "); err != nil {
+				return
+			}
+			if err := utils.EncodeJson(w, state); err != nil {
+				a.log.Error(ctx, "could not encode auction state")
+				return
+			}
+			if _, err := fmt.Fprint(w, "\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func (a *AuctionController) writeError(ctx context.Context, w http.ResponseWriter, httpErr domain.HTTPError) {
+	a.log.Warn(ctx, httpErr.String())
+	w.WriteHeader(int(httpErr.Status))
+	_, _ = w.Write([]byte(httpErr.String()))
+}
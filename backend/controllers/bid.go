@@ -12,7 +12,7 @@ import (
 type BidService interface {
 	Create(ctx context.Context, tender *domain.CreateBidReq) (*domain.CreateBidResp, error)
 	GetByUsername(ctx context.Context, offset, limit int, username string) ([]domain.GetBidResp, error)
-	GetByTenderId(ctx context.Context, offset, limit int, tenderId string) ([]domain.GetBidResp, error)
+	GetByTenderId(ctx context.Context, offset, limit int, tenderId, username string) ([]domain.GetBidResp, error)
 	GetStatus(ctx context.Context, bidId, username string) (string, error)
 	SetStatus(ctx context.Context, bidId, username, status string) (*domain.SetStatusBidResp, error)
 	Edit(ctx context.Context, username, bidId string, bid *domain.EditBidReq) (*domain.EditBidResp, error)
@@ -20,6 +20,11 @@ type BidService interface {
 	SubmitFeedback(ctx context.Context, content, bidId, authorUsername string) (*domain.FeedbackBidResp, error)
 	Rollback(ctx context.Context, username, tenderId string, version int) (*domain.RollbackBidResp, error)
 	Reviews(ctx context.Context, requesterName, authorName, tenderId string, offset, limit int) ([]domain.ReviewResp, error)
+	ConflictOverrides(ctx context.Context, username, tenderId string) ([]domain.ConflictOverrideResp, error)
+	Withdraw(ctx context.Context, username, bidId, reason string) (*domain.WithdrawBidResp, error)
+	Resubmit(ctx context.Context, username, bidId string) (*domain.ResubmitBidResp, error)
+	Content(ctx context.Context, username, bidId string) (*domain.BidContentResp, error)
+	Commercial(ctx context.Context, username, bidId string) (*domain.CommercialPartResp, error)
 }
 
 type BidController struct {
@@ -41,7 +46,38 @@ func (b *BidController) Create(ctx context.Context, createReq domain.CreateBidRe
 		return bid, nil
 	}
 
-	return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderIsNotPublished):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is not published", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidAuthorType):
+		return nil, &domain.HTTPError{Cause: err, Reason: "authorType must be User or Organization", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrAuthorDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "author with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrAuthorHasNoOrganization):
+		return nil, &domain.HTTPError{Cause: err, Reason: "organization author must be responsible for an organization", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrOrganizationRequired):
+		return nil, &domain.HTTPError{Cause: err, Reason: "organizationId is required for the author of several organizations", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "author does not belong to the organization", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrConflictOfInterest):
+		return nil, &domain.HTTPError{Cause: err, Reason: "author is responsible for the tender organization, justification is required", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrTenderNotAccessible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is available by invitation only", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrActiveBidExists):
+		return nil, &domain.HTTPError{Cause: err, Reason: "author already has an active bid on the tender", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrSubmissionsClosed):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid submissions of the sealed tender are closed", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTechnicalStageClosed):
+		return nil, &domain.HTTPError{Cause: err, Reason: "technical stage of the tender is over", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrCommercialPartRequired):
+		return nil, &domain.HTTPError{Cause: err, Reason: "commercial part is required by the two-stage tender", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderNotTwoStage):
+		return nil, &domain.HTTPError{Cause: err, Reason: "commercial part is accepted only by the two-stage tenders", Status: domain.BadRequestCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
 }
 
 func (b *BidController) GetByUsername(ctx context.Context, rd domain.RequestData) ([]domain.GetBidResp, *domain.HTTPError) {
@@ -114,7 +150,7 @@ func (b *BidController) GetByTenderId(ctx context.Context, rd domain.RequestData
 		limit = 0
 	}
 
-	bids, err := b.bidService.GetByTenderId(ctx, offset, limit, tenderId)
+	bids, err := b.bidService.GetByTenderId(ctx, offset, limit, tenderId, username)
 
 	if err == nil {
 		return bids, nil
@@ -123,6 +159,12 @@ func (b *BidController) GetByTenderId(ctx context.Context, rd domain.RequestData
 	switch {
 	case errors.Is(err, domain.ErrBidDoesNotExist):
 		return nil, &domain.HTTPError{Cause: err, Reason: "bid with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user with this name does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrBidsNotVisible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "only the tender organization and bid authors can see the bids", Status: domain.ForbiddenCode}
 	default:
 		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
 	}
@@ -191,6 +233,18 @@ func (b *BidController) SetStatus(ctx context.Context, rd domain.RequestData) (*
 		return nil, &domain.HTTPError{Cause: err, Reason: "you must be the author to edit status", Status: domain.BadRequestCode}
 	case errors.Is(err, domain.ErrForbiddenApproval):
 		return nil, &domain.HTTPError{Cause: err, Reason: "you can not self approve", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidBidStatus):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid can only be moved between Created and Published", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrResubmitRequired):
+		return nil, &domain.HTTPError{Cause: err, Reason: "withdrawn bid must be resubmitted", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderIsNotPublished):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is not published", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrActiveBidExists):
+		return nil, &domain.HTTPError{Cause: err, Reason: "author already has an active bid on the tender", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrSubmissionsClosed):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid submissions of the sealed tender are closed", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTechnicalStageClosed):
+		return nil, &domain.HTTPError{Cause: err, Reason: "technical stage of the tender is over", Status: domain.BadRequestCode}
 	default:
 		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
 	}
@@ -223,6 +277,12 @@ func (b *BidController) Edit(ctx context.Context, req domain.EditBidReq, rd doma
 		return nil, &domain.HTTPError{Cause: err, Reason: "bid with this id does not exist", Status: domain.BadRequestCode}
 	case errors.Is(err, domain.ErrNotBidAuthor):
 		return nil, &domain.HTTPError{Cause: err, Reason: "you must be the author to edit", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrSubmissionsClosed):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid submissions of the sealed tender are closed", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTechnicalStageClosed):
+		return nil, &domain.HTTPError{Cause: err, Reason: "technical stage of the tender is over", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderNotTwoStage):
+		return nil, &domain.HTTPError{Cause: err, Reason: "commercial part is accepted only by the two-stage tenders", Status: domain.BadRequestCode}
 	default:
 		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
 	}
@@ -266,6 +326,16 @@ func (b *BidController) SubmitDecision(ctx context.Context, rd domain.RequestDat
 	case errors.Is(err, domain.ErrUserNotResponsible):
 		return nil, &domain.HTTPError{Cause: err, Reason: "you must be from tender's organization to submit decision",
 			Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrTechnicalStage):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is still at the technical stage", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrBidNotShortlisted):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid is not shortlisted for the commercial stage", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrAuctionNotFinished):
+		return nil, &domain.HTTPError{Cause: err, Reason: "auction of the tender is not finished yet", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrNotAuctionWinner):
+		return nil, &domain.HTTPError{Cause: err, Reason: "only the best price of the auction can be approved", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrEnvelopesNotOpened):
+		return nil, &domain.HTTPError{Cause: err, Reason: "envelopes of the sealed tender are not opened yet", Status: domain.BadRequestCode}
 	default:
 		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
 	}
@@ -344,6 +414,10 @@ func (b *BidController) Rollback(ctx context.Context, rd domain.RequestData) (*d
 		return nil, &domain.HTTPError{Cause: err, Reason: "bid with this id does not exist", Status: domain.BadRequestCode}
 	case errors.Is(err, domain.ErrNotBidAuthor):
 		return nil, &domain.HTTPError{Cause: err, Reason: "you must be the bid author to roll it back", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrSubmissionsClosed):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid submissions of the sealed tender are closed", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTechnicalStageClosed):
+		return nil, &domain.HTTPError{Cause: err, Reason: "technical stage of the tender is over", Status: domain.BadRequestCode}
 	default:
 		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
 	}
@@ -401,3 +475,189 @@ func (b *BidController) Reviews(ctx context.Context, rd domain.RequestData) ([]d
 		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
 	}
 }
+
+func (b *BidController) ConflictOverrides(ctx context.Context, rd domain.RequestData) ([]domain.ConflictOverrideResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	b.log.Info(ctx, "bid ConflictOverrides handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	overrides, err := b.bidService.ConflictOverrides(ctx, username, tenderId)
+	if err == nil {
+		return overrides, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (b *BidController) Withdraw(ctx context.Context, req domain.WithdrawBidReq, rd domain.RequestData) (*domain.WithdrawBidResp, *domain.HTTPError) {
+	var (
+		username, bidId string
+		ok              bool
+	)
+
+	if bidId, ok = ExtractParam(rd.Request, "bidId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "bidId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "bidId", bidId)
+	b.log.Info(ctx, "bid Withdraw handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	bid, err := b.bidService.Withdraw(ctx, username, bidId, req.Reason)
+	if err == nil {
+		return bid, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrWithdrawReasonRequired):
+		return nil, &domain.HTTPError{Cause: err, Reason: "reason is required", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrBidDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user with this name does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrNotBidAuthor):
+		return nil, &domain.HTTPError{Cause: err, Reason: "you must be the bid author to withdraw it", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrBidIsNotActive):
+		return nil, &domain.HTTPError{Cause: err, Reason: "only created or published bid can be withdrawn", Status: domain.BadRequestCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (b *BidController) Resubmit(ctx context.Context, rd domain.RequestData) (*domain.ResubmitBidResp, *domain.HTTPError) {
+	var (
+		username, bidId string
+		ok              bool
+	)
+
+	if bidId, ok = ExtractParam(rd.Request, "bidId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "bidId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "bidId", bidId)
+	b.log.Info(ctx, "bid Resubmit handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	bid, err := b.bidService.Resubmit(ctx, username, bidId)
+	if err == nil {
+		return bid, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrBidDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrNotBidAuthor):
+		return nil, &domain.HTTPError{Cause: err, Reason: "you must be the bid author to resubmit it", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrSubmissionsClosed):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid submissions of the sealed tender are closed", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTechnicalStageClosed):
+		return nil, &domain.HTTPError{Cause: err, Reason: "technical stage of the tender is over", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderIsNotPublished):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is not published", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrBidIsNotWithdrawn):
+		return nil, &domain.HTTPError{Cause: err, Reason: "only withdrawn bid can be resubmitted", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrActiveBidExists):
+		return nil, &domain.HTTPError{Cause: err, Reason: "author already has an active bid on the tender", Status: domain.BadRequestCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (b *BidController) Content(ctx context.Context, rd domain.RequestData) (*domain.BidContentResp, *domain.HTTPError) {
+	var (
+		username, bidId string
+		ok              bool
+	)
+
+	if bidId, ok = ExtractParam(rd.Request, "bidId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "bidId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "bidId", bidId)
+	b.log.Info(ctx, "bid Content handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	content, err := b.bidService.Content(ctx, username, bidId)
+	if err == nil {
+		return content, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrBidDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user with this name does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "you must be the bid author or responsible for the tender organization", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrEnvelopesSealed):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid contents are sealed until the envelopes are opened", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (b *BidController) Commercial(ctx context.Context, rd domain.RequestData) (*domain.CommercialPartResp, *domain.HTTPError) {
+	var (
+		username, bidId string
+		ok              bool
+	)
+
+	if bidId, ok = ExtractParam(rd.Request, "bidId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "bidId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "bidId", bidId)
+	b.log.Info(ctx, "bid Commercial handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	part, err := b.bidService.Commercial(ctx, username, bidId)
+	if err == nil {
+		return part, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrBidDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderNotTwoStage):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is not two-stage", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "you must be the bid author or responsible for the tender organization", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrTechnicalStage):
+		return nil, &domain.HTTPError{Cause: err, Reason: "commercial parts are hidden until the technical stage is over", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrBidNotShortlisted):
+		return nil, &domain.HTTPError{Cause: err, Reason: "commercial part of the bid not shortlisted stays hidden", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
@@ -0,0 +1,144 @@
+//nolint:lll,gosimple
+package controllers
+
+import (
+	"avito/domain"
+	"avito/log"
+	"context"
+	"github.com/pkg/errors"
+)
+
+type ComplaintService interface {
+	File(ctx context.Context, username, tenderId string, req *domain.FileComplaintReq) (*domain.ComplaintResp, error)
+	GetByTenderId(ctx context.Context, username, tenderId string) ([]domain.ComplaintResp, error)
+	Get(ctx context.Context, username, complaintId string) (*domain.ComplaintResp, error)
+	Respond(ctx context.Context, username, complaintId, message string) (*domain.ComplaintResp, error)
+	SetStatus(ctx context.Context, username, complaintId, status, message string) (*domain.ComplaintResp, error)
+}
+
+type ComplaintController struct {
+	log              log.Logger
+	complaintService ComplaintService
+}
+
+func NewComplaintController(log log.Logger, complaintService ComplaintService) *ComplaintController {
+	return &ComplaintController{log: log, complaintService: complaintService}
+}
+
+func (c *ComplaintController) File(ctx context.Context, req domain.FileComplaintReq, rd domain.RequestData) (*domain.ComplaintResp, *domain.HTTPError) {
+	username, tenderId, httpErr := c.params(ctx, rd, "tenderId", "complaint File handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	complaint, err := c.complaintService.File(log.AddKeyVal(ctx, "tenderId", tenderId), username, tenderId, &req)
+	if err == nil {
+		return complaint, nil
+	}
+
+	return nil, complaintHTTPError(err)
+}
+
+func (c *ComplaintController) GetByTenderId(ctx context.Context, rd domain.RequestData) ([]domain.ComplaintResp, *domain.HTTPError) {
+	username, tenderId, httpErr := c.params(ctx, rd, "tenderId", "complaint GetByTenderId handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	complaints, err := c.complaintService.GetByTenderId(log.AddKeyVal(ctx, "tenderId", tenderId), username, tenderId)
+	if err == nil {
+		return complaints, nil
+	}
+
+	return nil, complaintHTTPError(err)
+}
+
+func (c *ComplaintController) Get(ctx context.Context, rd domain.RequestData) (*domain.ComplaintResp, *domain.HTTPError) {
+	username, complaintId, httpErr := c.params(ctx, rd, "complaintId", "complaint Get handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	complaint, err := c.complaintService.Get(log.AddKeyVal(ctx, "complaintId", complaintId), username, complaintId)
+	if err == nil {
+		return complaint, nil
+	}
+
+	return nil, complaintHTTPError(err)
+}
+
+func (c *ComplaintController) Respond(ctx context.Context, req domain.ComplaintResponseReq, rd domain.RequestData) (*domain.ComplaintResp, *domain.HTTPError) {
+	username, complaintId, httpErr := c.params(ctx, rd, "complaintId", "complaint Respond handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	complaint, err := c.complaintService.Respond(log.AddKeyVal(ctx, "complaintId", complaintId), username, complaintId, req.Message)
+	if err == nil {
+		return complaint, nil
+	}
+
+	return nil, complaintHTTPError(err)
+}
+
+func (c *ComplaintController) SetStatus(ctx context.Context, req domain.ComplaintResponseReq, rd domain.RequestData) (*domain.ComplaintResp, *domain.HTTPError) {
+	username, complaintId, httpErr := c.params(ctx, rd, "complaintId", "complaint SetStatus handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	status, ok := ExtractQuery(rd.Request, "status", "")
+	if !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "status is required query", Status: domain.BadRequestCode}
+	}
+
+	complaint, err := c.complaintService.SetStatus(log.AddKeyVal(ctx, "complaintId", complaintId), username, complaintId, status, req.Message)
+	if err == nil {
+		return complaint, nil
+	}
+
+	return nil, complaintHTTPError(err)
+}
+
+func (c *ComplaintController) params(ctx context.Context, rd domain.RequestData, param, msg string) (string, string, *domain.HTTPError) {
+	id, ok := ExtractParam(rd.Request, param, "")
+	if !ok {
+		return "", "", &domain.HTTPError{Cause: nil, Reason: param + " is required", Status: domain.BadRequestCode}
+	}
+
+	c.log.Info(log.AddKeyVal(ctx, param, id), msg)
+
+	username, ok := ExtractQuery(rd.Request, "username", "")
+	if !ok {
+		return "", "", &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	return username, id, nil
+}
+
+func complaintHTTPError(err error) *domain.HTTPError {
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrBidDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "bid with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrComplaintDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "complaint with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidComplaint):
+		return &domain.HTTPError{Cause: err, Reason: "complaint requires a reason and a not approved bid of the tender", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrComplaintWindowClosed):
+		return &domain.HTTPError{Cause: err, Reason: "complaint window of the tender is closed", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrComplaintAlreadyOpen):
+		return &domain.HTTPError{Cause: err, Reason: "bid already has an open complaint", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrComplaintTransition):
+		return &domain.HTTPError{Cause: err, Reason: "complaint can not be moved to this status", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrComplaintResponseMissing):
+		return &domain.HTTPError{Cause: err, Reason: "response message is required", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return &domain.HTTPError{Cause: err, Reason: "user with this username does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return &domain.HTTPError{Cause: err, Reason: "user is not responsible for this complaint", Status: domain.ForbiddenCode}
+	default:
+		return &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
@@ -0,0 +1,105 @@
+//nolint:lll,gosimple
+package controllers
+
+import (
+	"avito/domain"
+	"avito/log"
+	"context"
+	"github.com/pkg/errors"
+)
+
+type ContractService interface {
+	Get(ctx context.Context, username, tenderId string) (*domain.ContractResp, error)
+	Sign(ctx context.Context, username, tenderId, side string) (*domain.ContractResp, error)
+}
+
+type ContractController struct {
+	log             log.Logger
+	contractService ContractService
+}
+
+func NewContractController(log log.Logger, contractService ContractService) *ContractController {
+	return &ContractController{log: log, contractService: contractService}
+}
+
+func (c *ContractController) Get(ctx context.Context, rd domain.RequestData) (*domain.ContractResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	c.log.Info(ctx, "contract Get handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	contract, err := c.contractService.Get(ctx, username, tenderId)
+	if err == nil {
+		return contract, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrContractDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender has no contract", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user with this name does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "only the contract parties can see it", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (c *ContractController) Sign(ctx context.Context, rd domain.RequestData) (*domain.ContractResp, *domain.HTTPError) {
+	var (
+		username, tenderId, side string
+		ok                       bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	c.log.Info(ctx, "contract Sign handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	if side, ok = ExtractQuery(rd.Request, "side", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "side is required query", Status: domain.BadRequestCode}
+	}
+
+	contract, err := c.contractService.Sign(ctx, username, tenderId, side)
+	if err == nil {
+		return contract, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrContractDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender has no contract", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidContractSide):
+		return nil, &domain.HTTPError{Cause: err, Reason: "side must be Buyer or Supplier", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user with this name does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user can not sign the contract for this side", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrContractAlreadySigned):
+		return nil, &domain.HTTPError{Cause: err, Reason: "contract is already signed by this side", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrAwardFrozen):
+		return nil, &domain.HTTPError{Cause: err, Reason: "award is frozen by an open complaint", Status: domain.BadRequestCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
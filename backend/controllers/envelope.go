@@ -0,0 +1,93 @@
+//nolint:lll,gosimple
+package controllers
+
+import (
+	"avito/domain"
+	"avito/log"
+	"context"
+	"github.com/pkg/errors"
+)
+
+type EnvelopeService interface {
+	Open(ctx context.Context, username, tenderId string) (*domain.EnvelopeOpeningResp, error)
+	GetOpening(ctx context.Context, username, tenderId string) (*domain.EnvelopeOpeningResp, error)
+}
+
+type EnvelopeController struct {
+	log             log.Logger
+	envelopeService EnvelopeService
+}
+
+func NewEnvelopeController(log log.Logger, envelopeService EnvelopeService) *EnvelopeController {
+	return &EnvelopeController{log: log, envelopeService: envelopeService}
+}
+
+func (e *EnvelopeController) Open(ctx context.Context, rd domain.RequestData) (*domain.EnvelopeOpeningResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	e.log.Info(ctx, "envelope Open handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	opening, err := e.envelopeService.Open(ctx, username, tenderId)
+	if err == nil {
+		return opening, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user with this name does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrTenderNotSealed):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is not sealed", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrEnvelopesOpened):
+		return nil, &domain.HTTPError{Cause: err, Reason: "envelopes are already opened", Status: domain.BadRequestCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (e *EnvelopeController) GetOpening(ctx context.Context, rd domain.RequestData) (*domain.EnvelopeOpeningResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	e.log.Info(ctx, "envelope GetOpening handler")
+
+	username, _ = ExtractQuery(rd.Request, "username", "")
+
+	opening, err := e.envelopeService.GetOpening(ctx, username, tenderId)
+	if err == nil {
+		return opening, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderNotAccessible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is available by invitation only", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrEnvelopesNotOpened):
+		return nil, &domain.HTTPError{Cause: err, Reason: "envelopes are not opened yet", Status: domain.BadRequestCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
@@ -0,0 +1,235 @@
+//nolint:lll,gosimple
+package controllers
+
+import (
+	"avito/domain"
+	"avito/log"
+	"context"
+	"github.com/pkg/errors"
+)
+
+type EvaluationService interface {
+	SetCriteria(ctx context.Context, username, tenderId string, req *domain.SetCriteriaReq) ([]domain.CriterionResp, error)
+	GetCriteria(ctx context.Context, username, tenderId string) ([]domain.CriterionResp, error)
+	Score(ctx context.Context, username, bidId string, req *domain.ScoreBidReq) ([]domain.BidScoreResp, error)
+	Ranking(ctx context.Context, username, tenderId string) ([]domain.BidRankingResp, error)
+	Shortlist(ctx context.Context, username, tenderId string, req *domain.ShortlistReq) ([]domain.ShortlistedBidResp, error)
+	GetShortlist(ctx context.Context, username, tenderId string) ([]domain.ShortlistedBidResp, error)
+}
+
+type EvaluationController struct {
+	log               log.Logger
+	evaluationService EvaluationService
+}
+
+func NewEvaluationController(log log.Logger, evaluationService EvaluationService) *EvaluationController {
+	return &EvaluationController{log: log, evaluationService: evaluationService}
+}
+
+func (e *EvaluationController) SetCriteria(ctx context.Context, req domain.SetCriteriaReq, rd domain.RequestData) ([]domain.CriterionResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	e.log.Info(ctx, "evaluation SetCriteria handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	criteria, err := e.evaluationService.SetCriteria(ctx, username, tenderId, &req)
+	if err == nil {
+		return criteria, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrInvalidCriteria):
+		return nil, &domain.HTTPError{Cause: err, Reason: "criteria must have unique names and positive weights", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrCriteriaLocked):
+		return nil, &domain.HTTPError{Cause: err, Reason: "criteria can not be changed after scoring started or tender closed", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (e *EvaluationController) GetCriteria(ctx context.Context, rd domain.RequestData) ([]domain.CriterionResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	e.log.Info(ctx, "evaluation GetCriteria handler")
+
+	username, _ = ExtractQuery(rd.Request, "username", "")
+
+	criteria, err := e.evaluationService.GetCriteria(ctx, username, tenderId)
+	if err == nil {
+		return criteria, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderNotAccessible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is available by invitation only", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (e *EvaluationController) Score(ctx context.Context, req domain.ScoreBidReq, rd domain.RequestData) ([]domain.BidScoreResp, *domain.HTTPError) {
+	var (
+		username, bidId string
+		ok              bool
+	)
+
+	if bidId, ok = ExtractParam(rd.Request, "bidId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "bidId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "bidId", bidId)
+	e.log.Info(ctx, "evaluation Score handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	scores, err := e.evaluationService.Score(ctx, username, bidId, &req)
+	if err == nil {
+		return scores, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrBidDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrBidIsNotPublished):
+		return nil, &domain.HTTPError{Cause: err, Reason: "bid is not published", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrCriterionDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "criterion does not belong to the tender", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidScore):
+		return nil, &domain.HTTPError{Cause: err, Reason: "score must be between 0 and 10, one per criterion", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrScoresLocked):
+		return nil, &domain.HTTPError{Cause: err, Reason: "scores can not be changed after tender closed", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrEnvelopesNotOpened):
+		return nil, &domain.HTTPError{Cause: err, Reason: "envelopes of the sealed tender are not opened yet", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (e *EvaluationController) Ranking(ctx context.Context, rd domain.RequestData) ([]domain.BidRankingResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	e.log.Info(ctx, "evaluation Ranking handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	ranking, err := e.evaluationService.Ranking(ctx, username, tenderId)
+	if err == nil {
+		return ranking, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (e *EvaluationController) Shortlist(ctx context.Context, req domain.ShortlistReq, rd domain.RequestData) ([]domain.ShortlistedBidResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	e.log.Info(ctx, "evaluation Shortlist handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	shortlist, err := e.evaluationService.Shortlist(ctx, username, tenderId, &req)
+	if err == nil {
+		return shortlist, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderNotTwoStage):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is not two-stage", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTechnicalStageClosed):
+		return nil, &domain.HTTPError{Cause: err, Reason: "technical stage of the tender is already over", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidShortlist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "shortlist must contain distinct published bids of the tender", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (e *EvaluationController) GetShortlist(ctx context.Context, rd domain.RequestData) ([]domain.ShortlistedBidResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	e.log.Info(ctx, "evaluation GetShortlist handler")
+
+	username, _ = ExtractQuery(rd.Request, "username", "")
+
+	shortlist, err := e.evaluationService.GetShortlist(ctx, username, tenderId)
+	if err == nil {
+		return shortlist, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderNotAccessible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is available by invitation only", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
@@ -0,0 +1,96 @@
+//nolint:lll,gosimple
+package controllers
+
+import (
+	"avito/domain"
+	"avito/log"
+	"context"
+	"github.com/pkg/errors"
+)
+
+type FrameworkService interface {
+	IssueOrder(ctx context.Context, username, tenderId string, req *domain.CallOffOrderReq) (*domain.CallOffOrderResp, error)
+	GetOrders(ctx context.Context, username, tenderId string) ([]domain.CallOffOrderResp, error)
+}
+
+type FrameworkController struct {
+	log              log.Logger
+	frameworkService FrameworkService
+}
+
+func NewFrameworkController(log log.Logger, frameworkService FrameworkService) *FrameworkController {
+	return &FrameworkController{log: log, frameworkService: frameworkService}
+}
+
+func (f *FrameworkController) IssueOrder(ctx context.Context, req domain.CallOffOrderReq, rd domain.RequestData) (*domain.CallOffOrderResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	f.log.Info(ctx, "framework IssueOrder handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	order, err := f.frameworkService.IssueOrder(ctx, username, tenderId, &req)
+	if err == nil {
+		return order, nil
+	}
+
+	return nil, frameworkHTTPError(err)
+}
+
+func (f *FrameworkController) GetOrders(ctx context.Context, rd domain.RequestData) ([]domain.CallOffOrderResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	f.log.Info(ctx, "framework GetOrders handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	orders, err := f.frameworkService.GetOrders(ctx, username, tenderId)
+	if err == nil {
+		return orders, nil
+	}
+
+	return nil, frameworkHTTPError(err)
+}
+
+func frameworkHTTPError(err error) *domain.HTTPError {
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrBidDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "bid with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrNotFramework):
+		return &domain.HTTPError{Cause: err, Reason: "tender is not a framework agreement", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidCallOffOrder):
+		return &domain.HTTPError{Cause: err, Reason: "call-off order requires a description, a positive amount and an approved supplier", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderCanceled):
+		return &domain.HTTPError{Cause: err, Reason: "framework is canceled", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrAwardFrozen):
+		return &domain.HTTPError{Cause: err, Reason: "orders are frozen by an open complaint", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return &domain.HTTPError{Cause: err, Reason: "user with this username does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return &domain.HTTPError{Cause: err, Reason: "user is not responsible for the framework", Status: domain.ForbiddenCode}
+	default:
+		return &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
@@ -0,0 +1,128 @@
+//nolint:lll,gosimple
+package controllers
+
+import (
+	"avito/domain"
+	"avito/log"
+	"context"
+	"github.com/pkg/errors"
+	"strconv"
+)
+
+type InboxService interface {
+	Get(ctx context.Context, username string, unreadOnly bool, offset, limit int) ([]domain.InboxNotificationResp, error)
+	UnreadCount(ctx context.Context, username string) (*domain.InboxUnreadResp, error)
+	MarkRead(ctx context.Context, username, notificationId string) error
+	MarkAllRead(ctx context.Context, username string, ids []string) (*domain.InboxReadResp, error)
+}
+
+type InboxController struct {
+	log          log.Logger
+	inboxService InboxService
+}
+
+func NewInboxController(log log.Logger, inboxService InboxService) *InboxController {
+	return &InboxController{log: log, inboxService: inboxService}
+}
+
+func (c *InboxController) Get(ctx context.Context, rd domain.RequestData) ([]domain.InboxNotificationResp, *domain.HTTPError) {
+	username, httpErr := c.username(ctx, rd, "inbox Get handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	offsetStr, _ := ExtractQuery(rd.Request, "offset", "0")
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	limitStr, _ := ExtractQuery(rd.Request, "limit", "0")
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 0 {
+		limit = 0
+	}
+
+	unreadStr, _ := ExtractQuery(rd.Request, "unread", "false")
+	unreadOnly, err := strconv.ParseBool(unreadStr)
+	if err != nil {
+		return nil, &domain.HTTPError{Cause: err, Reason: "unread must be true or false", Status: domain.BadRequestCode}
+	}
+
+	notifications, err := c.inboxService.Get(ctx, username, unreadOnly, offset, limit)
+	if err == nil {
+		return notifications, nil
+	}
+
+	return nil, inboxHTTPError(err)
+}
+
+func (c *InboxController) UnreadCount(ctx context.Context, rd domain.RequestData) (*domain.InboxUnreadResp, *domain.HTTPError) {
+	username, httpErr := c.username(ctx, rd, "inbox UnreadCount handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	unread, err := c.inboxService.UnreadCount(ctx, username)
+	if err == nil {
+		return unread, nil
+	}
+
+	return nil, inboxHTTPError(err)
+}
+
+func (c *InboxController) MarkRead(ctx context.Context, rd domain.RequestData) (string, *domain.HTTPError) {
+	notificationId, ok := ExtractParam(rd.Request, "notificationId", "")
+	if !ok {
+		return "", &domain.HTTPError{Cause: nil, Reason: "notificationId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "notificationId", notificationId)
+	username, httpErr := c.username(ctx, rd, "inbox MarkRead handler")
+	if httpErr != nil {
+		return "", httpErr
+	}
+
+	err := c.inboxService.MarkRead(ctx, username, notificationId)
+	if err == nil {
+		return notificationId, nil
+	}
+
+	return "", inboxHTTPError(err)
+}
+
+func (c *InboxController) MarkAllRead(ctx context.Context, req domain.InboxReadReq, rd domain.RequestData) (*domain.InboxReadResp, *domain.HTTPError) {
+	username, httpErr := c.username(ctx, rd, "inbox MarkAllRead handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	read, err := c.inboxService.MarkAllRead(ctx, username, req.Ids)
+	if err == nil {
+		return read, nil
+	}
+
+	return nil, inboxHTTPError(err)
+}
+
+func (c *InboxController) username(ctx context.Context, rd domain.RequestData, msg string) (string, *domain.HTTPError) {
+	username, ok := ExtractQuery(rd.Request, "username", "")
+	if !ok {
+		return "", &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	c.log.Info(log.AddKeyVal(ctx, "username", username), msg)
+
+	return username, nil
+}
+
+func inboxHTTPError(err error) *domain.HTTPError {
+	switch {
+	case errors.Is(err, domain.ErrNotificationDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "notification with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return &domain.HTTPError{Cause: err, Reason: "user with this username does not exist", Status: domain.BadRequestCode}
+	default:
+		return &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
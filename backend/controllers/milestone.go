@@ -0,0 +1,185 @@
+//nolint:lll,gosimple
+package controllers
+
+import (
+	"avito/domain"
+	"avito/log"
+	"context"
+	"github.com/pkg/errors"
+)
+
+type MilestoneService interface {
+	Create(ctx context.Context, username, tenderId string, req *domain.CreateMilestoneReq) (*domain.MilestoneResp, error)
+	GetByTenderId(ctx context.Context, username, tenderId string) ([]domain.MilestoneResp, error)
+	Deliver(ctx context.Context, username, milestoneId string) (*domain.MilestoneResp, error)
+	Accept(ctx context.Context, username, milestoneId string) (*domain.MilestoneResp, error)
+	Dispute(ctx context.Context, username, milestoneId, reason string) (*domain.MilestoneResp, error)
+	Overdue(ctx context.Context, username, orgId string) ([]domain.MilestoneResp, error)
+}
+
+type MilestoneController struct {
+	log              log.Logger
+	milestoneService MilestoneService
+}
+
+func NewMilestoneController(log log.Logger, milestoneService MilestoneService) *MilestoneController {
+	return &MilestoneController{log: log, milestoneService: milestoneService}
+}
+
+func (m *MilestoneController) Create(ctx context.Context, req domain.CreateMilestoneReq, rd domain.RequestData) (*domain.MilestoneResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	m.log.Info(ctx, "milestone Create handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	milestone, err := m.milestoneService.Create(ctx, username, tenderId, &req)
+	if err == nil {
+		return milestone, nil
+	}
+
+	return nil, milestoneHTTPError(err)
+}
+
+func (m *MilestoneController) GetByTenderId(ctx context.Context, rd domain.RequestData) ([]domain.MilestoneResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	m.log.Info(ctx, "milestone GetByTenderId handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	milestones, err := m.milestoneService.GetByTenderId(ctx, username, tenderId)
+	if err == nil {
+		return milestones, nil
+	}
+
+	return nil, milestoneHTTPError(err)
+}
+
+func (m *MilestoneController) Deliver(ctx context.Context, rd domain.RequestData) (*domain.MilestoneResp, *domain.HTTPError) {
+	username, milestoneId, httpErr := m.milestoneParams(ctx, rd, "milestone Deliver handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	milestone, err := m.milestoneService.Deliver(log.AddKeyVal(ctx, "milestoneId", milestoneId), username, milestoneId)
+	if err == nil {
+		return milestone, nil
+	}
+
+	return nil, milestoneHTTPError(err)
+}
+
+func (m *MilestoneController) Accept(ctx context.Context, rd domain.RequestData) (*domain.MilestoneResp, *domain.HTTPError) {
+	username, milestoneId, httpErr := m.milestoneParams(ctx, rd, "milestone Accept handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	milestone, err := m.milestoneService.Accept(log.AddKeyVal(ctx, "milestoneId", milestoneId), username, milestoneId)
+	if err == nil {
+		return milestone, nil
+	}
+
+	return nil, milestoneHTTPError(err)
+}
+
+func (m *MilestoneController) Dispute(ctx context.Context, req domain.DisputeMilestoneReq, rd domain.RequestData) (*domain.MilestoneResp, *domain.HTTPError) {
+	username, milestoneId, httpErr := m.milestoneParams(ctx, rd, "milestone Dispute handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	milestone, err := m.milestoneService.Dispute(log.AddKeyVal(ctx, "milestoneId", milestoneId), username, milestoneId, req.Reason)
+	if err == nil {
+		return milestone, nil
+	}
+
+	return nil, milestoneHTTPError(err)
+}
+
+func (m *MilestoneController) Overdue(ctx context.Context, rd domain.RequestData) ([]domain.MilestoneResp, *domain.HTTPError) {
+	var (
+		username, orgId string
+		ok              bool
+	)
+
+	if orgId, ok = ExtractParam(rd.Request, "organizationId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "organizationId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "orgId", orgId)
+	m.log.Info(ctx, "milestone Overdue handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	milestones, err := m.milestoneService.Overdue(ctx, username, orgId)
+	if err == nil {
+		return milestones, nil
+	}
+
+	return nil, milestoneHTTPError(err)
+}
+
+func (m *MilestoneController) milestoneParams(ctx context.Context, rd domain.RequestData, msg string) (string, string, *domain.HTTPError) {
+	milestoneId, ok := ExtractParam(rd.Request, "milestoneId", "")
+	if !ok {
+		return "", "", &domain.HTTPError{Cause: nil, Reason: "milestoneId is required", Status: domain.BadRequestCode}
+	}
+
+	m.log.Info(log.AddKeyVal(ctx, "milestoneId", milestoneId), msg)
+
+	username, ok := ExtractQuery(rd.Request, "username", "")
+	if !ok {
+		return "", "", &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	return username, milestoneId, nil
+}
+
+func milestoneHTTPError(err error) *domain.HTTPError {
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrContractDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "tender has no contract", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrMilestoneDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "milestone with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidMilestone):
+		return &domain.HTTPError{Cause: err, Reason: "milestone requires a description, a due date and a non-negative amount", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrMilestoneTransition):
+		return &domain.HTTPError{Cause: err, Reason: "milestone can not be moved to this status", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrDisputeReasonRequired):
+		return &domain.HTTPError{Cause: err, Reason: "dispute reason is required", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrAwardFrozen):
+		return &domain.HTTPError{Cause: err, Reason: "award is frozen by an open complaint", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return &domain.HTTPError{Cause: err, Reason: "user with this username does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return &domain.HTTPError{Cause: err, Reason: "user is not the party of the contract", Status: domain.ForbiddenCode}
+	default:
+		return &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
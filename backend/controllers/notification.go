@@ -0,0 +1,73 @@
+//nolint:lll,gosimple
+package controllers
+
+import (
+	"avito/domain"
+	"avito/log"
+	"context"
+	"github.com/pkg/errors"
+)
+
+type NotificationService interface {
+	GetPreference(ctx context.Context, username string) (*domain.NotificationPreferenceResp, error)
+	SetPreference(ctx context.Context, username string, req *domain.NotificationPreferenceReq) (*domain.NotificationPreferenceResp, error)
+}
+
+type NotificationController struct {
+	log                 log.Logger
+	notificationService NotificationService
+}
+
+func NewNotificationController(log log.Logger, notificationService NotificationService) *NotificationController {
+	return &NotificationController{log: log, notificationService: notificationService}
+}
+
+func (c *NotificationController) GetPreference(ctx context.Context, rd domain.RequestData) (*domain.NotificationPreferenceResp, *domain.HTTPError) {
+	username, httpErr := c.username(ctx, rd, "notification GetPreference handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	preference, err := c.notificationService.GetPreference(ctx, username)
+	if err == nil {
+		return preference, nil
+	}
+
+	return nil, notificationHTTPError(err)
+}
+
+func (c *NotificationController) SetPreference(ctx context.Context, req domain.NotificationPreferenceReq, rd domain.RequestData) (*domain.NotificationPreferenceResp, *domain.HTTPError) {
+	username, httpErr := c.username(ctx, rd, "notification SetPreference handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	preference, err := c.notificationService.SetPreference(ctx, username, &req)
+	if err == nil {
+		return preference, nil
+	}
+
+	return nil, notificationHTTPError(err)
+}
+
+func (c *NotificationController) username(ctx context.Context, rd domain.RequestData, msg string) (string, *domain.HTTPError) {
+	username, ok := ExtractQuery(rd.Request, "username", "")
+	if !ok {
+		return "", &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	c.log.Info(log.AddKeyVal(ctx, "username", username), msg)
+
+	return username, nil
+}
+
+func notificationHTTPError(err error) *domain.HTTPError {
+	switch {
+	case errors.Is(err, domain.ErrInvalidNotificationPref):
+		return &domain.HTTPError{Cause: err, Reason: "email must be a valid address and locale must be ru or en", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return &domain.HTTPError{Cause: err, Reason: "user with this username does not exist", Status: domain.BadRequestCode}
+	default:
+		return &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
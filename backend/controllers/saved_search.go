@@ -0,0 +1,130 @@
+//nolint:lll,gosimple
+package controllers
+
+import (
+	"avito/domain"
+	"avito/log"
+	"context"
+	"github.com/pkg/errors"
+	"strconv"
+)
+
+type SavedSearchService interface {
+	Create(ctx context.Context, username string, req *domain.CreateSavedSearchReq) (*domain.SavedSearchResp, error)
+	GetSearches(ctx context.Context, username string) ([]domain.SavedSearchResp, error)
+	Delete(ctx context.Context, username, searchId string) error
+	GetAlerts(ctx context.Context, username, searchId string, offset, limit int) ([]domain.TenderAlertResp, error)
+}
+
+type SavedSearchController struct {
+	log                log.Logger
+	savedSearchService SavedSearchService
+}
+
+func NewSavedSearchController(log log.Logger, savedSearchService SavedSearchService) *SavedSearchController {
+	return &SavedSearchController{log: log, savedSearchService: savedSearchService}
+}
+
+func (c *SavedSearchController) Create(ctx context.Context, req domain.CreateSavedSearchReq, rd domain.RequestData) (*domain.SavedSearchResp, *domain.HTTPError) {
+	username, httpErr := c.username(ctx, rd, "saved search Create handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	search, err := c.savedSearchService.Create(ctx, username, &req)
+	if err == nil {
+		return search, nil
+	}
+
+	return nil, savedSearchHTTPError(err)
+}
+
+func (c *SavedSearchController) GetSearches(ctx context.Context, rd domain.RequestData) ([]domain.SavedSearchResp, *domain.HTTPError) {
+	username, httpErr := c.username(ctx, rd, "saved search GetSearches handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	searches, err := c.savedSearchService.GetSearches(ctx, username)
+	if err == nil {
+		return searches, nil
+	}
+
+	return nil, savedSearchHTTPError(err)
+}
+
+func (c *SavedSearchController) Delete(ctx context.Context, rd domain.RequestData) (string, *domain.HTTPError) {
+	searchId, ok := ExtractParam(rd.Request, "searchId", "")
+	if !ok {
+		return "", &domain.HTTPError{Cause: nil, Reason: "searchId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "searchId", searchId)
+	username, httpErr := c.username(ctx, rd, "saved search Delete handler")
+	if httpErr != nil {
+		return "", httpErr
+	}
+
+	err := c.savedSearchService.Delete(ctx, username, searchId)
+	if err == nil {
+		return searchId, nil
+	}
+
+	return "", savedSearchHTTPError(err)
+}
+
+func (c *SavedSearchController) GetAlerts(ctx context.Context, rd domain.RequestData) ([]domain.TenderAlertResp, *domain.HTTPError) {
+	username, httpErr := c.username(ctx, rd, "saved search GetAlerts handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	offsetStr, _ := ExtractQuery(rd.Request, "offset", "0")
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	limitStr, _ := ExtractQuery(rd.Request, "limit", "0")
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 0 {
+		limit = 0
+	}
+
+	searchId, _ := ExtractQuery(rd.Request, "searchId", "")
+
+	alerts, err := c.savedSearchService.GetAlerts(ctx, username, searchId, offset, limit)
+	if err == nil {
+		return alerts, nil
+	}
+
+	return nil, savedSearchHTTPError(err)
+}
+
+func (c *SavedSearchController) username(ctx context.Context, rd domain.RequestData, msg string) (string, *domain.HTTPError) {
+	username, ok := ExtractQuery(rd.Request, "username", "")
+	if !ok {
+		return "", &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	c.log.Info(log.AddKeyVal(ctx, "username", username), msg)
+
+	return username, nil
+}
+
+func savedSearchHTTPError(err error) *domain.HTTPError {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSavedSearch):
+		return &domain.HTTPError{Cause: err, Reason: "search requires a name, non-blank keywords and a non-negative budget range", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidServiceType):
+		return &domain.HTTPError{Cause: err, Reason: "service type does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrOrganizationDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "organization does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrSavedSearchDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "saved search with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return &domain.HTTPError{Cause: err, Reason: "user with this username does not exist", Status: domain.BadRequestCode}
+	default:
+		return &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
@@ -0,0 +1,124 @@
+//nolint:lll,gosimple
+package controllers
+
+import (
+	"avito/domain"
+	"avito/log"
+	"context"
+	"github.com/pkg/errors"
+)
+
+type ServiceTypeService interface {
+	GetAll(ctx context.Context) ([]domain.ServiceTypeResp, error)
+	Create(ctx context.Context, username string, req *domain.CreateServiceTypeReq) (*domain.ServiceTypeResp, error)
+	Update(ctx context.Context, username, code string, req *domain.UpdateServiceTypeReq) (*domain.ServiceTypeResp, error)
+	Delete(ctx context.Context, username, code string) error
+}
+
+type ServiceTypeController struct {
+	log                log.Logger
+	serviceTypeService ServiceTypeService
+}
+
+func NewServiceTypeController(log log.Logger, serviceTypeService ServiceTypeService) *ServiceTypeController {
+	return &ServiceTypeController{log: log, serviceTypeService: serviceTypeService}
+}
+
+func (s *ServiceTypeController) GetAll(ctx context.Context, rd domain.RequestData) ([]domain.ServiceTypeResp, *domain.HTTPError) {
+	s.log.Info(ctx, "service type GetAll handler")
+
+	serviceTypes, err := s.serviceTypeService.GetAll(ctx)
+	if err == nil {
+		return serviceTypes, nil
+	}
+
+	return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+}
+
+func (s *ServiceTypeController) Create(ctx context.Context, req domain.CreateServiceTypeReq, rd domain.RequestData) (*domain.ServiceTypeResp, *domain.HTTPError) {
+	var (
+		username string
+		ok       bool
+	)
+
+	ctx = log.AddKeyVal(ctx, "code", req.Code)
+	s.log.Info(ctx, "service type Create handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	serviceType, err := s.serviceTypeService.Create(ctx, username, &req)
+	if err == nil {
+		return serviceType, nil
+	}
+
+	return nil, serviceTypeHTTPError(err)
+}
+
+func (s *ServiceTypeController) Update(ctx context.Context, req domain.UpdateServiceTypeReq, rd domain.RequestData) (*domain.ServiceTypeResp, *domain.HTTPError) {
+	var (
+		username, code string
+		ok             bool
+	)
+
+	if code, ok = ExtractParam(rd.Request, "code", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "code is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "code", code)
+	s.log.Info(ctx, "service type Update handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	serviceType, err := s.serviceTypeService.Update(ctx, username, code, &req)
+	if err == nil {
+		return serviceType, nil
+	}
+
+	return nil, serviceTypeHTTPError(err)
+}
+
+func (s *ServiceTypeController) Delete(ctx context.Context, rd domain.RequestData) (string, *domain.HTTPError) {
+	var (
+		username, code string
+		ok             bool
+	)
+
+	if code, ok = ExtractParam(rd.Request, "code", ""); !ok {
+		return "", &domain.HTTPError{Cause: nil, Reason: "code is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "code", code)
+	s.log.Info(ctx, "service type Delete handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return "", &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	err := s.serviceTypeService.Delete(ctx, username, code)
+	if err == nil {
+		return code, nil
+	}
+
+	return "", serviceTypeHTTPError(err)
+}
+
+func serviceTypeHTTPError(err error) *domain.HTTPError {
+	switch {
+	case errors.Is(err, domain.ErrNotAdmin):
+		return &domain.HTTPError{Cause: err, Reason: "only administrators can manage service types", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrServiceTypeExists):
+		return &domain.HTTPError{Cause: err, Reason: "service type with this code already exists", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrServiceTypeDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "service type with this code does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidServiceTypeParent):
+		return &domain.HTTPError{Cause: err, Reason: "parent service type does not exist or makes a cycle", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrServiceTypeInUse):
+		return &domain.HTTPError{Cause: err, Reason: "service type is used by tenders, templates or child types", Status: domain.BadRequestCode}
+	default:
+		return &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
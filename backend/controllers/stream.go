@@ -0,0 +1,160 @@
+//nolint:lll,gosimple
+package controllers
+
+import (
+	"avito/domain"
+	"avito/log"
+	"avito/utils"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type StreamService interface {
+	Subscribe(ctx context.Context, username string, scope domain.StreamScope, lastEventId int64) (*domain.StreamSubscription, error)
+}
+
+type StreamController struct {
+	log           log.Logger
+	streamService StreamService
+}
+
+func NewStreamController(log log.Logger, streamService StreamService) *StreamController {
+	return &StreamController{log: log, streamService: streamService}
+}
+
+// Tender streams the changes of the tender and its bids to the tender organization and the bidders.
+func (c *StreamController) Tender(w http.ResponseWriter, r *http.Request) {
+	tenderId, ok := ExtractParam(r, "tenderId", "")
+	if !ok {
+		c.writeError(r.Context(), w, domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode})
+		return
+	}
+
+	ctx := log.AddKeyVal(r.Context(), "tenderId", tenderId)
+	c.log.Info(ctx, "stream Tender handler")
+
+	c.stream(ctx, w, r, domain.StreamScope{TenderId: tenderId})
+}
+
+// Organization streams the changes of the organization tenders and their bids to its employees.
+func (c *StreamController) Organization(w http.ResponseWriter, r *http.Request) {
+	orgId, ok := ExtractParam(r, "organizationId", "")
+	if !ok {
+		c.writeError(r.Context(), w, domain.HTTPError{Cause: nil, Reason: "organizationId is required", Status: domain.BadRequestCode})
+		return
+	}
+
+	ctx := log.AddKeyVal(r.Context(), "organizationId", orgId)
+	c.log.Info(ctx, "stream Organization handler")
+
+	c.stream(ctx, w, r, domain.StreamScope{OrganizationId: orgId})
+}
+
+// MyBids streams the changes of the bids authored by the user.
+func (c *StreamController) MyBids(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	c.log.Info(ctx, "stream MyBids handler")
+
+	c.stream(ctx, w, r, domain.StreamScope{})
+}
+
+// stream sends the events as Server-Sent Events with their outbox ids, the browser sends the last
+// received id back in the Last-Event-ID header on reconnect and the stream resumes after it.
+func (c *StreamController) stream(ctx context.Context, w http.ResponseWriter, r *http.Request, scope domain.StreamScope) {
+	username, ok := ExtractQuery(r, "username", "")
+	if !ok {
+		c.writeError(ctx, w, domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode})
+		return
+	}
+
+	var lastEventId int64
+	if header := r.Header.Get("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseInt(header, 10, 64)
+		if err != nil || id < 0 {
+			c.writeError(ctx, w, domain.HTTPError{Cause: err, Reason: "Last-Event-ID must be an event id", Status: domain.BadRequestCode})
+			return
+		}
+		lastEventId = id
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		c.writeError(ctx, w, domain.HTTPError{Cause: nil, Reason: "streaming is not supported", Status: domain.ServerFailureCode})
+		return
+	}
+
+	subscription, err := c.streamService.Subscribe(ctx, username, scope, lastEventId)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTenderDoesNotExist):
+			c.writeError(ctx, w, domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode})
+		case errors.Is(err, domain.ErrUserWithNameNotFound):
+			c.writeError(ctx, w, domain.HTTPError{Cause: err, Reason: "user with this name does not exist", Status: domain.BadRequestCode})
+		case errors.Is(err, domain.ErrBidsNotVisible):
+			c.writeError(ctx, w, domain.HTTPError{Cause: err, Reason: "only the tender organization and bidders can follow the tender", Status: domain.ForbiddenCode})
+		case errors.Is(err, domain.ErrUserNotResponsible):
+			c.writeError(ctx, w, domain.HTTPError{Cause: err, Reason: "user is not responsible for this organization", Status: domain.ForbiddenCode})
+		default:
+			c.writeError(ctx, w, domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode})
+		}
+		return
+	}
+	defer subscription.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(int(domain.SuccessCode))
+
+	for i := range subscription.Backlog {
+		if err = c.writeEvent(ctx, w, &subscription.Backlog[i]); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-subscription.Updates:
+			if !ok {
+				c.log.Warn(ctx, "stream subscriber fell behind")
+				return
+			}
+			if err := c.writeEvent(ctx, w, &event); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func (c *StreamController) writeEvent(ctx context.Context, w http.ResponseWriter, event *domain.StreamEventResp) error {
+	if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: ", event.Id, event.Type); err != nil {
+		return err
+	}
+	if err := utils.EncodeJson(w, event); err != nil {
+		c.log.Error(ctx, "could not encode stream event")
+		return err
+	}
+	_, err := fmt.Fprint(w, "\n")
+	return err
+}
+
+func (c *StreamController) writeError(ctx context.Context, w http.ResponseWriter, httpErr domain.HTTPError) {
+	c.log.Warn(ctx, httpErr.String())
+	w.WriteHeader(int(httpErr.Status))
+	_, _ = w.Write([]byte(httpErr.String()))
+}
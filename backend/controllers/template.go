@@ -0,0 +1,172 @@
+//nolint:lll,gosimple
+package controllers
+
+import (
+	"avito/domain"
+	"avito/log"
+	"context"
+	"github.com/pkg/errors"
+)
+
+type TemplateService interface {
+	Create(ctx context.Context, username, orgId string, req *domain.TemplateReq) (*domain.TemplateResp, error)
+	GetByOrganization(ctx context.Context, username, orgId string) ([]domain.TemplateResp, error)
+	GetById(ctx context.Context, username, templateId string) (*domain.TemplateResp, error)
+	Update(ctx context.Context, username, templateId string, req *domain.TemplateReq) (*domain.TemplateResp, error)
+	Delete(ctx context.Context, username, templateId string) error
+}
+
+type TemplateController struct {
+	log             log.Logger
+	templateService TemplateService
+}
+
+func NewTemplateController(log log.Logger, templateService TemplateService) *TemplateController {
+	return &TemplateController{log: log, templateService: templateService}
+}
+
+func (t *TemplateController) Create(ctx context.Context, req domain.TemplateReq, rd domain.RequestData) (*domain.TemplateResp, *domain.HTTPError) {
+	var (
+		username, orgId string
+		ok              bool
+	)
+
+	if orgId, ok = ExtractParam(rd.Request, "organizationId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "organizationId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "orgId", orgId)
+	t.log.Info(ctx, "template Create handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	template, err := t.templateService.Create(ctx, username, orgId, &req)
+	if err == nil {
+		return template, nil
+	}
+
+	return nil, templateHTTPError(err)
+}
+
+func (t *TemplateController) GetByOrganization(ctx context.Context, rd domain.RequestData) ([]domain.TemplateResp, *domain.HTTPError) {
+	var (
+		username, orgId string
+		ok              bool
+	)
+
+	if orgId, ok = ExtractParam(rd.Request, "organizationId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "organizationId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "orgId", orgId)
+	t.log.Info(ctx, "template GetByOrganization handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	templates, err := t.templateService.GetByOrganization(ctx, username, orgId)
+	if err == nil {
+		return templates, nil
+	}
+
+	return nil, templateHTTPError(err)
+}
+
+func (t *TemplateController) GetById(ctx context.Context, rd domain.RequestData) (*domain.TemplateResp, *domain.HTTPError) {
+	var (
+		username, templateId string
+		ok                   bool
+	)
+
+	if templateId, ok = ExtractParam(rd.Request, "templateId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "templateId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "templateId", templateId)
+	t.log.Info(ctx, "template GetById handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	template, err := t.templateService.GetById(ctx, username, templateId)
+	if err == nil {
+		return template, nil
+	}
+
+	return nil, templateHTTPError(err)
+}
+
+func (t *TemplateController) Update(ctx context.Context, req domain.TemplateReq, rd domain.RequestData) (*domain.TemplateResp, *domain.HTTPError) {
+	var (
+		username, templateId string
+		ok                   bool
+	)
+
+	if templateId, ok = ExtractParam(rd.Request, "templateId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "templateId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "templateId", templateId)
+	t.log.Info(ctx, "template Update handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	template, err := t.templateService.Update(ctx, username, templateId, &req)
+	if err == nil {
+		return template, nil
+	}
+
+	return nil, templateHTTPError(err)
+}
+
+func (t *TemplateController) Delete(ctx context.Context, rd domain.RequestData) (string, *domain.HTTPError) {
+	var (
+		username, templateId string
+		ok                   bool
+	)
+
+	if templateId, ok = ExtractParam(rd.Request, "templateId", ""); !ok {
+		return "", &domain.HTTPError{Cause: nil, Reason: "templateId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "templateId", templateId)
+	t.log.Info(ctx, "template Delete handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return "", &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	err := t.templateService.Delete(ctx, username, templateId)
+	if err == nil {
+		return templateId, nil
+	}
+
+	return "", templateHTTPError(err)
+}
+
+func templateHTTPError(err error) *domain.HTTPError {
+	switch {
+	case errors.Is(err, domain.ErrTemplateDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "template with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTemplateNameTaken):
+		return &domain.HTTPError{Cause: err, Reason: "template with this name already exists", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidCriteria):
+		return &domain.HTTPError{Cause: err, Reason: "criteria must have unique names and positive weights", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidServiceType):
+		return &domain.HTTPError{Cause: err, Reason: "service type is invalid", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrOrganizationDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "organization with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return &domain.HTTPError{Cause: err, Reason: "user with this username does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	default:
+		return &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
@@ -11,12 +11,21 @@ import (
 
 type TenderService interface {
 	Create(ctx context.Context, tender *domain.CreateTenderReq) (*domain.CreateTenderResp, error)
-	GetPublished(ctx context.Context, offset, limit int, types []string) ([]domain.GetTendersResp, error)
+	CreateFromTemplate(ctx context.Context, templateId string, req *domain.CreateTenderFromTemplateReq) (*domain.CreateTenderResp, error)
+	GetPublished(ctx context.Context, offset, limit int, types []string, username string) ([]domain.GetTendersResp, error)
 	GetByUsername(ctx context.Context, offset, limit int, username string) ([]domain.GetTendersResp, error)
 	GetStatus(ctx context.Context, tenderId, username string) (string, error)
 	SetStatus(ctx context.Context, tenderId, status, username string) (*domain.SetStatusTenderResp, error)
 	Edit(ctx context.Context, username, tenderId string, tender *domain.EditTenderReq) (*domain.EditTenderResp, error)
 	Rollback(ctx context.Context, username string, tenderId string, version int) (*domain.RollbackTenderResp, error)
+	SetVisibility(ctx context.Context, tenderId, visibility, username string) (*domain.SetVisibilityTenderResp, error)
+	Invite(ctx context.Context, username, tenderId string, req *domain.InviteTenderReq) (*domain.TenderInvitationResp, error)
+	GetInvitations(ctx context.Context, username, tenderId string) ([]domain.TenderInvitationResp, error)
+	RevokeInvitation(ctx context.Context, username, tenderId, invitationId string) error
+	Schedule(ctx context.Context, username, tenderId string, req *domain.ScheduleTenderReq) (*domain.ScheduleTenderResp, error)
+	CancelSchedule(ctx context.Context, username, tenderId string) (*domain.ScheduleTenderResp, error)
+	Cancel(ctx context.Context, username, tenderId, reason string) (*domain.TenderCancellationResp, error)
+	GetCancellation(ctx context.Context, username, tenderId string) (*domain.TenderCancellationResp, error)
 }
 
 type TenderController struct {
@@ -44,6 +53,52 @@ func (t *TenderController) Create(ctx context.Context, createReq domain.CreateTe
 		return nil, &domain.HTTPError{Cause: err, Reason: "organization with this id does not exist", Status: domain.BadRequestCode}
 	case errors.Is(err, domain.ErrUserNotResponsible):
 		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrInvalidVisibility):
+		return nil, &domain.HTTPError{Cause: err, Reason: "visibility must be Public or InviteOnly", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidSchedule):
+		return nil, &domain.HTTPError{Cause: err, Reason: "schedule must be in the future and close after publication", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidSeal):
+		return nil, &domain.HTTPError{Cause: err, Reason: "sealed tender requires a bid deadline in the future before closing", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidServiceType):
+		return nil, &domain.HTTPError{Cause: err, Reason: "service type does not exist", Status: domain.BadRequestCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (t *TenderController) CreateFromTemplate(ctx context.Context, req domain.CreateTenderFromTemplateReq, rd domain.RequestData) (*domain.CreateTenderResp, *domain.HTTPError) {
+	var (
+		templateId string
+		ok         bool
+	)
+
+	if templateId, ok = ExtractParam(rd.Request, "templateId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "templateId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "templateId", templateId)
+	t.log.Info(ctx, "tender CreateFromTemplate handler")
+
+	tender, err := t.tenderService.CreateFromTemplate(ctx, templateId, &req)
+	if err == nil {
+		return tender, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTemplateDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "template with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user with this username does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrInvalidVisibility):
+		return nil, &domain.HTTPError{Cause: err, Reason: "visibility must be Public or InviteOnly", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidSchedule):
+		return nil, &domain.HTTPError{Cause: err, Reason: "schedule must be in the future and close after publication", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidSeal):
+		return nil, &domain.HTTPError{Cause: err, Reason: "sealed tender requires a bid deadline in the future before closing", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidServiceType):
+		return nil, &domain.HTTPError{Cause: err, Reason: "service type does not exist", Status: domain.BadRequestCode}
 	default:
 		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
 	}
@@ -51,12 +106,16 @@ func (t *TenderController) Create(ctx context.Context, createReq domain.CreateTe
 
 func (t *TenderController) GetPublished(ctx context.Context, rd domain.RequestData) ([]domain.GetTendersResp, *domain.HTTPError) {
 	var (
+		username            string
 		offsetStr, limitStr string
 		offset, limit       int
 		types               []string
 		err                 error
 	)
 
+	username, _ = ExtractQuery(rd.Request, "username", "")
+
+	ctx = log.AddKeyVal(ctx, "username", username)
 	t.log.Info(ctx, "tender get handler")
 
 	offsetStr, _ = ExtractQuery(rd.Request, "offset", "0")
@@ -71,7 +130,7 @@ func (t *TenderController) GetPublished(ctx context.Context, rd domain.RequestDa
 
 	types, _ = ExtractQueryMany(rd.Request, "service_type")
 
-	resp, err := t.tenderService.GetPublished(ctx, offset, limit, types)
+	resp, err := t.tenderService.GetPublished(ctx, offset, limit, types, username)
 
 	if err == nil {
 		return resp, nil
@@ -140,6 +199,8 @@ func (t *TenderController) GetStatus(ctx context.Context, rd domain.RequestData)
 	switch {
 	case errors.Is(err, domain.ErrTenderDoesNotExist):
 		return "", &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderNotAccessible):
+		return "", &domain.HTTPError{Cause: err, Reason: "tender is available by invitation only", Status: domain.ForbiddenCode}
 	case errors.Is(err, domain.ErrUserNotResponsible):
 		return "", &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
 	default:
@@ -207,8 +268,12 @@ func (t *TenderController) Edit(ctx context.Context, req domain.EditTenderReq, r
 	switch {
 	case errors.Is(err, domain.ErrTenderDoesNotExist):
 		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidSchedule):
+		return nil, &domain.HTTPError{Cause: err, Reason: "schedule must be in the future and close after publication", Status: domain.BadRequestCode}
 	case errors.Is(err, domain.ErrUserNotResponsible):
 		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	case errors.Is(err, domain.ErrInvalidServiceType):
+		return nil, &domain.HTTPError{Cause: err, Reason: "service type does not exist", Status: domain.BadRequestCode}
 	default:
 		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
 	}
@@ -255,3 +320,287 @@ func (t *TenderController) Rollback(ctx context.Context, rd domain.RequestData)
 		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
 	}
 }
+
+func (t *TenderController) SetVisibility(ctx context.Context, rd domain.RequestData) (*domain.SetVisibilityTenderResp, *domain.HTTPError) {
+	var (
+		username, tenderId, visibility string
+		ok                             bool
+	)
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	if visibility, ok = ExtractQuery(rd.Request, "visibility", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "visibility is required query", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	t.log.Info(ctx, "tender SetVisibility handler")
+
+	tender, err := t.tenderService.SetVisibility(ctx, tenderId, visibility, username)
+	if err == nil {
+		return tender, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrInvalidVisibility):
+		return nil, &domain.HTTPError{Cause: err, Reason: "visibility must be Public or InviteOnly", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (t *TenderController) Invite(ctx context.Context, req domain.InviteTenderReq, rd domain.RequestData) (*domain.TenderInvitationResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	t.log.Info(ctx, "tender Invite handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	invitation, err := t.tenderService.Invite(ctx, username, tenderId, &req)
+	if err == nil {
+		return invitation, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrInvalidInvitation):
+		return nil, &domain.HTTPError{Cause: err, Reason: "either organizationId or userId must be specified", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInviteeDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "invited organization or user does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrAlreadyInvited):
+		return nil, &domain.HTTPError{Cause: err, Reason: "organization or user is already invited", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (t *TenderController) GetInvitations(ctx context.Context, rd domain.RequestData) ([]domain.TenderInvitationResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	t.log.Info(ctx, "tender GetInvitations handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	invitations, err := t.tenderService.GetInvitations(ctx, username, tenderId)
+	if err == nil {
+		return invitations, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (t *TenderController) RevokeInvitation(ctx context.Context, rd domain.RequestData) (string, *domain.HTTPError) {
+	var (
+		username, tenderId, invitationId string
+		ok                               bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return "", &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	if invitationId, ok = ExtractParam(rd.Request, "invitationId", ""); !ok {
+		return "", &domain.HTTPError{Cause: nil, Reason: "invitationId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	t.log.Info(ctx, "tender RevokeInvitation handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return "", &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	err := t.tenderService.RevokeInvitation(ctx, username, tenderId, invitationId)
+	if err == nil {
+		return invitationId, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrInvitationDoesNotExist):
+		return "", &domain.HTTPError{Cause: err, Reason: "invitation with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return "", &domain.HTTPError{Cause: err, Reason: "user with this username does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return "", &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	default:
+		return "", &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (t *TenderController) Schedule(ctx context.Context, req domain.ScheduleTenderReq, rd domain.RequestData) (*domain.ScheduleTenderResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	t.log.Info(ctx, "tender Schedule handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	tender, err := t.tenderService.Schedule(ctx, username, tenderId, &req)
+	if err == nil {
+		return tender, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrInvalidSchedule):
+		return nil, &domain.HTTPError{Cause: err, Reason: "schedule must be in the future and close after publication", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (t *TenderController) CancelSchedule(ctx context.Context, rd domain.RequestData) (*domain.ScheduleTenderResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	t.log.Info(ctx, "tender CancelSchedule handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	tender, err := t.tenderService.CancelSchedule(ctx, username, tenderId)
+	if err == nil {
+		return tender, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (t *TenderController) Cancel(ctx context.Context, req domain.CancelTenderReq, rd domain.RequestData) (*domain.TenderCancellationResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	t.log.Info(ctx, "tender Cancel handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	cancellation, err := t.tenderService.Cancel(ctx, username, tenderId, req.Reason)
+	if err == nil {
+		return cancellation, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrCancelReasonRequired):
+		return nil, &domain.HTTPError{Cause: err, Reason: "reason is required", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrCancelReasonTooLong):
+		return nil, &domain.HTTPError{Cause: err, Reason: "reason must be at most 1000 characters", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderIsClosed):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is already closed", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "user does not belong to org", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
+
+func (t *TenderController) GetCancellation(ctx context.Context, rd domain.RequestData) (*domain.TenderCancellationResp, *domain.HTTPError) {
+	var (
+		username, tenderId string
+		ok                 bool
+	)
+
+	if tenderId, ok = ExtractParam(rd.Request, "tenderId", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "tenderId is required", Status: domain.BadRequestCode}
+	}
+
+	ctx = log.AddKeyVal(ctx, "tenderId", tenderId)
+	t.log.Info(ctx, "tender GetCancellation handler")
+
+	if username, ok = ExtractQuery(rd.Request, "username", ""); !ok {
+		return nil, &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	cancellation, err := t.tenderService.GetCancellation(ctx, username, tenderId)
+	if err == nil {
+		return cancellation, nil
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTenderNotCanceled):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender was not canceled", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderDoesNotExist):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrTenderNotAccessible):
+		return nil, &domain.HTTPError{Cause: err, Reason: "tender is available by invitation only", Status: domain.ForbiddenCode}
+	default:
+		return nil, &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
@@ -0,0 +1,154 @@
+//nolint:lll,gosimple
+package controllers
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/log"
+	"context"
+	"github.com/pkg/errors"
+)
+
+type WebhookService interface {
+	Create(ctx context.Context, username, orgId string, req *domain.CreateWebhookReq) (*domain.WebhookResp, error)
+	GetByOrganization(ctx context.Context, username, orgId string) ([]domain.WebhookResp, error)
+	Delete(ctx context.Context, username, webhookId string) error
+	Deliveries(ctx context.Context, username, webhookId, status string) ([]domain.WebhookDeliveryResp, error)
+	DeadLetters(ctx context.Context, username, orgId string) ([]domain.WebhookDeliveryResp, error)
+	Replay(ctx context.Context, username, deliveryId string) (*domain.WebhookDeliveryResp, error)
+}
+
+type WebhookController struct {
+	log            log.Logger
+	webhookService WebhookService
+}
+
+func NewWebhookController(log log.Logger, webhookService WebhookService) *WebhookController {
+	return &WebhookController{log: log, webhookService: webhookService}
+}
+
+func (c *WebhookController) Create(ctx context.Context, req domain.CreateWebhookReq, rd domain.RequestData) (*domain.WebhookResp, *domain.HTTPError) {
+	username, orgId, httpErr := c.params(ctx, rd, "organizationId", "webhook Create handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	webhook, err := c.webhookService.Create(log.AddKeyVal(ctx, "organizationId", orgId), username, orgId, &req)
+	if err == nil {
+		return webhook, nil
+	}
+
+	return nil, webhookHTTPError(err)
+}
+
+func (c *WebhookController) GetByOrganization(ctx context.Context, rd domain.RequestData) ([]domain.WebhookResp, *domain.HTTPError) {
+	username, orgId, httpErr := c.params(ctx, rd, "organizationId", "webhook GetByOrganization handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	webhooks, err := c.webhookService.GetByOrganization(log.AddKeyVal(ctx, "organizationId", orgId), username, orgId)
+	if err == nil {
+		return webhooks, nil
+	}
+
+	return nil, webhookHTTPError(err)
+}
+
+func (c *WebhookController) Delete(ctx context.Context, rd domain.RequestData) (string, *domain.HTTPError) {
+	username, webhookId, httpErr := c.params(ctx, rd, "webhookId", "webhook Delete handler")
+	if httpErr != nil {
+		return "", httpErr
+	}
+
+	err := c.webhookService.Delete(log.AddKeyVal(ctx, "webhookId", webhookId), username, webhookId)
+	if err == nil {
+		return webhookId, nil
+	}
+
+	return "", webhookHTTPError(err)
+}
+
+func (c *WebhookController) Deliveries(ctx context.Context, rd domain.RequestData) ([]domain.WebhookDeliveryResp, *domain.HTTPError) {
+	username, webhookId, httpErr := c.params(ctx, rd, "webhookId", "webhook Deliveries handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	status, _ := ExtractQuery(rd.Request, "status", "")
+	switch model.WebhookDeliveryStatus(status) {
+	case "", model.WebhookDeliveryPending, model.WebhookDeliveryDelivered, model.WebhookDeliveryDead:
+	default:
+		return nil, &domain.HTTPError{Cause: nil, Reason: "status must be Pending, Delivered or Dead", Status: domain.BadRequestCode}
+	}
+
+	deliveries, err := c.webhookService.Deliveries(log.AddKeyVal(ctx, "webhookId", webhookId), username, webhookId, status)
+	if err == nil {
+		return deliveries, nil
+	}
+
+	return nil, webhookHTTPError(err)
+}
+
+func (c *WebhookController) DeadLetters(ctx context.Context, rd domain.RequestData) ([]domain.WebhookDeliveryResp, *domain.HTTPError) {
+	username, orgId, httpErr := c.params(ctx, rd, "organizationId", "webhook DeadLetters handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	deliveries, err := c.webhookService.DeadLetters(log.AddKeyVal(ctx, "organizationId", orgId), username, orgId)
+	if err == nil {
+		return deliveries, nil
+	}
+
+	return nil, webhookHTTPError(err)
+}
+
+func (c *WebhookController) Replay(ctx context.Context, rd domain.RequestData) (*domain.WebhookDeliveryResp, *domain.HTTPError) {
+	username, deliveryId, httpErr := c.params(ctx, rd, "deliveryId", "webhook Replay handler")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	delivery, err := c.webhookService.Replay(log.AddKeyVal(ctx, "deliveryId", deliveryId), username, deliveryId)
+	if err == nil {
+		return delivery, nil
+	}
+
+	return nil, webhookHTTPError(err)
+}
+
+func (c *WebhookController) params(ctx context.Context, rd domain.RequestData, param, msg string) (string, string, *domain.HTTPError) {
+	id, ok := ExtractParam(rd.Request, param, "")
+	if !ok {
+		return "", "", &domain.HTTPError{Cause: nil, Reason: param + " is required", Status: domain.BadRequestCode}
+	}
+
+	c.log.Info(log.AddKeyVal(ctx, param, id), msg)
+
+	username, ok := ExtractQuery(rd.Request, "username", "")
+	if !ok {
+		return "", "", &domain.HTTPError{Cause: nil, Reason: "username is required query", Status: domain.BadRequestCode}
+	}
+
+	return username, id, nil
+}
+
+func webhookHTTPError(err error) *domain.HTTPError {
+	switch {
+	case errors.Is(err, domain.ErrWebhookDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "webhook with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrDeliveryDoesNotExist):
+		return &domain.HTTPError{Cause: err, Reason: "webhook delivery with this id does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrInvalidWebhook):
+		return &domain.HTTPError{Cause: err, Reason: "webhook requires an http(s) url, known event types and a secret of at least 16 characters", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrDeliveryNotFailed):
+		return &domain.HTTPError{Cause: err, Reason: "only dead deliveries can be replayed", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserWithNameNotFound):
+		return &domain.HTTPError{Cause: err, Reason: "user with this username does not exist", Status: domain.BadRequestCode}
+	case errors.Is(err, domain.ErrUserNotResponsible):
+		return &domain.HTTPError{Cause: err, Reason: "user is not responsible for this organization", Status: domain.ForbiddenCode}
+	default:
+		return &domain.HTTPError{Cause: err, Reason: "server error", Status: domain.ServerFailureCode}
+	}
+}
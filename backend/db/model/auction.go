@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// Auction is the live reverse auction of the tender: during the window the bidders
+// keep lowering their prices, each new price must beat the best one by MinStep.
+type Auction struct {
+	TenderId string    `db:"tender_id"`
+	StartAt  time.Time `db:"start_at"`
+	EndAt    time.Time `db:"end_at"`
+	MinStep  float64   `db:"min_step"`
+	// ExtensionSeconds is the anti-sniping window: a price placed within it before
+	// the end moves the end so that the others have the time to respond.
+	ExtensionSeconds int       `db:"extension_seconds"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+type AuctionState struct {
+	BestPrice *float64 `db:"best_price"`
+	Prices    int      `db:"prices"`
+}
+
+type AuctionPrice struct {
+	Id        string    `db:"id"`
+	TenderId  string    `db:"tender_id"`
+	BidId     string    `db:"bid_id"`
+	Price     float64   `db:"price"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type AuctionRanking struct {
+	BidId    string    `db:"bid_id"`
+	Price    float64   `db:"price"`
+	PlacedAt time.Time `db:"placed_at"`
+}
@@ -37,6 +37,57 @@ type Bid struct {
 	TenderId    string        `db:"tender_id"`
 	AuthorType  BidAuthorType `db:"author_type"`
 	AuthorId    string        `db:"author_id"`
-	Version     int           `db:"version"`
-	CreatedAt   time.Time     `db:"created_at"`
+	// OrganizationId is set for the bids authored by an organization.
+	OrganizationId *string   `db:"organization_id"`
+	Version        int       `db:"version"`
+	CreatedAt      time.Time `db:"created_at"`
+	// SealedContent holds the encrypted name and description of the bids on sealed tenders.
+	SealedContent []byte `db:"sealed_content"`
+	// Commercial is the price part of the bids on two-stage tenders.
+	Commercial *CommercialPart `db:"-"`
+}
+
+type CommercialPart struct {
+	BidId     string    `db:"bid_id"`
+	Price     float64   `db:"price"`
+	Terms     string    `db:"terms"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type ShortlistEntry struct {
+	BidId     string    `db:"bid_id"`
+	UserId    string    `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type BidContent struct {
+	BidId         string `db:"bid_id"`
+	Version       int    `db:"version"`
+	Name          string `db:"name"`
+	Description   string `db:"description"`
+	SealedContent []byte `db:"sealed_content"`
+}
+
+type BidWithdrawal struct {
+	Id        string    `db:"id"`
+	BidId     string    `db:"bid_id"`
+	Reason    string    `db:"reason"`
+	UserId    string    `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type ConflictOverride struct {
+	Id             string    `db:"id"`
+	BidId          string    `db:"bid_id"`
+	TenderId       string    `db:"tender_id"`
+	UserId         string    `db:"user_id"`
+	OrganizationId *string   `db:"organization_id"`
+	Justification  string    `db:"justification"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// BidPseudonym is the stable name of the bid author shown to the evaluators of the anonymized tender.
+type BidPseudonym struct {
+	AuthorId  string `db:"author_id"`
+	Pseudonym string `db:"pseudonym"`
 }
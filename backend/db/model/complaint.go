@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+type ComplaintStatus string
+
+const (
+	ComplaintStatusFiled       ComplaintStatus = "Filed"
+	ComplaintStatusUnderReview ComplaintStatus = "UnderReview"
+	ComplaintStatusUpheld      ComplaintStatus = "Upheld"
+	ComplaintStatusDismissed   ComplaintStatus = "Dismissed"
+)
+
+// Complaint is filed by a bid author against the decision on the closed tender.
+// The award of the tender is frozen while a complaint with FreezeAward is open.
+type Complaint struct {
+	Id          string          `db:"id"`
+	TenderId    string          `db:"tender_id"`
+	BidId       string          `db:"bid_id"`
+	UserId      string          `db:"user_id"`
+	Reason      string          `db:"reason"`
+	FreezeAward bool            `db:"freeze_award"`
+	Status      ComplaintStatus `db:"status"`
+	ResolvedAt  *time.Time      `db:"resolved_at"`
+	CreatedAt   time.Time       `db:"created_at"`
+}
+
+type ComplaintResponse struct {
+	Id          string `db:"id"`
+	ComplaintId string `db:"complaint_id"`
+	UserId      string `db:"user_id"`
+	// Status is set for the responses that moved the complaint to it.
+	Status    *ComplaintStatus `db:"status"`
+	Message   string           `db:"message"`
+	CreatedAt time.Time        `db:"created_at"`
+}
@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+type ContractStatus string
+
+const (
+	ContractStatusPending ContractStatus = "Pending"
+	ContractStatusSigned  ContractStatus = "Signed"
+)
+
+// ContractSide is the party of the contract: the tender organization buys from the bid author.
+type ContractSide string
+
+const (
+	ContractSideBuyer    ContractSide = "Buyer"
+	ContractSideSupplier ContractSide = "Supplier"
+)
+
+// Contract is awarded to the approved bid. Price is the best auction price or the commercial
+// part of the bid if the tender had any.
+type Contract struct {
+	Id                     string        `db:"id"`
+	TenderId               string        `db:"tender_id"`
+	BidId                  string        `db:"bid_id"`
+	BidVersion             int           `db:"bid_version"`
+	Price                  *float64      `db:"price"`
+	BuyerOrganizationId    string        `db:"buyer_organization_id"`
+	SupplierType           BidAuthorType `db:"supplier_type"`
+	SupplierId             string        `db:"supplier_id"`
+	SupplierOrganizationId *string       `db:"supplier_organization_id"`
+	BuyerSignerId          *string       `db:"buyer_signer_id"`
+	BuyerSignedAt          *time.Time    `db:"buyer_signed_at"`
+	SupplierSignerId       *string       `db:"supplier_signer_id"`
+	SupplierSignedAt       *time.Time    `db:"supplier_signed_at"`
+	CreatedAt              time.Time     `db:"created_at"`
+}
+
+type MilestoneStatus string
+
+const (
+	MilestoneStatusPending   MilestoneStatus = "Pending"
+	MilestoneStatusDelivered MilestoneStatus = "Delivered"
+	MilestoneStatusAccepted  MilestoneStatus = "Accepted"
+	MilestoneStatusDisputed  MilestoneStatus = "Disputed"
+)
+
+// Milestone is a delivery of the awarded contract: the supplier delivers it and the buyer
+// either accepts the delivery or disputes it, the disputed milestone can be delivered again.
+type Milestone struct {
+	Id            string          `db:"id"`
+	ContractId    string          `db:"contract_id"`
+	TenderId      string          `db:"tender_id"`
+	Description   string          `db:"description"`
+	DueDate       time.Time       `db:"due_date"`
+	Amount        float64         `db:"amount"`
+	Status        MilestoneStatus `db:"status"`
+	DeliveredAt   *time.Time      `db:"delivered_at"`
+	ResolvedAt    *time.Time      `db:"resolved_at"`
+	DisputeReason *string         `db:"dispute_reason"`
+	CreatedAt     time.Time       `db:"created_at"`
+}
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+type Criterion struct {
+	Id        string    `db:"id"`
+	TenderId  string    `db:"tender_id"`
+	Name      string    `db:"name"`
+	Weight    float64   `db:"weight"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type BidScore struct {
+	Id          string    `db:"id"`
+	BidId       string    `db:"bid_id"`
+	CriterionId string    `db:"criterion_id"`
+	UserId      string    `db:"user_id"`
+	Score       int       `db:"score"`
+	Comment     string    `db:"comment"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+type BidRanking struct {
+	BidId          string        `db:"bid_id"`
+	Name           string        `db:"name"`
+	Status         BidStatus     `db:"status"`
+	AuthorType     BidAuthorType `db:"author_type"`
+	AuthorId       string        `db:"author_id"`
+	Score          float64       `db:"score"`
+	ScoredCriteria int           `db:"scored_criteria"`
+}
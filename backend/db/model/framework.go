@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// CallOffOrder is issued by the organization to an approved supplier of the framework agreement.
+type CallOffOrder struct {
+	Id           string     `db:"id"`
+	TenderId     string     `db:"tender_id"`
+	BidId        string     `db:"bid_id"`
+	UserId       string     `db:"user_id"`
+	Description  string     `db:"description"`
+	Amount       float64    `db:"amount"`
+	DeliveryDate *time.Time `db:"delivery_date"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
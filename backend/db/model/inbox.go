@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// InboxNotification is the event shown in the inbox of the employee it concerns.
+type InboxNotification struct {
+	Id         string       `db:"id"`
+	EventId    int64        `db:"event_id"`
+	Type       EventType    `db:"type"`
+	TenderId   string       `db:"tender_id"`
+	TenderName string       `db:"tender_name"`
+	Anonymized bool         `db:"anonymized"`
+	BidId      *string      `db:"bid_id"`
+	Payload    EventPayload `db:"payload"`
+	ReadAt     *time.Time   `db:"read_at"`
+	CreatedAt  time.Time    `db:"created_at"`
+}
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+type Locale string
+
+const (
+	LocaleRu Locale = "ru"
+	LocaleEn Locale = "en"
+)
+
+type EmailStatus string
+
+const (
+	EmailStatusPending EmailStatus = "Pending"
+	EmailStatusSent    EmailStatus = "Sent"
+	EmailStatusDead    EmailStatus = "Dead"
+)
+
+// NotificationPreference is how the user is notified, the users without an email get no emails.
+type NotificationPreference struct {
+	UserId      string     `db:"user_id"`
+	Email       *string    `db:"email"`
+	Locale      Locale     `db:"locale"`
+	EmailOptOut bool       `db:"email_opt_out"`
+	UpdatedAt   *time.Time `db:"updated_at"`
+}
+
+// EmailRecipient is the event to notify the user about with the data of the email.
+type EmailRecipient struct {
+	OutboxEvent
+	UserId     string `db:"user_id"`
+	Email      string `db:"email"`
+	Locale     Locale `db:"locale"`
+	TenderName string `db:"tender_name"`
+}
+
+type EmailNotification struct {
+	Id            string      `db:"id"`
+	UserId        string      `db:"user_id"`
+	EventId       int64       `db:"event_id"`
+	Email         string      `db:"email"`
+	Subject       string      `db:"subject"`
+	Body          string      `db:"body"`
+	Status        EmailStatus `db:"status"`
+	Attempts      int         `db:"attempts"`
+	NextAttemptAt time.Time   `db:"next_attempt_at"`
+	LastError     *string     `db:"last_error"`
+	SentAt        *time.Time  `db:"sent_at"`
+	CreatedAt     time.Time   `db:"created_at"`
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventTenderCreated   EventType = "TenderCreated"
+	EventTenderPublished EventType = "TenderPublished"
+	EventTenderClosed    EventType = "TenderClosed"
+	EventTenderEdited    EventType = "TenderEdited"
+	EventTenderCanceled  EventType = "TenderCanceled"
+	EventTenderInvited   EventType = "TenderInvited"
+	EventBidCreated      EventType = "BidCreated"
+	EventBidSubmitted    EventType = "BidSubmitted"
+	EventBidEdited       EventType = "BidEdited"
+	EventBidWithdrawn    EventType = "BidWithdrawn"
+	EventBidResubmitted  EventType = "BidResubmitted"
+	EventBidApproved     EventType = "BidApproved"
+	EventBidRejected     EventType = "BidRejected"
+	EventBidFeedback     EventType = "BidFeedback"
+)
+
+// OutboxEvent is the domain event written in the same transaction as the change.
+// The ids grow in the commit order, the sinks track the last delivered id.
+type OutboxEvent struct {
+	Id        int64        `db:"id"`
+	Type      EventType    `db:"type"`
+	TenderId  string       `db:"tender_id"`
+	BidId     *string      `db:"bid_id"`
+	Payload   EventPayload `db:"payload"`
+	CreatedAt time.Time    `db:"created_at"`
+	// TenderVersion and BidVersion are the versions after the change, empty for the events written before they were kept.
+	TenderVersion *int `db:"tender_version"`
+	BidVersion    *int `db:"bid_version"`
+}
+
+// StreamEvent is the outbox event with the parties it concerns, the streams filter the events by them.
+type StreamEvent struct {
+	OutboxEvent
+	OrganizationId string  `db:"organization_id"`
+	BidAuthorId    *string `db:"bid_author_id"`
+}
+
+// EventPayload is the JSON body of the event, the fields are set by the event type.
+type EventPayload struct {
+	Status string `json:"status,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// Feedback is the text of the feedback left on the bid.
+	Feedback string `json:"feedback,omitempty"`
+	// InvitationId is the invitation to the invite-only tender.
+	InvitationId string `json:"invitationId,omitempty"`
+	// Username is the employee who made the change, empty for the scheduled changes.
+	Username string `json:"username,omitempty"`
+}
+
+func (p EventPayload) Value() (driver.Value, error) {
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(payload), nil
+}
+
+func (p *EventPayload) Scan(src any) error {
+	switch src := src.(type) {
+	case []byte:
+		return json.Unmarshal(src, p)
+	case string:
+		return json.Unmarshal([]byte(src), p)
+	case nil:
+		*p = EventPayload{}
+		return nil
+	default:
+		return errors.Errorf("unsupported event payload type %T", src)
+	}
+}
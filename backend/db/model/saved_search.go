@@ -0,0 +1,62 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// SearchTerms is the JSON array of the service types or the keywords of a saved search.
+type SearchTerms []string
+
+func (t SearchTerms) Value() (driver.Value, error) {
+	if t == nil {
+		t = SearchTerms{}
+	}
+
+	terms, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(terms), nil
+}
+
+func (t *SearchTerms) Scan(src any) error {
+	switch src := src.(type) {
+	case []byte:
+		return json.Unmarshal(src, t)
+	case string:
+		return json.Unmarshal([]byte(src), t)
+	default:
+		return errors.Errorf("unsupported search terms type %T", src)
+	}
+}
+
+// SavedSearch is the criteria the published tenders are matched against. The empty criteria
+// match any tender: the service types include their child types, every keyword must occur
+// in the name or the description of the tender and the budget range needs the tender budget.
+type SavedSearch struct {
+	Id             string      `db:"id"`
+	UserId         string      `db:"user_id"`
+	Name           string      `db:"name"`
+	ServiceTypes   SearchTerms `db:"service_types"`
+	Keywords       SearchTerms `db:"keywords"`
+	BudgetMin      *float64    `db:"budget_min"`
+	BudgetMax      *float64    `db:"budget_max"`
+	OrganizationId *string     `db:"organization_id"`
+	CreatedAt      time.Time   `db:"created_at"`
+}
+
+// TenderAlert is the published tender matched by the saved search.
+type TenderAlert struct {
+	Id             string            `db:"id"`
+	SavedSearchId  string            `db:"saved_search_id"`
+	SearchName     string            `db:"search_name"`
+	TenderId       string            `db:"tender_id"`
+	TenderName     string            `db:"tender_name"`
+	ServiceType    TenderServiceType `db:"service_type"`
+	Budget         *float64          `db:"budget"`
+	OrganizationId string            `db:"organization_id"`
+	CreatedAt      time.Time         `db:"created_at"`
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+type ServiceType struct {
+	Code       string    `db:"code"`
+	Name       string    `db:"name"`
+	ParentCode *string   `db:"parent_code"`
+	CreatedAt  time.Time `db:"created_at"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+type TenderTemplate struct {
+	Id             string              `db:"id"`
+	OrganizationId string              `db:"organization_id"`
+	Name           string              `db:"name"`
+	Description    string              `db:"description"`
+	ServiceType    TenderServiceType   `db:"service_type"`
+	Budget         *float64            `db:"budget"`
+	CreatedAt      time.Time           `db:"created_at"`
+	UpdatedAt      time.Time           `db:"updated_at"`
+	Criteria       []TemplateCriterion `db:"-"`
+}
+
+type TemplateCriterion struct {
+	TemplateId string  `db:"template_id"`
+	Name       string  `db:"name"`
+	Weight     float64 `db:"weight"`
+}
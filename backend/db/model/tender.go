@@ -3,6 +3,8 @@ package model
 
 import "time"
 
+// TenderServiceType is a code from the service_type catalogue.
+// The constants are the codes seeded by the migrations.
 type TenderServiceType string
 
 const (
@@ -19,14 +21,67 @@ const (
 	TenderStatusClosed    TenderStatus = "Closed"
 )
 
+type TenderVisibility string
+
+const (
+	TenderVisibilityPublic     TenderVisibility = "Public"
+	TenderVisibilityInviteOnly TenderVisibility = "InviteOnly"
+)
+
+// TenderStage is the evaluation stage of the two-stage tenders: the commercial parts
+// of the bids are revealed after the technical stage produces the shortlist.
+type TenderStage string
+
+const (
+	TenderStageTechnical  TenderStage = "Technical"
+	TenderStageCommercial TenderStage = "Commercial"
+)
+
 type Tender struct {
 	Id             string            `db:"id"`
 	Name           string            `db:"name"`
 	Description    string            `db:"description"`
 	ServiceType    TenderServiceType `db:"service_type"`
 	Status         TenderStatus      `db:"status"`
+	Visibility     TenderVisibility  `db:"visibility"`
 	OrganizationId string            `db:"organization_id"`
 	Version        int               `db:"version"`
 	CreatedAt      time.Time         `db:"created_at"`
 	UserId         string            `db:"user_id"`
+	PublishAt      *time.Time        `db:"publish_at"`
+	CloseAt        *time.Time        `db:"close_at"`
+	Budget         *float64          `db:"budget"`
+	TemplateId     *string           `db:"template_id"`
+	// Sealed tenders keep the bid contents encrypted until the envelopes are opened.
+	Sealed      bool         `db:"sealed"`
+	BidDeadline *time.Time   `db:"bid_deadline"`
+	TwoStage    bool         `db:"two_stage"`
+	Stage       *TenderStage `db:"stage"`
+	// Anonymized tenders show the evaluators pseudonyms of the bid authors until the tender is closed.
+	Anonymized bool `db:"anonymized"`
+	// Framework tenders approve several suppliers and stay open, the organization then issues call-off orders to them.
+	Framework bool `db:"framework"`
+}
+
+type TenderInvitation struct {
+	Id             string    `db:"id"`
+	TenderId       string    `db:"tender_id"`
+	OrganizationId *string   `db:"organization_id"`
+	UserId         *string   `db:"user_id"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+type TenderCancellation struct {
+	Id        string    `db:"id"`
+	TenderId  string    `db:"tender_id"`
+	Reason    string    `db:"reason"`
+	UserId    string    `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type EnvelopeOpening struct {
+	TenderId string `db:"tender_id"`
+	// UserId is empty when the envelopes were opened automatically at the bid deadline.
+	UserId    *string   `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
 }
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "Pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "Delivered"
+	WebhookDeliveryDead      WebhookDeliveryStatus = "Dead"
+)
+
+// EventTypes is the JSON array of the event types a webhook is subscribed to.
+type EventTypes []EventType
+
+func (t EventTypes) Value() (driver.Value, error) {
+	types, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(types), nil
+}
+
+func (t *EventTypes) Scan(src any) error {
+	switch src := src.(type) {
+	case []byte:
+		return json.Unmarshal(src, t)
+	case string:
+		return json.Unmarshal([]byte(src), t)
+	default:
+		return errors.Errorf("unsupported event types type %T", src)
+	}
+}
+
+// Webhook delivers the events of the organization tenders to the URL.
+// The secret signing the deliveries is encrypted with the master key.
+type Webhook struct {
+	Id             string     `db:"id"`
+	OrganizationId string     `db:"organization_id"`
+	Url            string     `db:"url"`
+	EventTypes     EventTypes `db:"event_types"`
+	Secret         []byte     `db:"secret"`
+	UserId         string     `db:"user_id"`
+	CreatedAt      time.Time  `db:"created_at"`
+}
+
+type WebhookDelivery struct {
+	Id             string                `db:"id"`
+	WebhookId      string                `db:"webhook_id"`
+	EventId        int64                 `db:"event_id"`
+	EventType      EventType             `db:"event_type"`
+	Status         WebhookDeliveryStatus `db:"status"`
+	Attempts       int                   `db:"attempts"`
+	NextAttemptAt  time.Time             `db:"next_attempt_at"`
+	LastError      *string               `db:"last_error"`
+	ResponseStatus *int                  `db:"response_status"`
+	DeliveredAt    *time.Time            `db:"delivered_at"`
+	CreatedAt      time.Time             `db:"created_at"`
+}
+
+// WebhookDispatch is the delivery claimed for an attempt with its webhook and event.
+type WebhookDispatch struct {
+	DeliveryId string `db:"delivery_id"`
+	Attempts   int    `db:"attempts"`
+	Url        string `db:"url"`
+	Secret     []byte `db:"secret"`
+	// Anonymized hides the bidders of the tender from the tender organization.
+	Anonymized bool `db:"anonymized"`
+	OutboxEvent
+}
@@ -34,12 +34,122 @@ func NewManager(db db.Transactional,
 type decisionTx struct {
 	*repository.BidRep
 	*repository.TenderRep
+	*repository.ContractRep
+	*repository.OutboxRep
 }
 
 func (m Manager) DecisionTransaction(ctx context.Context, pTx func(ctx context.Context, tx service.DecisionTransaction) error) error {
 	return m.db.RunInTransaction(ctx, func(ctx context.Context, tx *db.Tx) error {
 		bidRepo := repository.NewBidRep(m.logger, tx, m.bidIdsStorage, m.usernameIdMatchStorage)
 		tenderRepo := repository.NewTenderRep(m.logger, tx, m.tenderIdsStorage, m.usernameIdMatchStorage)
-		return pTx(ctx, &decisionTx{bidRepo, tenderRepo})
+		contractRepo := repository.NewContractRep(m.logger, tx)
+		outboxRepo := repository.NewOutboxRep(m.logger, tx)
+		return pTx(ctx, &decisionTx{bidRepo, tenderRepo, contractRepo, outboxRepo})
+	})
+}
+
+type cancelTx struct {
+	*repository.BidRep
+	*repository.TenderRep
+	*repository.OutboxRep
+}
+
+func (m Manager) CancelTransaction(ctx context.Context, pTx func(ctx context.Context, tx service.CancelTransaction) error) error {
+	return m.db.RunInTransaction(ctx, func(ctx context.Context, tx *db.Tx) error {
+		bidRepo := repository.NewBidRep(m.logger, tx, m.bidIdsStorage, m.usernameIdMatchStorage)
+		tenderRepo := repository.NewTenderRep(m.logger, tx, m.tenderIdsStorage, m.usernameIdMatchStorage)
+		outboxRepo := repository.NewOutboxRep(m.logger, tx)
+		return pTx(ctx, &cancelTx{bidRepo, tenderRepo, outboxRepo})
+	})
+}
+
+type templateTx struct {
+	*repository.TenderRep
+	*repository.CriterionRep
+	*repository.OutboxRep
+}
+
+func (m Manager) TemplateTransaction(ctx context.Context, pTx func(ctx context.Context, tx service.TemplateTransaction) error) error {
+	return m.db.RunInTransaction(ctx, func(ctx context.Context, tx *db.Tx) error {
+		tenderRepo := repository.NewTenderRep(m.logger, tx, m.tenderIdsStorage, m.usernameIdMatchStorage)
+		criterionRepo := repository.NewCriterionRep(m.logger, tx)
+		outboxRepo := repository.NewOutboxRep(m.logger, tx)
+		return pTx(ctx, &templateTx{tenderRepo, criterionRepo, outboxRepo})
+	})
+}
+
+type openTx struct {
+	*repository.EnvelopeRep
+	*repository.BidRep
+}
+
+func (m Manager) OpenTransaction(ctx context.Context, pTx func(ctx context.Context, tx service.OpenTransaction) error) error {
+	return m.db.RunInTransaction(ctx, func(ctx context.Context, tx *db.Tx) error {
+		envelopeRepo := repository.NewEnvelopeRep(m.logger, tx)
+		bidRepo := repository.NewBidRep(m.logger, tx, m.bidIdsStorage, m.usernameIdMatchStorage)
+		return pTx(ctx, &openTx{envelopeRepo, bidRepo})
+	})
+}
+
+type shortlistTx struct {
+	*repository.BidRep
+	*repository.TenderRep
+}
+
+func (m Manager) ShortlistTransaction(ctx context.Context, pTx func(ctx context.Context, tx service.ShortlistTransaction) error) error {
+	return m.db.RunInTransaction(ctx, func(ctx context.Context, tx *db.Tx) error {
+		bidRepo := repository.NewBidRep(m.logger, tx, m.bidIdsStorage, m.usernameIdMatchStorage)
+		tenderRepo := repository.NewTenderRep(m.logger, tx, m.tenderIdsStorage, m.usernameIdMatchStorage)
+		return pTx(ctx, &shortlistTx{bidRepo, tenderRepo})
+	})
+}
+
+type auctionTx struct {
+	*repository.AuctionRep
+}
+
+func (m Manager) AuctionTransaction(ctx context.Context, pTx func(ctx context.Context, tx service.AuctionTransaction) error) error {
+	return m.db.RunInTransaction(ctx, func(ctx context.Context, tx *db.Tx) error {
+		auctionRepo := repository.NewAuctionRep(m.logger, tx)
+		return pTx(ctx, &auctionTx{auctionRepo})
+	})
+}
+
+type complaintTx struct {
+	*repository.ComplaintRep
+}
+
+func (m Manager) ComplaintTransaction(ctx context.Context, pTx func(ctx context.Context, tx service.ComplaintTransaction) error) error {
+	return m.db.RunInTransaction(ctx, func(ctx context.Context, tx *db.Tx) error {
+		complaintRepo := repository.NewComplaintRep(m.logger, tx)
+		return pTx(ctx, &complaintTx{complaintRepo})
+	})
+}
+
+type tenderTx struct {
+	*repository.TenderRep
+	*repository.OutboxRep
+}
+
+func (m Manager) TenderTransaction(ctx context.Context, pTx func(ctx context.Context, tx service.TenderTransaction) error) error {
+	return m.db.RunInTransaction(ctx, func(ctx context.Context, tx *db.Tx) error {
+		tenderRepo := repository.NewTenderRep(m.logger, tx, m.tenderIdsStorage, m.usernameIdMatchStorage)
+		outboxRepo := repository.NewOutboxRep(m.logger, tx)
+		return pTx(ctx, &tenderTx{tenderRepo, outboxRepo})
+	})
+}
+
+type bidTx struct {
+	*repository.BidRep
+	*repository.FeedbackRep
+	*repository.OutboxRep
+}
+
+func (m Manager) BidTransaction(ctx context.Context, pTx func(ctx context.Context, tx service.BidTransaction) error) error {
+	return m.db.RunInTransaction(ctx, func(ctx context.Context, tx *db.Tx) error {
+		bidRepo := repository.NewBidRep(m.logger, tx, m.bidIdsStorage, m.usernameIdMatchStorage)
+		feedbackRepo := repository.NewFeedbackRep(m.logger, tx, m.usernameIdMatchStorage)
+		outboxRepo := repository.NewOutboxRep(m.logger, tx)
+		return pTx(ctx, &bidTx{bidRepo, feedbackRepo, outboxRepo})
 	})
 }
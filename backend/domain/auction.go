@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+type SetAuctionReq struct {
+	StartAt          time.Time `validate:"required" json:"startAt"`
+	EndAt            time.Time `validate:"required" json:"endAt"`
+	MinStep          float64   `validate:"gt=0" json:"minStep"`
+	ExtensionSeconds int       `validate:"gte=0,lte=3600" json:"extensionSeconds"`
+}
+
+// AuctionResp is the current state of the auction, it is also sent to the stream subscribers.
+type AuctionResp struct {
+	TenderId         string    `json:"tenderId"`
+	StartAt          time.Time `json:"startAt"`
+	EndAt            time.Time `json:"endAt"`
+	MinStep          float64   `json:"minStep"`
+	ExtensionSeconds int       `json:"extensionSeconds"`
+	BestPrice        *float64  `json:"bestPrice,omitempty"`
+	Prices           int       `json:"prices"`
+}
+
+type PlacePriceReq struct {
+	Price float64 `validate:"gt=0" json:"price"`
+}
+
+type AuctionPriceResp struct {
+	Id        string    `json:"id"`
+	BidId     string    `json:"bidId"`
+	Price     float64   `json:"price"`
+	CreatedAt time.Time `json:"createdAt"`
+	EndAt     time.Time `json:"endAt"`
+}
+
+type AuctionRankingResp struct {
+	Rank     int       `json:"rank"`
+	BidId    string    `json:"bidId"`
+	Price    float64   `json:"price"`
+	PlacedAt time.Time `json:"placedAt"`
+}
@@ -11,6 +11,25 @@ type CreateBidReq struct {
 	TenderId    string              `validate:"required" json:"tenderId"`
 	AuthorType  model.BidAuthorType `validate:"required" json:"authorType"`
 	AuthorId    string              `validate:"required" json:"authorId"`
+	// OrganizationId is the bidding organization of the Organization author.
+	// It can be omitted when the author is responsible for a single organization.
+	OrganizationId string `json:"organizationId"`
+	// ConflictJustification allows employees responsible for the tender organization to bid anyway.
+	ConflictJustification string `validate:"lte=1000" json:"conflictJustification"`
+	// Commercial is required by the two-stage tenders, Name and Description are the technical part.
+	Commercial *CommercialPartReq `json:"commercial"`
+}
+
+type CommercialPartReq struct {
+	Price float64 `validate:"gte=0" json:"price"`
+	Terms string  `validate:"lte=1000" json:"terms"`
+}
+
+type CommercialPartResp struct {
+	BidId     string    `json:"bidId"`
+	Price     float64   `json:"price"`
+	Terms     string    `json:"terms"`
+	UpdatedAt time.Time `json:"updatedAt"`
 }
 
 type CreateBidResp struct {
@@ -44,8 +63,9 @@ type SetStatusBidResp struct {
 }
 
 type EditBidReq struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Commercial  *CommercialPartReq `json:"commercial"`
 }
 
 type EditBidResp struct {
@@ -87,3 +107,45 @@ type RollbackBidResp struct {
 	Version    int                 `json:"version"`
 	CreatedAt  time.Time           `json:"createdAt"`
 }
+
+type WithdrawBidReq struct {
+	Reason string `validate:"required,lte=1000" json:"reason"`
+}
+
+type WithdrawBidResp struct {
+	Id         string              `json:"id"`
+	Name       string              `json:"name"`
+	Status     model.BidStatus     `json:"status"`
+	AuthorType model.BidAuthorType `json:"authorType"`
+	AuthorId   string              `json:"authorId"`
+	Reason     string              `json:"reason"`
+	Version    int                 `json:"version"`
+	CreatedAt  time.Time           `json:"createdAt"`
+}
+
+type ResubmitBidResp struct {
+	Id         string              `json:"id"`
+	Name       string              `json:"name"`
+	Status     model.BidStatus     `json:"status"`
+	AuthorType model.BidAuthorType `json:"authorType"`
+	AuthorId   string              `json:"authorId"`
+	Version    int                 `json:"version"`
+	CreatedAt  time.Time           `json:"createdAt"`
+}
+
+type BidContentResp struct {
+	BidId       string `json:"bidId"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Version     int    `json:"version"`
+}
+
+type ConflictOverrideResp struct {
+	Id             string    `json:"id"`
+	BidId          string    `json:"bidId"`
+	TenderId       string    `json:"tenderId"`
+	UserId         string    `json:"userId"`
+	OrganizationId *string   `json:"organizationId,omitempty"`
+	Justification  string    `json:"justification"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
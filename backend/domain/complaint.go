@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"avito/db/model"
+	"time"
+)
+
+type FileComplaintReq struct {
+	BidId       string `validate:"required" json:"bidId"`
+	Reason      string `validate:"required,lte=1000" json:"reason"`
+	FreezeAward bool   `json:"freezeAward"`
+}
+
+type ComplaintResponseReq struct {
+	Message string `validate:"lte=1000" json:"message"`
+}
+
+type ComplaintResponseResp struct {
+	Id        string                 `json:"id"`
+	UserId    string                 `json:"userId"`
+	Status    *model.ComplaintStatus `json:"status,omitempty"`
+	Message   string                 `json:"message"`
+	CreatedAt time.Time              `json:"createdAt"`
+}
+
+type ComplaintResp struct {
+	Id          string                  `json:"id"`
+	TenderId    string                  `json:"tenderId"`
+	BidId       string                  `json:"bidId"`
+	UserId      string                  `json:"userId"`
+	Reason      string                  `json:"reason"`
+	FreezeAward bool                    `json:"freezeAward"`
+	Status      model.ComplaintStatus   `json:"status"`
+	ResolvedAt  *time.Time              `json:"resolvedAt,omitempty"`
+	CreatedAt   time.Time               `json:"createdAt"`
+	Responses   []ComplaintResponseResp `json:"responses"`
+}
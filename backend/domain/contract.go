@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"avito/db/model"
+	"time"
+)
+
+type ContractResp struct {
+	Id                     string               `json:"id"`
+	TenderId               string               `json:"tenderId"`
+	BidId                  string               `json:"bidId"`
+	BidVersion             int                  `json:"bidVersion"`
+	Price                  *float64             `json:"price,omitempty"`
+	Status                 model.ContractStatus `json:"status"`
+	BuyerOrganizationId    string               `json:"buyerOrganizationId"`
+	SupplierType           model.BidAuthorType  `json:"supplierType"`
+	SupplierId             string               `json:"supplierId"`
+	SupplierOrganizationId *string              `json:"supplierOrganizationId,omitempty"`
+	BuyerSignerId          *string              `json:"buyerSignerId,omitempty"`
+	BuyerSignedAt          *time.Time           `json:"buyerSignedAt,omitempty"`
+	SupplierSignerId       *string              `json:"supplierSignerId,omitempty"`
+	SupplierSignedAt       *time.Time           `json:"supplierSignedAt,omitempty"`
+	CreatedAt              time.Time            `json:"createdAt"`
+}
+
+type CreateMilestoneReq struct {
+	Description string    `validate:"required,lte=1000" json:"description"`
+	DueDate     time.Time `validate:"required" json:"dueDate"`
+	Amount      float64   `validate:"gte=0" json:"amount"`
+}
+
+type DisputeMilestoneReq struct {
+	Reason string `validate:"required,lte=1000" json:"reason"`
+}
+
+type MilestoneResp struct {
+	Id            string                `json:"id"`
+	ContractId    string                `json:"contractId"`
+	TenderId      string                `json:"tenderId"`
+	Description   string                `json:"description"`
+	DueDate       time.Time             `json:"dueDate"`
+	Amount        float64               `json:"amount"`
+	Status        model.MilestoneStatus `json:"status"`
+	DeliveredAt   *time.Time            `json:"deliveredAt,omitempty"`
+	ResolvedAt    *time.Time            `json:"resolvedAt,omitempty"`
+	DisputeReason *string               `json:"disputeReason,omitempty"`
+	CreatedAt     time.Time             `json:"createdAt"`
+}
@@ -27,6 +27,87 @@ var (
 	ErrUserWithNameNotFound     = errors.New("User with this name does not exist")
 	ErrInvalidDecision          = errors.New("The decision is invalid")
 	ErrOrganizationDoesNotExist = errors.New("Organization does not exist")
+	ErrTenderNotAccessible      = errors.New("Tender is available by invitation only")
+	ErrInvalidVisibility        = errors.New("The visibility is invalid")
+	ErrInvalidInvitation        = errors.New("Exactly one of organization or user must be invited")
+	ErrInviteeDoesNotExist      = errors.New("Invited organization or user does not exist")
+	ErrAlreadyInvited           = errors.New("Organization or user is already invited")
+	ErrInvitationDoesNotExist   = errors.New("Invitation with this id does not exist")
+	ErrInvalidSchedule          = errors.New("The schedule is invalid")
+	ErrCancelReasonRequired     = errors.New("Cancellation reason is required")
+	ErrCancelReasonTooLong      = errors.New("Cancellation reason must be at most 1000 characters")
+	ErrTenderIsClosed           = errors.New("Tender is already closed")
+	ErrTenderNotCanceled        = errors.New("Tender was not canceled")
+	ErrTenderCanceled           = errors.New("Tender is canceled")
+	ErrInvalidCriteria          = errors.New("Criteria must have unique names and positive weights")
+	ErrCriteriaLocked           = errors.New("Criteria can not be changed after scoring started")
+	ErrCriterionDoesNotExist    = errors.New("Criterion with this id does not exist")
+	ErrInvalidScore             = errors.New("Score must be between 0 and 10, one per criterion")
+	ErrScoresLocked             = errors.New("Scores of a closed tender are immutable")
+	ErrTemplateDoesNotExist     = errors.New("Template with this id does not exist")
+	ErrTemplateNameTaken        = errors.New("Template with this name already exists in the organization")
+	ErrInvalidServiceType       = errors.New("The service type is invalid")
+	ErrServiceTypeExists        = errors.New("Service type with this code already exists")
+	ErrServiceTypeDoesNotExist  = errors.New("Service type with this code does not exist")
+	ErrInvalidServiceTypeParent = errors.New("Parent service type is invalid")
+	ErrServiceTypeInUse         = errors.New("Service type is used by tenders, templates or child types")
+	ErrNotAdmin                 = errors.New("User is not an administrator")
+	ErrInvalidAuthorType        = errors.New("Author type must be User or Organization")
+	ErrAuthorDoesNotExist       = errors.New("Author with this id does not exist")
+	ErrAuthorHasNoOrganization  = errors.New("Organization author must be responsible for an organization")
+	ErrOrganizationRequired     = errors.New("Organization must be specified for the author of several organizations")
+	ErrConflictOfInterest       = errors.New("Bid author is responsible for the tender organization")
+	ErrActiveBidExists          = errors.New("Author already has an active bid on the tender")
+	ErrWithdrawReasonRequired   = errors.New("Withdrawal reason is required")
+	ErrBidIsNotActive           = errors.New("Only created or published bid can be withdrawn")
+	ErrBidIsNotWithdrawn        = errors.New("Bid was not withdrawn")
+	ErrInvalidBidStatus         = errors.New("Bid can only be moved between Created and Published")
+	ErrResubmitRequired         = errors.New("Withdrawn bid must be resubmitted")
+	ErrBidsNotVisible           = errors.New("Only the tender organization and bid authors can see the bids")
+	ErrInvalidSeal              = errors.New("Sealed tender requires a bid deadline in the future before closing")
+	ErrTenderNotSealed          = errors.New("Tender is not sealed")
+	ErrSubmissionsClosed        = errors.New("Bid submissions of the sealed tender are closed")
+	ErrEnvelopesOpened          = errors.New("Envelopes of the tender are already opened")
+	ErrEnvelopesNotOpened       = errors.New("Envelopes of the tender are not opened yet")
+	ErrEnvelopesSealed          = errors.New("Bid contents are sealed until the envelopes are opened")
+	ErrTenderNotTwoStage        = errors.New("Tender is not two-stage")
+	ErrCommercialPartRequired   = errors.New("Bids on two-stage tenders require a commercial part")
+	ErrTechnicalStageClosed     = errors.New("Technical stage of the tender is over")
+	ErrTechnicalStage           = errors.New("Tender is still at the technical stage")
+	ErrInvalidShortlist         = errors.New("Shortlist must contain published bids of the tender")
+	ErrBidNotShortlisted        = errors.New("Bid is not shortlisted for the commercial stage")
+	ErrInvalidAuction           = errors.New("Auction must end after it starts and in the future")
+	ErrAuctionDoesNotExist      = errors.New("Tender has no auction")
+	ErrAuctionStarted           = errors.New("Auction has already started")
+	ErrAuctionNotRunning        = errors.New("Auction is not running")
+	ErrAuctionNotFinished       = errors.New("Auction is not finished yet")
+	ErrInvalidPrice             = errors.New("Price must be positive")
+	ErrPriceStepTooSmall        = errors.New("Price must beat the best price by the minimum step")
+	ErrNotAuctionWinner         = errors.New("Only the best price of the auction can be approved")
+	ErrContractDoesNotExist     = errors.New("Tender has no contract")
+	ErrInvalidContractSide      = errors.New("Contract side must be Buyer or Supplier")
+	ErrContractAlreadySigned    = errors.New("Contract is already signed by this side")
+	ErrMilestoneDoesNotExist    = errors.New("Milestone does not exist")
+	ErrInvalidMilestone         = errors.New("Milestone requires a description and a non-negative amount")
+	ErrMilestoneTransition      = errors.New("Milestone can not be moved to this status")
+	ErrDisputeReasonRequired    = errors.New("Dispute reason is required")
+	ErrComplaintDoesNotExist    = errors.New("Complaint does not exist")
+	ErrInvalidComplaint         = errors.New("Complaint requires a reason and a bid of the tender without the award")
+	ErrComplaintWindowClosed    = errors.New("Complaints are accepted only within the window after the tender is closed")
+	ErrComplaintAlreadyOpen     = errors.New("Bid already has an open complaint")
+	ErrComplaintTransition      = errors.New("Complaint can not be moved to this status")
+	ErrComplaintResponseMissing = errors.New("Response message is required")
+	ErrAwardFrozen              = errors.New("Award is frozen by an open complaint")
+	ErrNotFramework             = errors.New("Tender is not a framework agreement")
+	ErrInvalidCallOffOrder      = errors.New("Call-off order requires a description, a positive amount and an approved supplier of the framework")
+	ErrWebhookDoesNotExist      = errors.New("Webhook does not exist")
+	ErrInvalidWebhook           = errors.New("Webhook requires an http(s) URL, known event types and a secret of at least 16 characters")
+	ErrDeliveryDoesNotExist     = errors.New("Webhook delivery does not exist")
+	ErrDeliveryNotFailed        = errors.New("Only the dead webhook deliveries can be replayed")
+	ErrInvalidNotificationPref  = errors.New("Notification preference requires a valid email and the ru or en locale")
+	ErrNotificationDoesNotExist = errors.New("Notification does not exist")
+	ErrSavedSearchDoesNotExist  = errors.New("Saved search does not exist")
+	ErrInvalidSavedSearch       = errors.New("Saved search requires a name, non-blank keywords and a non-negative budget range")
 )
 
 type StatusCode int
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"avito/db/model"
+	"time"
+)
+
+type CriterionReq struct {
+	Name   string  `validate:"required,lte=100" json:"name"`
+	Weight float64 `validate:"gt=0" json:"weight"`
+}
+
+type SetCriteriaReq struct {
+	Criteria []CriterionReq `validate:"required,dive" json:"criteria"`
+}
+
+type CriterionResp struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	Weight    float64   `json:"weight"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ScoreReq struct {
+	CriterionId string `validate:"required" json:"criterionId"`
+	Score       int    `validate:"gte=0,lte=10" json:"score"`
+	Comment     string `validate:"lte=1000" json:"comment"`
+}
+
+type ScoreBidReq struct {
+	Scores []ScoreReq `validate:"required,dive" json:"scores"`
+}
+
+type BidScoreResp struct {
+	CriterionId string    `json:"criterionId"`
+	Score       int       `json:"score"`
+	Comment     string    `json:"comment"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type BidRankingResp struct {
+	Rank           int                 `json:"rank"`
+	BidId          string              `json:"bidId"`
+	Name           string              `json:"name"`
+	Status         model.BidStatus     `json:"status"`
+	AuthorType     model.BidAuthorType `json:"authorType"`
+	AuthorId       string              `json:"authorId"`
+	Score          float64             `json:"score"`
+	ScoredCriteria int                 `json:"scoredCriteria"`
+}
+
+type ShortlistReq struct {
+	BidIds []string `validate:"required,min=1" json:"bidIds"`
+}
+
+type ShortlistedBidResp struct {
+	BidId         string    `json:"bidId"`
+	UserId        string    `json:"userId"`
+	ShortlistedAt time.Time `json:"shortlistedAt"`
+}
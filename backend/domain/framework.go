@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+type CallOffOrderReq struct {
+	BidId        string     `validate:"required" json:"bidId"`
+	Description  string     `validate:"required,lte=1000" json:"description"`
+	Amount       float64    `validate:"gt=0" json:"amount"`
+	DeliveryDate *time.Time `json:"deliveryDate"`
+}
+
+type CallOffOrderResp struct {
+	Id           string     `json:"id"`
+	TenderId     string     `json:"tenderId"`
+	BidId        string     `json:"bidId"`
+	UserId       string     `json:"userId"`
+	Description  string     `json:"description"`
+	Amount       float64    `json:"amount"`
+	DeliveryDate *time.Time `json:"deliveryDate,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
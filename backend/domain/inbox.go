@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"avito/db/model"
+	"time"
+)
+
+type InboxNotificationResp struct {
+	Id         string          `json:"id"`
+	Type       model.EventType `json:"type"`
+	TenderId   string          `json:"tenderId"`
+	TenderName string          `json:"tenderName"`
+	BidId      *string         `json:"bidId,omitempty"`
+	Status     string          `json:"status,omitempty"`
+	Feedback   string          `json:"feedback,omitempty"`
+	Username   string          `json:"username,omitempty"`
+	Read       bool            `json:"read"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+type InboxUnreadResp struct {
+	Unread int `json:"unread"`
+}
+
+// InboxReadReq marks the notifications read, all the unread ones when the ids are empty.
+type InboxReadReq struct {
+	Ids []string `json:"ids"`
+}
+
+type InboxReadResp struct {
+	Read int `json:"read"`
+}
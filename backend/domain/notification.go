@@ -0,0 +1,15 @@
+package domain
+
+import "avito/db/model"
+
+type NotificationPreferenceReq struct {
+	Email       *string      `validate:"omitempty,email" json:"email"`
+	Locale      model.Locale `validate:"omitempty,oneof=ru en" json:"locale"`
+	EmailOptOut bool         `json:"emailOptOut"`
+}
+
+type NotificationPreferenceResp struct {
+	Email       *string      `json:"email,omitempty"`
+	Locale      model.Locale `json:"locale"`
+	EmailOptOut bool         `json:"emailOptOut"`
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"avito/db/model"
+	"time"
+)
+
+type CreateSavedSearchReq struct {
+	Name           string                    `validate:"required,max=100" json:"name"`
+	ServiceTypes   []model.TenderServiceType `json:"serviceTypes"`
+	Keywords       []string                  `json:"keywords"`
+	BudgetMin      *float64                  `validate:"omitempty,gte=0" json:"budgetMin"`
+	BudgetMax      *float64                  `validate:"omitempty,gte=0" json:"budgetMax"`
+	OrganizationId *string                   `json:"organizationId"`
+}
+
+type SavedSearchResp struct {
+	Id             string                    `json:"id"`
+	Name           string                    `json:"name"`
+	ServiceTypes   []model.TenderServiceType `json:"serviceTypes"`
+	Keywords       []string                  `json:"keywords"`
+	BudgetMin      *float64                  `json:"budgetMin,omitempty"`
+	BudgetMax      *float64                  `json:"budgetMax,omitempty"`
+	OrganizationId *string                   `json:"organizationId,omitempty"`
+	CreatedAt      time.Time                 `json:"createdAt"`
+}
+
+type TenderAlertResp struct {
+	Id             string                  `json:"id"`
+	SearchId       string                  `json:"searchId"`
+	SearchName     string                  `json:"searchName"`
+	TenderId       string                  `json:"tenderId"`
+	TenderName     string                  `json:"tenderName"`
+	ServiceType    model.TenderServiceType `json:"serviceType"`
+	Budget         *float64                `json:"budget,omitempty"`
+	OrganizationId string                  `json:"organizationId"`
+	CreatedAt      time.Time               `json:"createdAt"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+type CreateServiceTypeReq struct {
+	Code       string  `validate:"required,lte=50" json:"code"`
+	Name       string  `validate:"required,lte=100" json:"name"`
+	ParentCode *string `json:"parentCode"`
+}
+
+type UpdateServiceTypeReq struct {
+	Name       string  `validate:"required,lte=100" json:"name"`
+	ParentCode *string `json:"parentCode"`
+}
+
+type ServiceTypeResp struct {
+	Code       string    `json:"code"`
+	Name       string    `json:"name"`
+	ParentCode *string   `json:"parentCode,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
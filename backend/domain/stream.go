@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"avito/db/model"
+	"time"
+)
+
+// StreamScope selects the events of a stream: of the tender, of the organization tenders,
+// or of the user bids when both ids are empty.
+type StreamScope struct {
+	TenderId       string
+	OrganizationId string
+}
+
+type StreamEventResp struct {
+	Id            int64           `json:"id"`
+	Type          model.EventType `json:"type"`
+	TenderId      string          `json:"tenderId"`
+	BidId         *string         `json:"bidId,omitempty"`
+	Status        string          `json:"status,omitempty"`
+	TenderVersion *int            `json:"tenderVersion,omitempty"`
+	BidVersion    *int            `json:"bidVersion,omitempty"`
+	CreatedAt     time.Time       `json:"createdAt"`
+}
+
+// StreamSubscription is the resumed events followed by the live ones. The updates are closed
+// when the subscriber falls behind, the client then resumes from the last received id.
+type StreamSubscription struct {
+	Backlog []StreamEventResp
+	Updates <-chan StreamEventResp
+	Close   func()
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"avito/db/model"
+	"time"
+)
+
+type TemplateReq struct {
+	Name        string                  `validate:"required,lte=100" json:"name"`
+	Description string                  `validate:"required,lte=500" json:"description"`
+	ServiceType model.TenderServiceType `validate:"required" json:"serviceType"`
+	Budget      *float64                `validate:"omitempty,gte=0" json:"budget"`
+	Criteria    []CriterionReq          `validate:"dive" json:"criteria"`
+}
+
+type TemplateCriterionResp struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+}
+
+type TemplateResp struct {
+	Id             string                  `json:"id"`
+	OrganizationId string                  `json:"organizationId"`
+	Name           string                  `json:"name"`
+	Description    string                  `json:"description"`
+	ServiceType    model.TenderServiceType `json:"serviceType"`
+	Budget         *float64                `json:"budget,omitempty"`
+	Criteria       []TemplateCriterionResp `json:"criteria"`
+	CreatedAt      time.Time               `json:"createdAt"`
+	UpdatedAt      time.Time               `json:"updatedAt"`
+}
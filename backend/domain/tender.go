@@ -10,8 +10,21 @@ type CreateTenderReq struct {
 	Description     string                  `validate:"required,lte=500" json:"description"`
 	ServiceType     model.TenderServiceType `json:"serviceType"`
 	Status          model.TenderStatus      `json:"status"`
+	Visibility      model.TenderVisibility  `json:"visibility"`
 	OrganizationId  string                  `validate:"required,lte=100" json:"organizationId"`
 	CreatorUsername string                  `json:"creatorUsername"`
+	PublishAt       *time.Time              `json:"publishAt"`
+	CloseAt         *time.Time              `json:"closeAt"`
+	Budget          *float64                `validate:"omitempty,gte=0" json:"budget"`
+	// Sealed tenders require BidDeadline, the bid contents are revealed after it passes.
+	Sealed      bool       `json:"sealed"`
+	BidDeadline *time.Time `json:"bidDeadline"`
+	// TwoStage tenders reveal the commercial parts of the shortlisted bids only.
+	TwoStage bool `json:"twoStage"`
+	// Anonymized tenders hide the bid authors from the evaluators until the tender is closed.
+	Anonymized bool `json:"anonymized"`
+	// Framework tenders approve several suppliers instead of closing on the first approval.
+	Framework bool `json:"framework"`
 }
 
 type CreateTenderResp struct {
@@ -20,8 +33,19 @@ type CreateTenderResp struct {
 	Description string                  `json:"description"`
 	ServiceType model.TenderServiceType `json:"serviceType"`
 	Status      model.TenderStatus      `json:"status"`
+	Visibility  model.TenderVisibility  `json:"visibility"`
 	Version     int                     `json:"version"`
 	CreatedAt   time.Time               `json:"createdAt"`
+	PublishAt   *time.Time              `json:"publishAt,omitempty"`
+	CloseAt     *time.Time              `json:"closeAt,omitempty"`
+	Budget      *float64                `json:"budget,omitempty"`
+	TemplateId  *string                 `json:"templateId,omitempty"`
+	Sealed      bool                    `json:"sealed,omitempty"`
+	BidDeadline *time.Time              `json:"bidDeadline,omitempty"`
+	TwoStage    bool                    `json:"twoStage,omitempty"`
+	Stage       *model.TenderStage      `json:"stage,omitempty"`
+	Anonymized  bool                    `json:"anonymized,omitempty"`
+	Framework   bool                    `json:"framework,omitempty"`
 }
 
 type SetStatusTenderResp struct {
@@ -30,8 +54,19 @@ type SetStatusTenderResp struct {
 	Description string                  `json:"description"`
 	ServiceType model.TenderServiceType `json:"serviceType"`
 	Status      model.TenderStatus      `json:"status"`
+	Visibility  model.TenderVisibility  `json:"visibility"`
 	Version     int                     `json:"version"`
 	CreatedAt   time.Time               `json:"createdAt"`
+	PublishAt   *time.Time              `json:"publishAt,omitempty"`
+	CloseAt     *time.Time              `json:"closeAt,omitempty"`
+	Budget      *float64                `json:"budget,omitempty"`
+	TemplateId  *string                 `json:"templateId,omitempty"`
+	Sealed      bool                    `json:"sealed,omitempty"`
+	BidDeadline *time.Time              `json:"bidDeadline,omitempty"`
+	TwoStage    bool                    `json:"twoStage,omitempty"`
+	Stage       *model.TenderStage      `json:"stage,omitempty"`
+	Anonymized  bool                    `json:"anonymized,omitempty"`
+	Framework   bool                    `json:"framework,omitempty"`
 }
 
 type GetTendersResp struct {
@@ -40,14 +75,27 @@ type GetTendersResp struct {
 	Description string                  `json:"description"`
 	ServiceType model.TenderServiceType `json:"serviceType"`
 	Status      model.TenderStatus      `json:"status"`
+	Visibility  model.TenderVisibility  `json:"visibility"`
 	Version     int                     `json:"version"`
 	CreatedAt   time.Time               `json:"createdAt"`
+	PublishAt   *time.Time              `json:"publishAt,omitempty"`
+	CloseAt     *time.Time              `json:"closeAt,omitempty"`
+	Budget      *float64                `json:"budget,omitempty"`
+	TemplateId  *string                 `json:"templateId,omitempty"`
+	Sealed      bool                    `json:"sealed,omitempty"`
+	BidDeadline *time.Time              `json:"bidDeadline,omitempty"`
+	TwoStage    bool                    `json:"twoStage,omitempty"`
+	Stage       *model.TenderStage      `json:"stage,omitempty"`
+	Anonymized  bool                    `json:"anonymized,omitempty"`
+	Framework   bool                    `json:"framework,omitempty"`
 }
 
 type EditTenderReq struct {
 	Name        string                  `json:"name"`
 	Description string                  `json:"description"`
 	ServiceType model.TenderServiceType `json:"serviceType"`
+	PublishAt   *time.Time              `json:"publishAt"`
+	CloseAt     *time.Time              `json:"closeAt"`
 }
 
 type EditTenderResp struct {
@@ -56,8 +104,19 @@ type EditTenderResp struct {
 	Description string                  `json:"description"`
 	ServiceType model.TenderServiceType `json:"serviceType"`
 	Status      model.TenderStatus      `json:"status"`
+	Visibility  model.TenderVisibility  `json:"visibility"`
 	Version     int                     `json:"version"`
 	CreatedAt   time.Time               `json:"createdAt"`
+	PublishAt   *time.Time              `json:"publishAt,omitempty"`
+	CloseAt     *time.Time              `json:"closeAt,omitempty"`
+	Budget      *float64                `json:"budget,omitempty"`
+	TemplateId  *string                 `json:"templateId,omitempty"`
+	Sealed      bool                    `json:"sealed,omitempty"`
+	BidDeadline *time.Time              `json:"bidDeadline,omitempty"`
+	TwoStage    bool                    `json:"twoStage,omitempty"`
+	Stage       *model.TenderStage      `json:"stage,omitempty"`
+	Anonymized  bool                    `json:"anonymized,omitempty"`
+	Framework   bool                    `json:"framework,omitempty"`
 }
 
 type RollbackTenderResp struct {
@@ -66,6 +125,111 @@ type RollbackTenderResp struct {
 	Description string                  `json:"description"`
 	ServiceType model.TenderServiceType `json:"serviceType"`
 	Status      model.TenderStatus      `json:"status"`
+	Visibility  model.TenderVisibility  `json:"visibility"`
 	Version     int                     `json:"version"`
 	CreatedAt   time.Time               `json:"createdAt"`
+	PublishAt   *time.Time              `json:"publishAt,omitempty"`
+	CloseAt     *time.Time              `json:"closeAt,omitempty"`
+	Budget      *float64                `json:"budget,omitempty"`
+	TemplateId  *string                 `json:"templateId,omitempty"`
+	Sealed      bool                    `json:"sealed,omitempty"`
+	BidDeadline *time.Time              `json:"bidDeadline,omitempty"`
+	TwoStage    bool                    `json:"twoStage,omitempty"`
+	Stage       *model.TenderStage      `json:"stage,omitempty"`
+	Anonymized  bool                    `json:"anonymized,omitempty"`
+	Framework   bool                    `json:"framework,omitempty"`
+}
+
+type SetVisibilityTenderResp struct {
+	Id          string                  `json:"id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	ServiceType model.TenderServiceType `json:"serviceType"`
+	Status      model.TenderStatus      `json:"status"`
+	Visibility  model.TenderVisibility  `json:"visibility"`
+	Version     int                     `json:"version"`
+	CreatedAt   time.Time               `json:"createdAt"`
+	PublishAt   *time.Time              `json:"publishAt,omitempty"`
+	CloseAt     *time.Time              `json:"closeAt,omitempty"`
+	Budget      *float64                `json:"budget,omitempty"`
+	TemplateId  *string                 `json:"templateId,omitempty"`
+	Sealed      bool                    `json:"sealed,omitempty"`
+	BidDeadline *time.Time              `json:"bidDeadline,omitempty"`
+	TwoStage    bool                    `json:"twoStage,omitempty"`
+	Stage       *model.TenderStage      `json:"stage,omitempty"`
+	Anonymized  bool                    `json:"anonymized,omitempty"`
+	Framework   bool                    `json:"framework,omitempty"`
+}
+
+type InviteTenderReq struct {
+	OrganizationId string `json:"organizationId"`
+	UserId         string `json:"userId"`
+}
+
+type TenderInvitationResp struct {
+	Id             string    `json:"id"`
+	TenderId       string    `json:"tenderId"`
+	OrganizationId *string   `json:"organizationId,omitempty"`
+	UserId         *string   `json:"userId,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type ScheduleTenderReq struct {
+	PublishAt *time.Time `json:"publishAt"`
+	CloseAt   *time.Time `json:"closeAt"`
+}
+
+type ScheduleTenderResp struct {
+	Id          string                  `json:"id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	ServiceType model.TenderServiceType `json:"serviceType"`
+	Status      model.TenderStatus      `json:"status"`
+	Visibility  model.TenderVisibility  `json:"visibility"`
+	Version     int                     `json:"version"`
+	CreatedAt   time.Time               `json:"createdAt"`
+	PublishAt   *time.Time              `json:"publishAt,omitempty"`
+	CloseAt     *time.Time              `json:"closeAt,omitempty"`
+	Budget      *float64                `json:"budget,omitempty"`
+	TemplateId  *string                 `json:"templateId,omitempty"`
+	Sealed      bool                    `json:"sealed,omitempty"`
+	BidDeadline *time.Time              `json:"bidDeadline,omitempty"`
+	TwoStage    bool                    `json:"twoStage,omitempty"`
+	Stage       *model.TenderStage      `json:"stage,omitempty"`
+	Anonymized  bool                    `json:"anonymized,omitempty"`
+	Framework   bool                    `json:"framework,omitempty"`
+}
+
+type CancelTenderReq struct {
+	Reason string `validate:"required,lte=1000" json:"reason"`
+}
+
+type TenderCancellationResp struct {
+	TenderId   string    `json:"tenderId"`
+	Reason     string    `json:"reason"`
+	CanceledAt time.Time `json:"canceledAt"`
+}
+
+type CreateTenderFromTemplateReq struct {
+	Name            string                 `validate:"lte=100" json:"name"`
+	Description     string                 `validate:"lte=500" json:"description"`
+	Status          model.TenderStatus     `json:"status"`
+	Visibility      model.TenderVisibility `json:"visibility"`
+	CreatorUsername string                 `json:"creatorUsername"`
+	PublishAt       *time.Time             `json:"publishAt"`
+	CloseAt         *time.Time             `json:"closeAt"`
+	Budget          *float64               `validate:"omitempty,gte=0" json:"budget"`
+	Sealed          bool                   `json:"sealed"`
+	BidDeadline     *time.Time             `json:"bidDeadline"`
+	TwoStage        bool                   `json:"twoStage"`
+	Anonymized      bool                   `json:"anonymized"`
+	Framework       bool                   `json:"framework"`
+}
+
+type EnvelopeOpeningResp struct {
+	TenderId string  `json:"tenderId"`
+	OpenedBy *string `json:"openedBy,omitempty"`
+	// Automatic is set when the envelopes were opened at the bid deadline.
+	Automatic bool      `json:"automatic"`
+	OpenedAt  time.Time `json:"openedAt"`
 }
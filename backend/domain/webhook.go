@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"avito/db/model"
+	"time"
+)
+
+type CreateWebhookReq struct {
+	Url        string            `validate:"required,url" json:"url"`
+	EventTypes []model.EventType `validate:"required" json:"eventTypes"`
+	Secret     string            `validate:"required,gte=16" json:"secret"`
+}
+
+type WebhookResp struct {
+	Id             string            `json:"id"`
+	OrganizationId string            `json:"organizationId"`
+	Url            string            `json:"url"`
+	EventTypes     []model.EventType `json:"eventTypes"`
+	CreatedAt      time.Time         `json:"createdAt"`
+}
+
+type WebhookDeliveryResp struct {
+	Id             string                      `json:"id"`
+	WebhookId      string                      `json:"webhookId"`
+	EventId        int64                       `json:"eventId"`
+	EventType      model.EventType             `json:"eventType"`
+	Status         model.WebhookDeliveryStatus `json:"status"`
+	Attempts       int                         `json:"attempts"`
+	NextAttemptAt  time.Time                   `json:"nextAttemptAt"`
+	LastError      *string                     `json:"lastError,omitempty"`
+	ResponseStatus *int                        `json:"responseStatus,omitempty"`
+	DeliveredAt    *time.Time                  `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time                   `json:"createdAt"`
+}
+
+// WebhookEventBody is the JSON body of a webhook delivery, the receiver checks it with
+// the HMAC-SHA256 signature of the webhook secret sent in the X-Webhook-Signature header.
+type WebhookEventBody struct {
+	Id        int64              `json:"id"`
+	Type      model.EventType    `json:"type"`
+	TenderId  string             `json:"tenderId"`
+	BidId     *string            `json:"bidId,omitempty"`
+	Payload   model.EventPayload `json:"payload"`
+	CreatedAt time.Time          `json:"createdAt"`
+}
@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TYPE tender_visibility AS ENUM (
+  'Public',
+  'InviteOnly'
+);
+
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS visibility tender_visibility NOT NULL DEFAULT 'Public';
+
+CREATE TABLE IF NOT EXISTS tender_invitation (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    tender_id UUId NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    organization_id UUId REFERENCES organization(id) ON DELETE CASCADE,
+    user_id UUId REFERENCES employee(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours'),
+    CHECK ((organization_id IS NULL) <> (user_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tender_invitation_org_uidx ON tender_invitation (tender_id, organization_id)
+    WHERE organization_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS tender_invitation_user_uidx ON tender_invitation (tender_id, user_id)
+    WHERE user_id IS NOT NULL;
+
+-- +goose Down
+DROP TABLE tender_invitation CASCADE;
+ALTER TABLE tender DROP COLUMN visibility;
+DROP TYPE tender_visibility CASCADE;
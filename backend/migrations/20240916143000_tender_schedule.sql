@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ;
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS close_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS tender_publish_at_idx ON tender (publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS tender_close_at_idx ON tender (close_at) WHERE close_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX tender_publish_at_idx;
+DROP INDEX tender_close_at_idx;
+ALTER TABLE tender DROP COLUMN publish_at;
+ALTER TABLE tender DROP COLUMN close_at;
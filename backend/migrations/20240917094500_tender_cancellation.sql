@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS tender_cancellation (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    tender_id UUId UNIQUE NOT NULL REFERENCES tender(id),
+    reason TEXT NOT NULL CHECK (char_length(reason) <= 1000),
+    user_id UUId NOT NULL REFERENCES employee(id),
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+-- +goose Down
+DROP TABLE tender_cancellation CASCADE;
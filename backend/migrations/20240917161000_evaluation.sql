@@ -0,0 +1,55 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS tender_criterion (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    tender_id UUId NOT NULL REFERENCES tender(id),
+    name TEXT NOT NULL CHECK (char_length(name) <= 100),
+    weight DOUBLE PRECISION NOT NULL CHECK (weight > 0),
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+CREATE TABLE IF NOT EXISTS bid_score (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    bid_id UUId NOT NULL REFERENCES bid(id),
+    criterion_id UUId NOT NULL REFERENCES tender_criterion(id) ON DELETE CASCADE,
+    user_id UUId NOT NULL REFERENCES employee(id),
+    score INTEGER NOT NULL CHECK (score BETWEEN 0 AND 10),
+    comment TEXT NOT NULL DEFAULT '' CHECK (char_length(comment) <= 1000),
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours'),
+    updated_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours'),
+    UNIQUE (bid_id, criterion_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS tender_criterion_tender_id_idx ON tender_criterion (tender_id);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION bid_score_tender_not_closed() RETURNS trigger AS $$
+DECLARE
+    score_bid_id UUId;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        score_bid_id := OLD.bid_id;
+    ELSE
+        score_bid_id := NEW.bid_id;
+    END IF;
+
+    IF EXISTS (SELECT 1 FROM bid b JOIN tender t ON t.id = b.tender_id
+               WHERE b.id = score_bid_id AND t.status = 'Closed') THEN
+        RAISE EXCEPTION 'scores of a closed tender are immutable';
+    END IF;
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER bid_score_immutable_when_closed
+    BEFORE INSERT OR UPDATE OR DELETE ON bid_score
+    FOR EACH ROW EXECUTE FUNCTION bid_score_tender_not_closed();
+
+-- +goose Down
+DROP TABLE bid_score CASCADE;
+DROP TABLE tender_criterion CASCADE;
+DROP FUNCTION bid_score_tender_not_closed;
@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS tender_template (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUId NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    name TEXT NOT NULL CHECK (char_length(name) <= 100),
+    description TEXT NOT NULL CHECK (char_length(description) <= 500),
+    service_type tender_service_type NOT NULL,
+    budget NUMERIC(15, 2) CHECK (budget >= 0),
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours'),
+    updated_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours'),
+    UNIQUE (organization_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS tender_template_criterion (
+    template_id UUId NOT NULL REFERENCES tender_template(id) ON DELETE CASCADE,
+    name TEXT NOT NULL CHECK (char_length(name) <= 100),
+    weight DOUBLE PRECISION NOT NULL CHECK (weight > 0)
+);
+
+CREATE INDEX IF NOT EXISTS tender_template_criterion_template_id_idx ON tender_template_criterion (template_id);
+
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS budget NUMERIC(15, 2) CHECK (budget >= 0);
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS template_id UUId REFERENCES tender_template(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE tender DROP COLUMN template_id;
+ALTER TABLE tender DROP COLUMN budget;
+DROP TABLE tender_template_criterion CASCADE;
+DROP TABLE tender_template CASCADE;
@@ -0,0 +1,42 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS service_type (
+    code TEXT PRIMARY KEY CHECK (char_length(code) BETWEEN 1 AND 50),
+    name TEXT NOT NULL CHECK (char_length(name) <= 100),
+    parent_code TEXT REFERENCES service_type(code) ON UPDATE CASCADE,
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours'),
+    CHECK (parent_code <> code)
+);
+
+CREATE INDEX IF NOT EXISTS service_type_parent_code_idx ON service_type (parent_code);
+
+INSERT INTO service_type(code, name) VALUES
+    ('Construction', 'Construction'),
+    ('Delivery', 'Delivery'),
+    ('Manufacture', 'Manufacture');
+
+ALTER TABLE tender_content ALTER COLUMN service_type TYPE TEXT USING service_type::text;
+ALTER TABLE tender_content ADD CONSTRAINT tender_content_service_type_fkey
+    FOREIGN KEY (service_type) REFERENCES service_type(code) ON UPDATE CASCADE;
+
+ALTER TABLE tender_template ALTER COLUMN service_type TYPE TEXT USING service_type::text;
+ALTER TABLE tender_template ADD CONSTRAINT tender_template_service_type_fkey
+    FOREIGN KEY (service_type) REFERENCES service_type(code) ON UPDATE CASCADE;
+
+DROP TYPE tender_service_type;
+
+-- +goose Down
+CREATE TYPE tender_service_type AS ENUM (
+  'Construction',
+  'Delivery',
+  'Manufacture'
+);
+
+ALTER TABLE tender_template DROP CONSTRAINT tender_template_service_type_fkey;
+ALTER TABLE tender_template ALTER COLUMN service_type TYPE tender_service_type
+    USING service_type::tender_service_type;
+
+ALTER TABLE tender_content DROP CONSTRAINT tender_content_service_type_fkey;
+ALTER TABLE tender_content ALTER COLUMN service_type TYPE tender_service_type
+    USING service_type::tender_service_type;
+
+DROP TABLE service_type CASCADE;
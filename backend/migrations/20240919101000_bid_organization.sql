@@ -0,0 +1,18 @@
+-- +goose Up
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS organization_id UUId REFERENCES organization(id);
+
+UPDATE bid b SET organization_id = (
+    SELECT r.organization_id FROM organization_responsible r WHERE r.user_id = b.author_id
+    ORDER BY r.id LIMIT 1)
+WHERE b.author_type = 'Organization';
+
+UPDATE bid SET author_type = 'User' WHERE author_type = 'Organization' AND organization_id IS NULL;
+
+ALTER TABLE bid ADD CONSTRAINT bid_organization_author_check
+    CHECK ((author_type = 'Organization') = (organization_id IS NOT NULL));
+
+CREATE INDEX IF NOT EXISTS bid_organization_id_idx ON bid (organization_id) WHERE organization_id IS NOT NULL;
+
+-- +goose Down
+ALTER TABLE bid DROP CONSTRAINT bid_organization_author_check;
+ALTER TABLE bid DROP COLUMN organization_id;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS bid_conflict_override (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    bid_id UUId NOT NULL UNIQUE REFERENCES bid(id) ON DELETE CASCADE,
+    tender_id UUId NOT NULL REFERENCES tender(id),
+    user_id UUId NOT NULL REFERENCES employee(id),
+    organization_id UUId REFERENCES organization(id),
+    justification TEXT NOT NULL CHECK (char_length(justification) <= 1000),
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+CREATE INDEX IF NOT EXISTS bid_conflict_override_tender_id_idx ON bid_conflict_override (tender_id);
+
+-- +goose Down
+DROP TABLE bid_conflict_override CASCADE;
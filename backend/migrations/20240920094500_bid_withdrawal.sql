@@ -0,0 +1,24 @@
+-- +goose Up
+UPDATE bid SET status = 'Canceled' WHERE id IN (
+    SELECT id FROM (
+        SELECT id, ROW_NUMBER() OVER (
+            PARTITION BY tender_id, COALESCE(organization_id, author_id) ORDER BY created_at DESC) AS position
+        FROM bid WHERE status IN ('Created', 'Published')) active
+    WHERE active.position > 1);
+
+CREATE UNIQUE INDEX IF NOT EXISTS bid_active_author_uidx ON bid (tender_id, COALESCE(organization_id, author_id))
+    WHERE status IN ('Created', 'Published');
+
+CREATE TABLE IF NOT EXISTS bid_withdrawal (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    bid_id UUId NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL CHECK (char_length(reason) <= 1000),
+    user_id UUId NOT NULL REFERENCES employee(id),
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+CREATE INDEX IF NOT EXISTS bid_withdrawal_bid_id_idx ON bid_withdrawal (bid_id);
+
+-- +goose Down
+DROP TABLE bid_withdrawal CASCADE;
+DROP INDEX bid_active_author_uidx;
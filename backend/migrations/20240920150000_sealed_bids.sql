@@ -0,0 +1,26 @@
+-- +goose Up
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS sealed BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS bid_deadline TIMESTAMPTZ;
+ALTER TABLE tender ADD CONSTRAINT tender_sealed_deadline_check CHECK (NOT sealed OR bid_deadline IS NOT NULL);
+
+ALTER TABLE bid_content ADD COLUMN IF NOT EXISTS sealed_content BYTEA;
+
+CREATE TABLE IF NOT EXISTS tender_bid_key (
+    tender_id UUId PRIMARY KEY REFERENCES tender(id) ON DELETE CASCADE,
+    encrypted_key BYTEA NOT NULL,
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+CREATE TABLE IF NOT EXISTS tender_envelope_opening (
+    tender_id UUId PRIMARY KEY REFERENCES tender(id) ON DELETE CASCADE,
+    user_id UUId REFERENCES employee(id),
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+-- +goose Down
+DROP TABLE tender_envelope_opening CASCADE;
+DROP TABLE tender_bid_key CASCADE;
+ALTER TABLE bid_content DROP COLUMN sealed_content;
+ALTER TABLE tender DROP CONSTRAINT tender_sealed_deadline_check;
+ALTER TABLE tender DROP COLUMN bid_deadline;
+ALTER TABLE tender DROP COLUMN sealed;
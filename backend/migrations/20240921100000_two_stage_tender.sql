@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TYPE tender_stage AS ENUM (
+  'Technical',
+  'Commercial'
+);
+
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS two_stage BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS stage tender_stage;
+ALTER TABLE tender ADD CONSTRAINT tender_two_stage_check CHECK (two_stage = (stage IS NOT NULL));
+
+CREATE TABLE IF NOT EXISTS bid_commercial (
+    bid_id UUId PRIMARY KEY REFERENCES bid(id) ON DELETE CASCADE,
+    price NUMERIC(15, 2) NOT NULL CHECK (price >= 0),
+    terms TEXT NOT NULL DEFAULT '' CHECK (char_length(terms) <= 1000),
+    updated_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+CREATE TABLE IF NOT EXISTS bid_shortlist (
+    bid_id UUId PRIMARY KEY REFERENCES bid(id) ON DELETE CASCADE,
+    user_id UUId NOT NULL REFERENCES employee(id),
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+-- +goose Down
+DROP TABLE bid_shortlist CASCADE;
+DROP TABLE bid_commercial CASCADE;
+ALTER TABLE tender DROP CONSTRAINT tender_two_stage_check;
+ALTER TABLE tender DROP COLUMN stage;
+ALTER TABLE tender DROP COLUMN two_stage;
+DROP TYPE tender_stage CASCADE;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS anonymized BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE tender DROP COLUMN anonymized;
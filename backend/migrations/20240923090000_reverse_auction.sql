@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS tender_auction (
+    tender_id UUId PRIMARY KEY REFERENCES tender(id) ON DELETE CASCADE,
+    start_at TIMESTAMPTZ NOT NULL,
+    end_at TIMESTAMPTZ NOT NULL,
+    min_step NUMERIC(15, 2) NOT NULL CHECK (min_step > 0),
+    extension_seconds INT NOT NULL DEFAULT 0 CHECK (extension_seconds >= 0),
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours'),
+    CHECK (start_at < end_at)
+);
+
+CREATE TABLE IF NOT EXISTS auction_price (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    tender_id UUId NOT NULL REFERENCES tender_auction(tender_id) ON DELETE CASCADE,
+    bid_id UUId NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    price NUMERIC(15, 2) NOT NULL CHECK (price > 0),
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+CREATE INDEX IF NOT EXISTS auction_price_tender_idx ON auction_price(tender_id, price);
+
+-- +goose Down
+DROP TABLE auction_price CASCADE;
+DROP TABLE tender_auction CASCADE;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS contract (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    tender_id UUId NOT NULL UNIQUE REFERENCES tender(id) ON DELETE CASCADE,
+    bid_id UUId NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    bid_version INT NOT NULL,
+    price NUMERIC(15, 2),
+    buyer_organization_id UUId NOT NULL REFERENCES organization(id),
+    supplier_type bid_author_type NOT NULL,
+    supplier_id UUId NOT NULL REFERENCES employee(id),
+    supplier_organization_id UUId REFERENCES organization(id),
+    buyer_signer_id UUId REFERENCES employee(id),
+    buyer_signed_at TIMESTAMP,
+    supplier_signer_id UUId REFERENCES employee(id),
+    supplier_signed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+-- +goose Down
+DROP TABLE contract CASCADE;
@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TYPE milestone_status AS ENUM (
+  'Pending',
+  'Delivered',
+  'Accepted',
+  'Disputed'
+);
+
+CREATE TABLE IF NOT EXISTS contract_milestone (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    contract_id UUId NOT NULL REFERENCES contract(id) ON DELETE CASCADE,
+    description TEXT NOT NULL CHECK (char_length(description) <= 1000),
+    due_date TIMESTAMPTZ NOT NULL,
+    amount NUMERIC(15, 2) NOT NULL CHECK (amount >= 0),
+    status milestone_status NOT NULL DEFAULT 'Pending',
+    delivered_at TIMESTAMP,
+    resolved_at TIMESTAMP,
+    dispute_reason TEXT CHECK (char_length(dispute_reason) <= 1000),
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+CREATE INDEX IF NOT EXISTS contract_milestone_due_idx ON contract_milestone(due_date) WHERE status IN ('Pending', 'Disputed');
+
+-- +goose Down
+DROP TABLE contract_milestone CASCADE;
+DROP TYPE milestone_status CASCADE;
@@ -0,0 +1,57 @@
+-- +goose Up
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION tender_set_closed_at() RETURNS trigger AS $$
+BEGIN
+    IF NEW.status = 'Closed' AND OLD.status != 'Closed' THEN
+        NEW.closed_at := (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours');
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER tender_closed_at
+    BEFORE UPDATE OF status ON tender
+    FOR EACH ROW EXECUTE FUNCTION tender_set_closed_at();
+
+CREATE TYPE complaint_status AS ENUM (
+  'Filed',
+  'UnderReview',
+  'Upheld',
+  'Dismissed'
+);
+
+CREATE TABLE IF NOT EXISTS complaint (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    tender_id UUId NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    bid_id UUId NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    user_id UUId NOT NULL REFERENCES employee(id),
+    reason TEXT NOT NULL CHECK (char_length(reason) <= 1000),
+    freeze_award BOOLEAN NOT NULL DEFAULT FALSE,
+    status complaint_status NOT NULL DEFAULT 'Filed',
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+-- a bid has at most one complaint under consideration
+CREATE UNIQUE INDEX IF NOT EXISTS complaint_open_bid_idx ON complaint(bid_id) WHERE status IN ('Filed', 'UnderReview');
+CREATE INDEX IF NOT EXISTS complaint_tender_id_idx ON complaint(tender_id);
+
+CREATE TABLE IF NOT EXISTS complaint_response (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    complaint_id UUId NOT NULL REFERENCES complaint(id) ON DELETE CASCADE,
+    user_id UUId NOT NULL REFERENCES employee(id),
+    status complaint_status,
+    message TEXT NOT NULL CHECK (char_length(message) <= 1000),
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+-- +goose Down
+DROP TABLE complaint_response CASCADE;
+DROP TABLE complaint CASCADE;
+DROP TYPE complaint_status CASCADE;
+DROP TRIGGER tender_closed_at ON tender;
+DROP FUNCTION tender_set_closed_at;
+ALTER TABLE tender DROP COLUMN IF EXISTS closed_at;
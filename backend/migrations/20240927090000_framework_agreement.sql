@@ -0,0 +1,19 @@
+-- +goose Up
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS framework BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS call_off_order (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    tender_id UUId NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    bid_id UUId NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    user_id UUId NOT NULL REFERENCES employee(id),
+    description TEXT NOT NULL CHECK (char_length(description) <= 1000),
+    amount NUMERIC(15, 2) NOT NULL CHECK (amount > 0),
+    delivery_date TIMESTAMP,
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+CREATE INDEX IF NOT EXISTS call_off_order_tender_id_idx ON call_off_order(tender_id);
+
+-- +goose Down
+DROP TABLE call_off_order CASCADE;
+ALTER TABLE tender DROP COLUMN framework;
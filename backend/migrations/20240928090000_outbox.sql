@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS outbox_event (
+    id BIGSERIAL PRIMARY KEY,
+    type TEXT NOT NULL,
+    tender_id UUId NOT NULL,
+    bid_id UUId,
+    payload JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+CREATE TABLE IF NOT EXISTS outbox_offset (
+    sink TEXT PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    updated_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+-- +goose Down
+DROP TABLE outbox_offset CASCADE;
+DROP TABLE outbox_event CASCADE;
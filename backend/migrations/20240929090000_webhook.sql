@@ -0,0 +1,39 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS webhook (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUId NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    url TEXT NOT NULL CHECK (char_length(url) <= 2000),
+    event_types JSONB NOT NULL,
+    secret BYTEA NOT NULL,
+    user_id UUId NOT NULL REFERENCES employee(id),
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+CREATE INDEX IF NOT EXISTS webhook_organization_id_idx ON webhook(organization_id);
+
+CREATE TYPE webhook_delivery_status AS ENUM (
+  'Pending',
+  'Delivered',
+  'Dead'
+);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    webhook_id UUId NOT NULL REFERENCES webhook(id) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL REFERENCES outbox_event(id),
+    status webhook_delivery_status NOT NULL DEFAULT 'Pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT now(),
+    last_error TEXT,
+    response_status INT,
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours'),
+    UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_due_idx ON webhook_delivery(next_attempt_at) WHERE status = 'Pending';
+
+-- +goose Down
+DROP TABLE webhook_delivery CASCADE;
+DROP TYPE webhook_delivery_status CASCADE;
+DROP TABLE webhook CASCADE;
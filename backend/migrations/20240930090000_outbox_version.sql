@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE outbox_event ADD COLUMN IF NOT EXISTS tender_version INTEGER;
+ALTER TABLE outbox_event ADD COLUMN IF NOT EXISTS bid_version INTEGER;
+
+-- +goose Down
+ALTER TABLE outbox_event DROP COLUMN IF EXISTS bid_version;
+ALTER TABLE outbox_event DROP COLUMN IF EXISTS tender_version;
@@ -0,0 +1,38 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS notification_preference (
+    user_id UUId PRIMARY KEY REFERENCES employee(id) ON DELETE CASCADE,
+    email VARCHAR(254),
+    locale VARCHAR(2) NOT NULL DEFAULT 'ru' CHECK (locale IN ('ru', 'en')),
+    email_opt_out BOOLEAN NOT NULL DEFAULT false,
+    email_enabled_at TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+);
+
+CREATE TYPE email_status AS ENUM (
+  'Pending',
+  'Sent',
+  'Dead'
+);
+
+CREATE TABLE IF NOT EXISTS email_notification (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUId NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL REFERENCES outbox_event(id),
+    email VARCHAR(254) NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    status email_status NOT NULL DEFAULT 'Pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT now(),
+    last_error TEXT,
+    sent_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours'),
+    UNIQUE (user_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS email_notification_due_idx ON email_notification(next_attempt_at) WHERE status = 'Pending';
+
+-- +goose Down
+DROP TABLE email_notification CASCADE;
+DROP TYPE email_status CASCADE;
+DROP TABLE notification_preference CASCADE;
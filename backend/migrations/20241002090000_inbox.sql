@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS inbox_notification (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUId NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL REFERENCES outbox_event(id),
+    read_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours'),
+    UNIQUE (user_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS inbox_notification_unread_idx ON inbox_notification(user_id) WHERE read_at IS NULL;
+CREATE INDEX IF NOT EXISTS inbox_notification_created_at_idx ON inbox_notification(created_at);
+
+-- +goose Down
+DROP TABLE inbox_notification CASCADE;
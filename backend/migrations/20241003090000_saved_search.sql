@@ -0,0 +1,31 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS saved_search (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUId NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    name TEXT NOT NULL CHECK (char_length(name) BETWEEN 1 AND 100),
+    service_types JSONB NOT NULL DEFAULT '[]',
+    keywords JSONB NOT NULL DEFAULT '[]',
+    budget_min NUMERIC(15, 2) CHECK (budget_min >= 0),
+    budget_max NUMERIC(15, 2) CHECK (budget_max >= 0),
+    organization_id UUId REFERENCES organization(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours'),
+    CHECK (budget_min <= budget_max)
+);
+
+CREATE INDEX IF NOT EXISTS saved_search_user_id_idx ON saved_search(user_id);
+
+CREATE TABLE IF NOT EXISTS tender_alert (
+    id UUId PRIMARY KEY DEFAULT gen_random_uuid(),
+    saved_search_id UUId NOT NULL REFERENCES saved_search(id) ON DELETE CASCADE,
+    user_id UUId NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    tender_id UUId NOT NULL REFERENCES tender(id),
+    event_id BIGINT NOT NULL REFERENCES outbox_event(id),
+    created_at TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours'),
+    UNIQUE (saved_search_id, tender_id)
+);
+
+CREATE INDEX IF NOT EXISTS tender_alert_user_id_idx ON tender_alert(user_id);
+
+-- +goose Down
+DROP TABLE tender_alert CASCADE;
+DROP TABLE saved_search CASCADE;
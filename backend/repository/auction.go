@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"avito/db"
+	"avito/db/model"
+	"avito/domain"
+	"avito/log"
+	"context"
+	"database/sql"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type AuctionRep struct {
+	cli    db.DB
+	logger log.Logger
+}
+
+func NewAuctionRep(logger log.Logger, cli db.DB) *AuctionRep {
+	return &AuctionRep{
+		logger: logger,
+		cli:    cli,
+	}
+}
+
+func (rep *AuctionRep) UpsertAuction(ctx context.Context, auction *model.Auction) error {
+	_, err := rep.cli.Exec(ctx,
+		`INSERT INTO tender_auction(tender_id, start_at, end_at, min_step, extension_seconds) VALUES ($1, $2, $3, $4, $5)
+			   ON CONFLICT (tender_id) DO UPDATE SET start_at = EXCLUDED.start_at, end_at = EXCLUDED.end_at,
+			       min_step = EXCLUDED.min_step, extension_seconds = EXCLUDED.extension_seconds`,
+		auction.TenderId, auction.StartAt, auction.EndAt, auction.MinStep, auction.ExtensionSeconds)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Auction.UpsertAuction with tender id: "+auction.TenderId)
+	}
+
+	return nil
+}
+
+func (rep *AuctionRep) GetAuction(ctx context.Context, tenderId string) (*model.Auction, error) {
+	return rep.selectAuction(ctx, tenderId, "")
+}
+
+// LockAuction returns the auction and locks it until the end of the transaction,
+// so that the concurrent prices are checked against each other.
+func (rep *AuctionRep) LockAuction(ctx context.Context, tenderId string) (*model.Auction, error) {
+	return rep.selectAuction(ctx, tenderId, " FOR UPDATE")
+}
+
+func (rep *AuctionRep) selectAuction(ctx context.Context, tenderId, lock string) (*model.Auction, error) {
+	var auction model.Auction
+	err := rep.cli.SelectRow(ctx, &auction,
+		`SELECT tender_id, start_at, end_at, min_step, extension_seconds, created_at
+			  FROM tender_auction WHERE tender_id = $1`+lock, tenderId)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAuctionDoesNotExist
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Auction.selectAuction with tender id: "+tenderId)
+	}
+
+	return &auction, nil
+}
+
+func (rep *AuctionRep) GetAuctionState(ctx context.Context, tenderId string) (*model.AuctionState, error) {
+	var state model.AuctionState
+	err := rep.cli.SelectRow(ctx, &state,
+		`SELECT MIN(p.price) FILTER (WHERE b.status = 'Published') AS best_price, COUNT(*) AS prices
+			   FROM auction_price p JOIN bid b ON b.id = p.bid_id WHERE p.tender_id = $1`, tenderId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Auction.GetAuctionState with tender id: "+tenderId)
+	}
+
+	return &state, nil
+}
+
+func (rep *AuctionRep) InsertPrice(ctx context.Context, price *model.AuctionPrice) error {
+	err := rep.cli.SelectRow(ctx, price,
+		`INSERT INTO auction_price(tender_id, bid_id, price) VALUES ($1, $2, $3)
+			   RETURNING id, tender_id, bid_id, price, created_at`,
+		price.TenderId, price.BidId, price.Price)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Auction.InsertPrice with bid id: "+price.BidId)
+	}
+
+	return nil
+}
+
+func (rep *AuctionRep) ExtendAuction(ctx context.Context, tenderId string, endAt time.Time) error {
+	_, err := rep.cli.Exec(ctx, `UPDATE tender_auction SET end_at = $2 WHERE tender_id = $1`, tenderId, endAt)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Auction.ExtendAuction with tender id: "+tenderId)
+	}
+
+	return nil
+}
+
+// AuctionRanking orders the published bids by their best prices, the earlier price wins the tie.
+func (rep *AuctionRep) AuctionRanking(ctx context.Context, tenderId string) ([]model.AuctionRanking, error) {
+	var ranking []model.AuctionRanking
+	err := rep.cli.Select(ctx, &ranking,
+		`SELECT bid_id, price, placed_at FROM (
+				SELECT DISTINCT ON (p.bid_id) p.bid_id, p.price, p.created_at AS placed_at FROM auction_price p
+				JOIN bid b ON b.id = p.bid_id AND b.status = 'Published'
+				WHERE p.tender_id = $1 ORDER BY p.bid_id, p.price, p.created_at) best
+			  ORDER BY price, placed_at`, tenderId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Auction.AuctionRanking with tender id: "+tenderId)
+	}
+
+	return ranking, nil
+}
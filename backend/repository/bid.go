@@ -7,9 +7,17 @@ import (
 	"avito/log"
 	"avito/repository/cache"
 	"context"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pkg/errors"
 )
 
+// bidActiveAuthorIndex allows a single created or published bid per author and tender.
+const bidActiveAuthorIndex = "bid_active_author_uidx"
+
+// bidAuthorColumn shows the bidding organization instead of the employee for organization bids.
+const bidAuthorColumn = `COALESCE(b.organization_id, b.author_id) AS author_id`
+
 type BidRep struct {
 	cli                  db.DB
 	logger               log.Logger
@@ -29,13 +37,25 @@ func NewBidRep(logger log.Logger, cli db.DB, idsCache *cache.Set, usernameIdMatc
 }
 
 func (rep *BidRep) Insert(ctx context.Context, newBid *model.Bid) (string, error) {
+	var price, terms any
+	if newBid.Commercial != nil {
+		price, terms = newBid.Commercial.Price, newBid.Commercial.Terms
+	}
+
 	var bidId string
 	err := rep.cli.SelectRow(ctx, &bidId,
-		`WITH bid_id_t AS (INSERT INTO bid (tender_id, author_type, author_id) VALUES ($1, $2, $3) RETURNING id)
-			   INSERT INTO bid_content(name, description, bid_id) VALUES ($4, $5, (SELECT id FROM bid_id_t)) 
+		`WITH bid_id_t AS (INSERT INTO bid (tender_id, author_type, author_id, organization_id) 
+				VALUES ($1, $2, $3, $6) RETURNING id),
+			   commercial_t AS (INSERT INTO bid_commercial(bid_id, price, terms)
+				SELECT id, $8, $9 FROM bid_id_t WHERE $8::numeric IS NOT NULL)
+			   INSERT INTO bid_content(name, description, sealed_content, bid_id) VALUES ($4, $5, $7, (SELECT id FROM bid_id_t)) 
                RETURNING (SELECT id FROM bid_id_t)`,
 		newBid.TenderId, newBid.AuthorType, newBid.AuthorId,
-		newBid.Name, newBid.Description)
+		newBid.Name, newBid.Description, newBid.OrganizationId, newBid.SealedContent, price, terms)
+
+	if isActiveBidViolation(err) {
+		return "", domain.ErrActiveBidExists
+	}
 
 	if err != nil {
 		return "", errors.WithMessage(err, "Repository.Bid.Insert with name: "+newBid.Name)
@@ -53,7 +73,7 @@ func (rep *BidRep) GetById(ctx context.Context, bidId string) (*model.Bid, error
 
 	var bid model.Bid
 	err := rep.cli.SelectRow(ctx, &bid,
-		`SELECT b.id, c.name, b.status, b.author_type, b.author_id, b.version, b.created_at FROM bid b
+		`SELECT b.id, c.name, b.status, b.author_type, `+bidAuthorColumn+`, b.organization_id, b.version, b.created_at FROM bid b
     			JOIN bid_content c ON b.id = c.bid_id AND c.version = b.version WHERE id = $1`, bidId)
 
 	if err != nil {
@@ -72,11 +92,14 @@ func (rep *BidRep) GetByUsername(ctx context.Context, offset, limit int, usernam
 			c.name,
 			b.status,
 			b.author_type,
-			b.author_id,
+			` + bidAuthorColumn + `,
+			b.organization_id,
 			b.version,
 			b.created_at
  			FROM bid b JOIN bid_content c ON b.id = c.bid_id and b.version = c.version
-            WHERE author_id = $1 ORDER BY name OFFSET $2`
+            WHERE b.author_id = $1 OR b.organization_id IN (
+            	SELECT organization_id FROM organization_responsible WHERE user_id = $1)
+            ORDER BY name OFFSET $2`
 
 	var (
 		bid []model.Bid
@@ -101,16 +124,27 @@ func (rep *BidRep) GetByUsername(ctx context.Context, offset, limit int, usernam
 	return bid, nil
 }
 
-func (rep *BidRep) GetVisibleByTenderId(ctx context.Context, offset, limit int, tenderId string) ([]model.Bid, error) {
+// GetVisibleByTenderId returns the bids of the tender the user can see: own bids including drafts
+// and, if the user is responsible for the tender organization, all the bids that are not drafts.
+func (rep *BidRep) GetVisibleByTenderId(ctx context.Context, offset, limit int, tenderId, username string, isResponsible bool) ([]model.Bid, error) {
+	userId, found := rep.usernameIdMatchCache.Get(username)
+	if !found {
+		return nil, domain.ErrUserWithNameNotFound
+	}
+
 	query := `SELECT b.id,
 			c.name,
 			b.status,
 			b.author_type,
-			b.author_id,
+			` + bidAuthorColumn + `,
+			b.organization_id,
 			b.version,
 			b.created_at
  			FROM bid b JOIN bid_content c ON b.id = c.bid_id and b.version = c.version
-            WHERE tender_id = $1 AND status != 'Created' ORDER BY name OFFSET $2`
+            WHERE b.tender_id = $1 AND (
+            	(b.status != 'Created' AND $3) OR b.author_id = $2 OR b.organization_id IN (
+            		SELECT organization_id FROM organization_responsible WHERE user_id = $2))
+            ORDER BY name OFFSET $4`
 
 	var (
 		bid []model.Bid
@@ -122,10 +156,10 @@ func (rep *BidRep) GetVisibleByTenderId(ctx context.Context, offset, limit int,
 	}
 
 	if limit > 0 {
-		query += ` LIMIT $3`
-		err = rep.cli.Select(ctx, &bid, query, tenderId, offset, limit)
+		query += ` LIMIT $5`
+		err = rep.cli.Select(ctx, &bid, query, tenderId, userId, isResponsible, offset, limit)
 	} else {
-		err = rep.cli.Select(ctx, &bid, query, tenderId, offset)
+		err = rep.cli.Select(ctx, &bid, query, tenderId, userId, isResponsible, offset)
 	}
 
 	if err != nil {
@@ -135,6 +169,42 @@ func (rep *BidRep) GetVisibleByTenderId(ctx context.Context, offset, limit int,
 	return bid, nil
 }
 
+// UsernameHasBids reports whether the user manages any bid on the tender.
+func (rep *BidRep) UsernameHasBids(ctx context.Context, username, tenderId string) (bool, error) {
+	userId, found := rep.usernameIdMatchCache.Get(username)
+	if !found {
+		return false, domain.ErrUserWithNameNotFound
+	}
+
+	var hasBids bool
+	err := rep.cli.SelectRow(ctx, &hasBids,
+		`SELECT EXISTS(SELECT 1 FROM bid b WHERE b.tender_id = $1 AND (b.author_id = $2 OR b.organization_id IN (
+				SELECT organization_id FROM organization_responsible WHERE user_id = $2)))`, tenderId, userId)
+
+	if err != nil {
+		return false, errors.WithMessage(err, "Repository.Bid.UsernameHasBids with tender id: "+tenderId)
+	}
+
+	return hasBids, nil
+}
+
+// GetPseudonyms numbers the bid authors of the tender in the order of their first bids,
+// so that the pseudonym of the author never changes once assigned. The authors are keyed
+// as in bidAuthorColumn, the organization bids get the pseudonym of the organization.
+func (rep *BidRep) GetPseudonyms(ctx context.Context, tenderId string) ([]model.BidPseudonym, error) {
+	var pseudonyms []model.BidPseudonym
+	err := rep.cli.Select(ctx, &pseudonyms,
+		`SELECT COALESCE(b.organization_id, b.author_id) AS author_id,
+				'Bidder ' || ROW_NUMBER() OVER (ORDER BY MIN(b.created_at), COALESCE(b.organization_id, b.author_id)) AS pseudonym
+			  FROM bid b WHERE b.tender_id = $1 GROUP BY COALESCE(b.organization_id, b.author_id)`, tenderId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Bid.GetPseudonyms with tender id: "+tenderId)
+	}
+
+	return pseudonyms, nil
+}
+
 func (rep *BidRep) GetBidStatus(ctx context.Context, bidId string) (string, error) {
 	if !rep.idsCache.Exists(bidId) {
 		return "", domain.ErrBidDoesNotExist
@@ -172,10 +242,10 @@ func (rep *BidRep) UpdateById(ctx context.Context, bid *model.Bid) error {
 
 	_, err := rep.cli.Exec(ctx,
 		`WITH version_t AS (
-					INSERT INTO bid_content(name, description, version, bid_id) 
-					VALUES($1, $2, (SELECT MAX(version) FROM bid_content WHERE bid_id = $3)+1, $3) RETURNING version)
+					INSERT INTO bid_content(name, description, sealed_content, version, bid_id) 
+					VALUES($1, $2, $4, (SELECT MAX(version) FROM bid_content WHERE bid_id = $3)+1, $3) RETURNING version)
 			UPDATE bid SET version=(SELECT version FROM version_t) WHERE id = $3`,
-		bid.Name, bid.Description, bid.Id)
+		bid.Name, bid.Description, bid.Id, bid.SealedContent)
 
 	if err != nil {
 		return errors.WithMessage(err, "Repository.Bid.UpdateById with id: "+bid.Id)
@@ -199,20 +269,50 @@ func (rep *BidRep) GetAuthorId(ctx context.Context, bidId string) (string, error
 	return authorId, nil
 }
 
+// UsernameCanManage reports whether the user is the author of the bid or,
+// for the organization bids, is responsible for the bidding organization.
+func (rep *BidRep) UsernameCanManage(ctx context.Context, username, bidId string) (bool, error) {
+	if !rep.idsCache.Exists(bidId) {
+		return false, domain.ErrBidDoesNotExist
+	}
+
+	userId, found := rep.usernameIdMatchCache.Get(username)
+	if !found {
+		return false, domain.ErrUserWithNameNotFound
+	}
+
+	var canManage bool
+	err := rep.cli.SelectRow(ctx, &canManage,
+		`SELECT b.author_id = $2 OR EXISTS(SELECT 1 FROM organization_responsible r
+				WHERE r.organization_id = b.organization_id AND r.user_id = $2)
+			  FROM bid b WHERE b.id = $1`, bidId, userId)
+
+	if err != nil {
+		return false, errors.WithMessage(err, "Repository.Bid.UsernameCanManage with id: "+bidId)
+	}
+
+	return canManage, nil
+}
+
+// SetBidStatus changes the status of the created or published bid.
 func (rep *BidRep) SetBidStatus(ctx context.Context, bidId string, status string) error {
 	if !rep.idsCache.Exists(bidId) {
 		return domain.ErrBidDoesNotExist
 	}
 
 	res, err := rep.cli.Exec(ctx,
-		`UPDATE bid SET status = $1 WHERE id = $2`, status, bidId)
+		`UPDATE bid SET status = $1 WHERE id = $2 AND status IN ('Created', 'Published')`, status, bidId)
+
+	if isActiveBidViolation(err) {
+		return domain.ErrActiveBidExists
+	}
 
 	if err != nil {
 		return errors.WithMessage(err, "Repository.Bid.SetBidStatus with id: "+bidId)
 	}
 
 	if num, err := res.RowsAffected(); err != nil || num == 0 {
-		return errors.WithMessage(domain.ErrPublishedBidNotFound, "Repository.Bid.SetBidStatusIfOpen with id: "+bidId)
+		return errors.WithMessage(domain.ErrInvalidBidStatus, "Repository.Bid.SetBidStatus with id: "+bidId)
 	}
 
 	return nil
@@ -234,6 +334,73 @@ func (rep *BidRep) SetBidStatusIfOpen(ctx context.Context, bidId string, status
 	return tenderId, nil
 }
 
+// Withdraw cancels the created or published bid and stores the reason.
+func (rep *BidRep) Withdraw(ctx context.Context, withdrawal *model.BidWithdrawal) error {
+	if !rep.idsCache.Exists(withdrawal.BidId) {
+		return domain.ErrBidDoesNotExist
+	}
+
+	res, err := rep.cli.Exec(ctx,
+		`WITH withdrawn AS (
+					UPDATE bid SET status = 'Canceled' WHERE id = $1 AND status IN ('Created', 'Published') RETURNING id)
+			   INSERT INTO bid_withdrawal(bid_id, reason, user_id) SELECT id, $2, $3 FROM withdrawn`,
+		withdrawal.BidId, withdrawal.Reason, withdrawal.UserId)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Bid.Withdraw with id: "+withdrawal.BidId)
+	}
+
+	if num, err := res.RowsAffected(); err != nil || num == 0 {
+		return errors.WithMessage(domain.ErrBidIsNotActive, "Repository.Bid.Withdraw with id: "+withdrawal.BidId)
+	}
+
+	return nil
+}
+
+// Resubmit reopens the withdrawn bid as Created with a new version of its last content.
+func (rep *BidRep) Resubmit(ctx context.Context, bidId string) error {
+	if !rep.idsCache.Exists(bidId) {
+		return domain.ErrBidDoesNotExist
+	}
+
+	res, err := rep.cli.Exec(ctx,
+		`WITH reopened AS (
+					UPDATE bid SET status = 'Created',
+						version = (SELECT MAX(version) FROM bid_content WHERE bid_id = $1) + 1
+					WHERE id = $1 AND status = 'Canceled' AND EXISTS(SELECT 1 FROM bid_withdrawal WHERE bid_id = $1)
+					RETURNING id, version)
+			   INSERT INTO bid_content(name, description, sealed_content, bid_id, version)
+			   SELECT c.name, c.description, c.sealed_content, c.bid_id, r.version FROM reopened r
+			   JOIN bid b ON b.id = r.id
+			   JOIN bid_content c ON c.bid_id = b.id AND c.version = b.version`, bidId)
+
+	if isActiveBidViolation(err) {
+		return domain.ErrActiveBidExists
+	}
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Bid.Resubmit with id: "+bidId)
+	}
+
+	if num, err := res.RowsAffected(); err != nil || num == 0 {
+		return errors.WithMessage(domain.ErrBidIsNotWithdrawn, "Repository.Bid.Resubmit with id: "+bidId)
+	}
+
+	return nil
+}
+
+func (rep *BidRep) CloseOpenBids(ctx context.Context, tenderId string) error {
+	_, err := rep.cli.Exec(ctx,
+		`UPDATE bid SET status = CASE WHEN status = 'Published' THEN 'Rejected'::bid_status ELSE 'Canceled'::bid_status END
+            	WHERE tender_id = $1 AND status IN ('Created', 'Published')`, tenderId)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Bid.CloseOpenBids with tender id: "+tenderId)
+	}
+
+	return nil
+}
+
 func (rep *BidRep) Rollback(ctx context.Context, bidId string, version int) error {
 	if !rep.idsCache.Exists(bidId) {
 		return domain.ErrBidDoesNotExist
@@ -242,8 +409,8 @@ func (rep *BidRep) Rollback(ctx context.Context, bidId string, version int) erro
 	_, err := rep.cli.Exec(ctx,
 		`WITH 
 					last_version AS (
-    					INSERT INTO bid_content(name, description, bid_id, version) 
-    					SELECT name, description, bid_id, 
+    					INSERT INTO bid_content(name, description, sealed_content, bid_id, version) 
+    					SELECT name, description, sealed_content, bid_id, 
 							(SELECT MAX(version) FROM bid_content WHERE bid_id = $1) + 1
 						FROM bid_content
 						WHERE bid_id = $1 and version = $2
@@ -274,6 +441,151 @@ func (rep *BidRep) GetOrgIdByBidId(ctx context.Context, bidId string) (string, e
 	return orgId, nil
 }
 
+func (rep *BidRep) GetTenderId(ctx context.Context, bidId string) (string, error) {
+	if !rep.idsCache.Exists(bidId) {
+		return "", domain.ErrBidDoesNotExist
+	}
+
+	var tenderId string
+	err := rep.cli.SelectRow(ctx, &tenderId, `SELECT tender_id FROM bid WHERE id = $1`, bidId)
+
+	if err != nil {
+		return "", errors.WithMessage(err, "Repository.Bid.GetTenderId with id: "+bidId)
+	}
+
+	return tenderId, nil
+}
+
+// GetContent returns the current content of the bid, which is encrypted for the sealed tenders.
+func (rep *BidRep) GetContent(ctx context.Context, bidId string) (*model.BidContent, error) {
+	if !rep.idsCache.Exists(bidId) {
+		return nil, domain.ErrBidDoesNotExist
+	}
+
+	var content model.BidContent
+	err := rep.cli.SelectRow(ctx, &content,
+		`SELECT c.bid_id, c.version, c.name, c.description, c.sealed_content FROM bid b
+			  JOIN bid_content c ON c.bid_id = b.id AND c.version = b.version WHERE b.id = $1`, bidId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Bid.GetContent with id: "+bidId)
+	}
+
+	return &content, nil
+}
+
+// GetSealedContents returns all the encrypted versions of the tender bids.
+func (rep *BidRep) GetSealedContents(ctx context.Context, tenderId string) ([]model.BidContent, error) {
+	var contents []model.BidContent
+	err := rep.cli.Select(ctx, &contents,
+		`SELECT c.bid_id, c.version, c.name, c.description, c.sealed_content FROM bid_content c
+			  JOIN bid b ON b.id = c.bid_id WHERE b.tender_id = $1 AND c.sealed_content IS NOT NULL`, tenderId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Bid.GetSealedContents with tender id: "+tenderId)
+	}
+
+	return contents, nil
+}
+
+// UnsealContent replaces the encrypted version of the bid with the decrypted name and description.
+func (rep *BidRep) UnsealContent(ctx context.Context, content *model.BidContent) error {
+	_, err := rep.cli.Exec(ctx,
+		`UPDATE bid_content SET name = $3, description = $4, sealed_content = NULL WHERE bid_id = $1 AND version = $2`,
+		content.BidId, content.Version, content.Name, content.Description)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Bid.UnsealContent with id: "+content.BidId)
+	}
+
+	return nil
+}
+
+func (rep *BidRep) UpsertCommercial(ctx context.Context, part *model.CommercialPart) error {
+	if !rep.idsCache.Exists(part.BidId) {
+		return domain.ErrBidDoesNotExist
+	}
+
+	_, err := rep.cli.Exec(ctx,
+		`INSERT INTO bid_commercial(bid_id, price, terms) VALUES ($1, $2, $3)
+			   ON CONFLICT (bid_id) DO UPDATE SET price = EXCLUDED.price, terms = EXCLUDED.terms,
+			       updated_at = (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')`,
+		part.BidId, part.Price, part.Terms)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Bid.UpsertCommercial with id: "+part.BidId)
+	}
+
+	return nil
+}
+
+func (rep *BidRep) GetCommercial(ctx context.Context, bidId string) (*model.CommercialPart, error) {
+	if !rep.idsCache.Exists(bidId) {
+		return nil, domain.ErrBidDoesNotExist
+	}
+
+	var part model.CommercialPart
+	err := rep.cli.SelectRow(ctx, &part,
+		`SELECT bid_id, price, terms, updated_at FROM bid_commercial WHERE bid_id = $1`, bidId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Bid.GetCommercial with id: "+bidId)
+	}
+
+	return &part, nil
+}
+
+func (rep *BidRep) IsShortlisted(ctx context.Context, bidId string) (bool, error) {
+	var shortlisted bool
+	err := rep.cli.SelectRow(ctx, &shortlisted, `SELECT EXISTS(SELECT 1 FROM bid_shortlist WHERE bid_id = $1)`, bidId)
+
+	if err != nil {
+		return false, errors.WithMessage(err, "Repository.Bid.IsShortlisted with id: "+bidId)
+	}
+
+	return shortlisted, nil
+}
+
+// Shortlist adds the published bids of the tender to the shortlist and returns how many were added.
+func (rep *BidRep) Shortlist(ctx context.Context, tenderId string, bidIds []string, userId string) (int64, error) {
+	res, err := rep.cli.Exec(ctx,
+		`INSERT INTO bid_shortlist(bid_id, user_id)
+			   SELECT b.id, $3 FROM bid b WHERE b.tender_id = $1 AND b.id = ANY($2::uuid[]) AND b.status = 'Published'`,
+		tenderId, bidIds, userId)
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.InvalidTextRepresentation:
+			return 0, domain.ErrInvalidShortlist
+		}
+	}
+
+	if err != nil {
+		return 0, errors.WithMessage(err, "Repository.Bid.Shortlist with tender id: "+tenderId)
+	}
+
+	num, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.WithMessage(err, "Repository.Bid.Shortlist with tender id: "+tenderId)
+	}
+
+	return num, nil
+}
+
+func (rep *BidRep) GetShortlist(ctx context.Context, tenderId string) ([]model.ShortlistEntry, error) {
+	var shortlist []model.ShortlistEntry
+	err := rep.cli.Select(ctx, &shortlist,
+		`SELECT s.bid_id, s.user_id, s.created_at FROM bid_shortlist s
+			  JOIN bid b ON b.id = s.bid_id WHERE b.tender_id = $1 ORDER BY s.created_at`, tenderId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Bid.GetShortlist with tender id: "+tenderId)
+	}
+
+	return shortlist, nil
+}
+
 func (rep *BidRep) GetBidIds(ctx context.Context) ([]string, error) {
 	var ids []string
 	err := rep.cli.Select(ctx, &ids, `SELECT id FROM bid`)
@@ -293,3 +605,35 @@ func (rep *BidRep) GetUserIdByName(ctx context.Context, username string) (string
 
 	return userId, nil
 }
+
+func (rep *BidRep) InsertConflictOverride(ctx context.Context, override *model.ConflictOverride) error {
+	_, err := rep.cli.Exec(ctx,
+		`INSERT INTO bid_conflict_override(bid_id, tender_id, user_id, organization_id, justification)
+			   VALUES ($1, $2, $3, $4, $5)`,
+		override.BidId, override.TenderId, override.UserId, override.OrganizationId, override.Justification)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Bid.InsertConflictOverride with bid id: "+override.BidId)
+	}
+
+	return nil
+}
+
+func (rep *BidRep) GetConflictOverrides(ctx context.Context, tenderId string) ([]model.ConflictOverride, error) {
+	var overrides []model.ConflictOverride
+	err := rep.cli.Select(ctx, &overrides,
+		`SELECT id, bid_id, tender_id, user_id, organization_id, justification, created_at
+			  FROM bid_conflict_override WHERE tender_id = $1 ORDER BY created_at`, tenderId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Bid.GetConflictOverrides with tender id: "+tenderId)
+	}
+
+	return overrides, nil
+}
+
+func isActiveBidViolation(err error) bool {
+	pgErr := &pgconn.PgError{}
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == bidActiveAuthorIndex
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"avito/db"
+	"avito/db/model"
+	"avito/domain"
+	"avito/log"
+	"context"
+	"database/sql"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type ComplaintRep struct {
+	cli    db.DB
+	logger log.Logger
+}
+
+func NewComplaintRep(logger log.Logger, cli db.DB) *ComplaintRep {
+	return &ComplaintRep{
+		logger: logger,
+		cli:    cli,
+	}
+}
+
+// ComplaintWindowOpen reports whether the tender was closed by a decision no longer than the window ago,
+// the canceled tender has no decision to complain about.
+func (rep *ComplaintRep) ComplaintWindowOpen(ctx context.Context, tenderId string, window time.Duration) (bool, error) {
+	var isOpen bool
+	err := rep.cli.SelectRow(ctx, &isOpen,
+		`SELECT COALESCE(t.closed_at + $2 * INTERVAL '1 second' > (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours'), FALSE)
+			    AND NOT EXISTS (SELECT 1 FROM tender_cancellation c WHERE c.tender_id = t.id)
+			  FROM tender t WHERE t.id = $1`,
+		tenderId, window.Seconds())
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, domain.ErrTenderDoesNotExist
+	}
+
+	if err != nil {
+		return false, errors.WithMessage(err, "Repository.Complaint.ComplaintWindowOpen with tender id: "+tenderId)
+	}
+
+	return isOpen, nil
+}
+
+func (rep *ComplaintRep) InsertComplaint(ctx context.Context, complaint *model.Complaint) (string, error) {
+	var complaintId string
+	err := rep.cli.SelectRow(ctx, &complaintId,
+		`INSERT INTO complaint(tender_id, bid_id, user_id, reason, freeze_award) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		complaint.TenderId, complaint.BidId, complaint.UserId, complaint.Reason, complaint.FreezeAward)
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return "", domain.ErrComplaintAlreadyOpen
+	}
+
+	if err != nil {
+		return "", errors.WithMessage(err, "Repository.Complaint.InsertComplaint with bid id: "+complaint.BidId)
+	}
+
+	return complaintId, nil
+}
+
+const complaintColumns = `id, tender_id, bid_id, user_id, reason, freeze_award, status, resolved_at, created_at`
+
+func (rep *ComplaintRep) GetComplaint(ctx context.Context, complaintId string) (*model.Complaint, error) {
+	var complaint model.Complaint
+	err := rep.cli.SelectRow(ctx, &complaint,
+		`SELECT `+complaintColumns+` FROM complaint WHERE id = $1`, complaintId)
+
+	pgErr := &pgconn.PgError{}
+	if errors.Is(err, sql.ErrNoRows) || errors.As(err, &pgErr) && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+		return nil, domain.ErrComplaintDoesNotExist
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Complaint.GetComplaint with id: "+complaintId)
+	}
+
+	return &complaint, nil
+}
+
+func (rep *ComplaintRep) GetComplaints(ctx context.Context, tenderId string) ([]model.Complaint, error) {
+	var complaints []model.Complaint
+	err := rep.cli.Select(ctx, &complaints,
+		`SELECT `+complaintColumns+` FROM complaint WHERE tender_id = $1 ORDER BY created_at`, tenderId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Complaint.GetComplaints with tender id: "+tenderId)
+	}
+
+	return complaints, nil
+}
+
+// SetComplaintStatus moves the complaint to the status if it is in one of the given ones.
+func (rep *ComplaintRep) SetComplaintStatus(ctx context.Context, complaintId string, from []model.ComplaintStatus,
+	to model.ComplaintStatus) error {
+	statuses := make([]string, len(from))
+	for i := range from {
+		statuses[i] = string(from[i])
+	}
+
+	res, err := rep.cli.Exec(ctx,
+		`UPDATE complaint SET status = $3,
+				resolved_at = CASE WHEN $3 IN ('Upheld', 'Dismissed')
+					THEN (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours') END
+			  WHERE id = $1 AND status = ANY($2::complaint_status[])`,
+		complaintId, statuses, to)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Complaint.SetComplaintStatus with id: "+complaintId)
+	}
+
+	if num, err := res.RowsAffected(); err != nil || num == 0 {
+		return errors.WithMessage(domain.ErrComplaintTransition, "Repository.Complaint.SetComplaintStatus with id: "+complaintId)
+	}
+
+	return nil
+}
+
+func (rep *ComplaintRep) InsertResponse(ctx context.Context, response *model.ComplaintResponse) error {
+	_, err := rep.cli.Exec(ctx,
+		`INSERT INTO complaint_response(complaint_id, user_id, status, message) VALUES ($1, $2, $3, $4)`,
+		response.ComplaintId, response.UserId, response.Status, response.Message)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Complaint.InsertResponse with complaint id: "+response.ComplaintId)
+	}
+
+	return nil
+}
+
+// GetResponses returns the responses to the complaints in the order they were given.
+func (rep *ComplaintRep) GetResponses(ctx context.Context, complaintIds []string) ([]model.ComplaintResponse, error) {
+	var responses []model.ComplaintResponse
+	err := rep.cli.Select(ctx, &responses,
+		`SELECT id, complaint_id, user_id, status, message, created_at FROM complaint_response
+			  WHERE complaint_id = ANY($1::uuid[]) ORDER BY created_at, id`, complaintIds)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Complaint.GetResponses")
+	}
+
+	return responses, nil
+}
+
+// AwardFrozen reports whether the tender has an open complaint that freezes its award.
+func (rep *ComplaintRep) AwardFrozen(ctx context.Context, tenderId string) (bool, error) {
+	var isFrozen bool
+	err := rep.cli.SelectRow(ctx, &isFrozen,
+		`SELECT EXISTS (SELECT 1 FROM complaint
+			  WHERE tender_id = $1 AND freeze_award AND status IN ('Filed', 'UnderReview'))`, tenderId)
+
+	if err != nil {
+		return false, errors.WithMessage(err, "Repository.Complaint.AwardFrozen with tender id: "+tenderId)
+	}
+
+	return isFrozen, nil
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"avito/db"
+	"avito/db/model"
+	"avito/domain"
+	"avito/log"
+	"context"
+	"database/sql"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+type ContractRep struct {
+	cli    db.DB
+	logger log.Logger
+}
+
+func NewContractRep(logger log.Logger, cli db.DB) *ContractRep {
+	return &ContractRep{
+		logger: logger,
+		cli:    cli,
+	}
+}
+
+// InsertContract awards the contract to the current version of the bid. The agreed price is
+// the best auction price of the bid, otherwise the price of its commercial part.
+func (rep *ContractRep) InsertContract(ctx context.Context, bidId string) error {
+	_, err := rep.cli.Exec(ctx,
+		`INSERT INTO contract(tender_id, bid_id, bid_version, price, buyer_organization_id,
+				supplier_type, supplier_id, supplier_organization_id)
+			   SELECT b.tender_id, b.id, b.version,
+				COALESCE((SELECT MIN(p.price) FROM auction_price p WHERE p.bid_id = b.id),
+					(SELECT c.price FROM bid_commercial c WHERE c.bid_id = b.id)),
+				t.organization_id, b.author_type, b.author_id, b.organization_id
+			   FROM bid b JOIN tender t ON t.id = b.tender_id WHERE b.id = $1`, bidId)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Contract.InsertContract with bid id: "+bidId)
+	}
+
+	return nil
+}
+
+func (rep *ContractRep) GetContract(ctx context.Context, tenderId string) (*model.Contract, error) {
+	var contract model.Contract
+	err := rep.cli.SelectRow(ctx, &contract,
+		`SELECT id, tender_id, bid_id, bid_version, price, buyer_organization_id, supplier_type, supplier_id,
+       			supplier_organization_id, buyer_signer_id, buyer_signed_at, supplier_signer_id, supplier_signed_at,
+       			created_at FROM contract WHERE tender_id = $1`, tenderId)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrContractDoesNotExist
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Contract.GetContract with tender id: "+tenderId)
+	}
+
+	return &contract, nil
+}
+
+// SignContract signs the contract on behalf of the side unless it has already signed.
+func (rep *ContractRep) SignContract(ctx context.Context, tenderId string, side model.ContractSide, userId string) error {
+	query := `UPDATE contract SET buyer_signer_id = $2,
+				buyer_signed_at = (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+			  WHERE tender_id = $1 AND buyer_signed_at IS NULL`
+	if side == model.ContractSideSupplier {
+		query = `UPDATE contract SET supplier_signer_id = $2,
+				supplier_signed_at = (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+			  WHERE tender_id = $1 AND supplier_signed_at IS NULL`
+	}
+
+	res, err := rep.cli.Exec(ctx, query, tenderId, userId)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Contract.SignContract with tender id: "+tenderId)
+	}
+
+	if num, err := res.RowsAffected(); err != nil || num == 0 {
+		return errors.WithMessage(domain.ErrContractAlreadySigned, "Repository.Contract.SignContract with tender id: "+tenderId)
+	}
+
+	return nil
+}
+
+func (rep *ContractRep) InsertMilestone(ctx context.Context, milestone *model.Milestone) (string, error) {
+	var milestoneId string
+	err := rep.cli.SelectRow(ctx, &milestoneId,
+		`INSERT INTO contract_milestone(contract_id, description, due_date, amount) VALUES ($1, $2, $3, $4) RETURNING id`,
+		milestone.ContractId, milestone.Description, milestone.DueDate, milestone.Amount)
+
+	if err != nil {
+		return "", errors.WithMessage(err, "Repository.Contract.InsertMilestone with contract id: "+milestone.ContractId)
+	}
+
+	return milestoneId, nil
+}
+
+const milestoneColumns = `m.id, m.contract_id, c.tender_id, m.description, m.due_date, m.amount, m.status,
+		m.delivered_at, m.resolved_at, m.dispute_reason, m.created_at`
+
+func (rep *ContractRep) GetMilestone(ctx context.Context, milestoneId string) (*model.Milestone, error) {
+	var milestone model.Milestone
+	err := rep.cli.SelectRow(ctx, &milestone,
+		`SELECT `+milestoneColumns+` FROM contract_milestone m JOIN contract c ON c.id = m.contract_id
+			  WHERE m.id = $1`, milestoneId)
+
+	pgErr := &pgconn.PgError{}
+	if errors.Is(err, sql.ErrNoRows) || errors.As(err, &pgErr) && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+		return nil, domain.ErrMilestoneDoesNotExist
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Contract.GetMilestone with id: "+milestoneId)
+	}
+
+	return &milestone, nil
+}
+
+func (rep *ContractRep) GetMilestones(ctx context.Context, tenderId string) ([]model.Milestone, error) {
+	var milestones []model.Milestone
+	err := rep.cli.Select(ctx, &milestones,
+		`SELECT `+milestoneColumns+` FROM contract_milestone m JOIN contract c ON c.id = m.contract_id
+			  WHERE c.tender_id = $1 ORDER BY m.due_date, m.created_at`, tenderId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Contract.GetMilestones with tender id: "+tenderId)
+	}
+
+	return milestones, nil
+}
+
+// OverdueMilestones returns the milestones past their due date that are not delivered yet
+// on the contracts where the organization is the buyer or the supplier.
+func (rep *ContractRep) OverdueMilestones(ctx context.Context, orgId string) ([]model.Milestone, error) {
+	var milestones []model.Milestone
+	err := rep.cli.Select(ctx, &milestones,
+		`SELECT `+milestoneColumns+` FROM contract_milestone m JOIN contract c ON c.id = m.contract_id
+			  WHERE (c.buyer_organization_id = $1 OR c.supplier_organization_id = $1)
+			    AND m.status IN ('Pending', 'Disputed') AND m.due_date < now()
+			  ORDER BY m.due_date`, orgId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Contract.OverdueMilestones with organization id: "+orgId)
+	}
+
+	return milestones, nil
+}
+
+// SetMilestoneStatus moves the milestone to the status if it is in one of the given ones.
+func (rep *ContractRep) SetMilestoneStatus(ctx context.Context, milestoneId string, from []model.MilestoneStatus,
+	to model.MilestoneStatus, disputeReason *string) error {
+	statuses := make([]string, len(from))
+	for i := range from {
+		statuses[i] = string(from[i])
+	}
+
+	res, err := rep.cli.Exec(ctx,
+		`UPDATE contract_milestone SET status = $3, dispute_reason = COALESCE($4, dispute_reason),
+				delivered_at = CASE WHEN $3 = 'Delivered' THEN (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+					ELSE delivered_at END,
+				resolved_at = CASE WHEN $3 = 'Delivered' THEN NULL
+					ELSE (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours') END
+			  WHERE id = $1 AND status = ANY($2::milestone_status[])`,
+		milestoneId, statuses, to, disputeReason)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Contract.SetMilestoneStatus with id: "+milestoneId)
+	}
+
+	if num, err := res.RowsAffected(); err != nil || num == 0 {
+		return errors.WithMessage(domain.ErrMilestoneTransition, "Repository.Contract.SetMilestoneStatus with id: "+milestoneId)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"avito/db"
+	"avito/db/model"
+	"avito/domain"
+	"avito/log"
+	"context"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+type CriterionRep struct {
+	cli    db.DB
+	logger log.Logger
+}
+
+func NewCriterionRep(logger log.Logger, cli db.DB) *CriterionRep {
+	return &CriterionRep{
+		logger: logger,
+		cli:    cli,
+	}
+}
+
+func (rep *CriterionRep) ReplaceCriteria(ctx context.Context, tenderId string, criteria []model.Criterion) error {
+	names := make([]string, len(criteria))
+	weights := make([]float64, len(criteria))
+	for i := range criteria {
+		names[i] = criteria[i].Name
+		weights[i] = criteria[i].Weight
+	}
+
+	_, err := rep.cli.Exec(ctx,
+		`WITH deleted AS (DELETE FROM tender_criterion WHERE tender_id = $1)
+			   INSERT INTO tender_criterion(tender_id, name, weight)
+			   SELECT $1, c.name, c.weight FROM unnest($2::text[], $3::float8[]) AS c(name, weight)`,
+		tenderId, names, weights)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Criterion.ReplaceCriteria with tender id: "+tenderId)
+	}
+
+	return nil
+}
+
+func (rep *CriterionRep) GetCriteria(ctx context.Context, tenderId string) ([]model.Criterion, error) {
+	var criteria []model.Criterion
+	err := rep.cli.Select(ctx, &criteria,
+		`SELECT id, tender_id, name, weight, created_at FROM tender_criterion
+              WHERE tender_id = $1 ORDER BY weight DESC, name`, tenderId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Criterion.GetCriteria with tender id: "+tenderId)
+	}
+
+	return criteria, nil
+}
+
+func (rep *CriterionRep) HasScores(ctx context.Context, tenderId string) (bool, error) {
+	var hasScores bool
+	err := rep.cli.SelectRow(ctx, &hasScores,
+		`SELECT EXISTS(SELECT 1 FROM bid_score s JOIN tender_criterion c ON c.id = s.criterion_id WHERE c.tender_id = $1)`,
+		tenderId)
+
+	if err != nil {
+		return false, errors.WithMessage(err, "Repository.Criterion.HasScores with tender id: "+tenderId)
+	}
+
+	return hasScores, nil
+}
+
+func (rep *CriterionRep) SaveScores(ctx context.Context, bidId, userId string, scores []model.BidScore) error {
+	criterionIds := make([]string, len(scores))
+	values := make([]int, len(scores))
+	comments := make([]string, len(scores))
+	for i := range scores {
+		criterionIds[i] = scores[i].CriterionId
+		values[i] = scores[i].Score
+		comments[i] = scores[i].Comment
+	}
+
+	_, err := rep.cli.Exec(ctx,
+		`INSERT INTO bid_score(bid_id, criterion_id, user_id, score, comment)
+			   SELECT $1, s.criterion_id::uuid, $2, s.score, s.comment
+			   FROM unnest($3::text[], $4::int[], $5::text[]) AS s(criterion_id, score, comment)
+			   ON CONFLICT (bid_id, criterion_id, user_id) DO UPDATE
+			   SET score = EXCLUDED.score, comment = EXCLUDED.comment,
+			       updated_at = (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')`,
+		bidId, userId, criterionIds, values, comments)
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.RaiseException:
+			return domain.ErrScoresLocked
+		}
+	}
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Criterion.SaveScores with bid id: "+bidId)
+	}
+
+	return nil
+}
+
+func (rep *CriterionRep) GetScores(ctx context.Context, bidId, userId string) ([]model.BidScore, error) {
+	var scores []model.BidScore
+	err := rep.cli.Select(ctx, &scores,
+		`SELECT id, bid_id, criterion_id, user_id, score, comment, created_at, updated_at FROM bid_score
+              WHERE bid_id = $1 AND user_id = $2 ORDER BY created_at`, bidId, userId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Criterion.GetScores with bid id: "+bidId)
+	}
+
+	return scores, nil
+}
+
+// Ranking orders the tender bids by the weighted average of the scores given by all evaluators.
+// Criteria that were not scored yet count as zero.
+func (rep *CriterionRep) Ranking(ctx context.Context, tenderId string) ([]model.BidRanking, error) {
+	var ranking []model.BidRanking
+	err := rep.cli.Select(ctx, &ranking,
+		`WITH avg_score AS (
+					SELECT s.bid_id, s.criterion_id, AVG(s.score) AS score FROM bid_score s
+					JOIN tender_criterion c ON c.id = s.criterion_id
+					WHERE c.tender_id = $1 GROUP BY s.bid_id, s.criterion_id),
+				total_weight AS (
+					SELECT SUM(weight) AS weight FROM tender_criterion WHERE tender_id = $1)
+			SELECT b.id AS bid_id, bc.name, b.status, b.author_type, `+bidAuthorColumn+`,
+				COALESCE(SUM(a.score * c.weight) / NULLIF((SELECT weight FROM total_weight), 0), 0) AS score,
+				COUNT(a.criterion_id) AS scored_criteria
+			FROM bid b
+			JOIN bid_content bc ON bc.bid_id = b.id AND bc.version = b.version
+			LEFT JOIN avg_score a ON a.bid_id = b.id
+			LEFT JOIN tender_criterion c ON c.id = a.criterion_id
+			WHERE b.tender_id = $1 AND b.status IN ('Published', 'Approved', 'Rejected')
+			GROUP BY b.id, bc.name
+			ORDER BY score DESC, b.created_at`, tenderId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Criterion.Ranking with tender id: "+tenderId)
+	}
+
+	return ranking, nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"avito/db"
+	"avito/db/model"
+	"avito/domain"
+	"avito/log"
+	"context"
+	"database/sql"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+type EnvelopeRep struct {
+	cli    db.DB
+	logger log.Logger
+}
+
+func NewEnvelopeRep(logger log.Logger, cli db.DB) *EnvelopeRep {
+	return &EnvelopeRep{
+		logger: logger,
+		cli:    cli,
+	}
+}
+
+// GetOrCreateKey stores the encrypted key of the tender unless it already has one
+// and returns the stored key.
+func (rep *EnvelopeRep) GetOrCreateKey(ctx context.Context, tenderId string, encryptedKey []byte) ([]byte, error) {
+	var storedKey []byte
+	err := rep.cli.SelectRow(ctx, &storedKey,
+		`WITH inserted AS (
+					INSERT INTO tender_bid_key(tender_id, encrypted_key) VALUES ($1, $2)
+					ON CONFLICT (tender_id) DO NOTHING RETURNING encrypted_key)
+			   SELECT encrypted_key FROM inserted
+			   UNION ALL
+			   SELECT encrypted_key FROM tender_bid_key WHERE tender_id = $1
+			   LIMIT 1`, tenderId, encryptedKey)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Envelope.GetOrCreateKey with tender id: "+tenderId)
+	}
+
+	return storedKey, nil
+}
+
+// GetKey returns the encrypted key of the tender or nil if nobody has bid on it yet.
+func (rep *EnvelopeRep) GetKey(ctx context.Context, tenderId string) ([]byte, error) {
+	var encryptedKey []byte
+	err := rep.cli.SelectRow(ctx, &encryptedKey,
+		`SELECT encrypted_key FROM tender_bid_key WHERE tender_id = $1`, tenderId)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Envelope.GetKey with tender id: "+tenderId)
+	}
+
+	return encryptedKey, nil
+}
+
+func (rep *EnvelopeRep) InsertOpening(ctx context.Context, opening *model.EnvelopeOpening) error {
+	_, err := rep.cli.Exec(ctx,
+		`INSERT INTO tender_envelope_opening(tender_id, user_id) VALUES ($1, $2)`,
+		opening.TenderId, opening.UserId)
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.UniqueViolation:
+			return domain.ErrEnvelopesOpened
+		}
+	}
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Envelope.InsertOpening with tender id: "+opening.TenderId)
+	}
+
+	return nil
+}
+
+func (rep *EnvelopeRep) GetOpening(ctx context.Context, tenderId string) (*model.EnvelopeOpening, error) {
+	var opening model.EnvelopeOpening
+	err := rep.cli.SelectRow(ctx, &opening,
+		`SELECT tender_id, user_id, created_at FROM tender_envelope_opening WHERE tender_id = $1`, tenderId)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrEnvelopesNotOpened
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Envelope.GetOpening with tender id: "+tenderId)
+	}
+
+	return &opening, nil
+}
+
+// DueOpenings returns the sealed tenders whose bid deadline has passed but the envelopes are still sealed.
+func (rep *EnvelopeRep) DueOpenings(ctx context.Context) ([]string, error) {
+	var ids []string
+	err := rep.cli.Select(ctx, &ids,
+		`SELECT t.id FROM tender t WHERE t.sealed AND t.bid_deadline <= now()
+			  AND NOT EXISTS(SELECT 1 FROM tender_envelope_opening o WHERE o.tender_id = t.id)`)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Envelope.DueOpenings")
+	}
+
+	return ids, nil
+}
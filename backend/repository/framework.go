@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"avito/db"
+	"avito/db/model"
+	"avito/log"
+	"context"
+	"github.com/pkg/errors"
+)
+
+type FrameworkRep struct {
+	cli    db.DB
+	logger log.Logger
+}
+
+func NewFrameworkRep(logger log.Logger, cli db.DB) *FrameworkRep {
+	return &FrameworkRep{
+		logger: logger,
+		cli:    cli,
+	}
+}
+
+func (rep *FrameworkRep) InsertCallOffOrder(ctx context.Context, order *model.CallOffOrder) (string, error) {
+	var orderId string
+	err := rep.cli.SelectRow(ctx, &orderId,
+		`INSERT INTO call_off_order(tender_id, bid_id, user_id, description, amount, delivery_date)
+			   VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+		order.TenderId, order.BidId, order.UserId, order.Description, order.Amount, order.DeliveryDate)
+
+	if err != nil {
+		return "", errors.WithMessage(err, "Repository.Framework.InsertCallOffOrder with tender id: "+order.TenderId)
+	}
+
+	return orderId, nil
+}
+
+const callOffOrderColumns = `id, tender_id, bid_id, user_id, description, amount, delivery_date, created_at`
+
+func (rep *FrameworkRep) GetCallOffOrder(ctx context.Context, orderId string) (*model.CallOffOrder, error) {
+	var order model.CallOffOrder
+	err := rep.cli.SelectRow(ctx, &order,
+		`SELECT `+callOffOrderColumns+` FROM call_off_order WHERE id = $1`, orderId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Framework.GetCallOffOrder with id: "+orderId)
+	}
+
+	return &order, nil
+}
+
+func (rep *FrameworkRep) GetCallOffOrders(ctx context.Context, tenderId string) ([]model.CallOffOrder, error) {
+	var orders []model.CallOffOrder
+	err := rep.cli.Select(ctx, &orders,
+		`SELECT `+callOffOrderColumns+` FROM call_off_order WHERE tender_id = $1 ORDER BY created_at`, tenderId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Framework.GetCallOffOrders with tender id: "+tenderId)
+	}
+
+	return orders, nil
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"avito/db"
+	"avito/db/model"
+	"avito/domain"
+	"avito/log"
+	"context"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type InboxRep struct {
+	cli    db.DB
+	logger log.Logger
+}
+
+func NewInboxRep(logger log.Logger, cli db.DB) *InboxRep {
+	return &InboxRep{
+		logger: logger,
+		cli:    cli,
+	}
+}
+
+// InsertNotifications puts the events to the inboxes of the employees they concern: a new bid goes
+// to the tender organization, a decision and a feedback to the bid author, an invitation to the invitee.
+func (rep *InboxRep) InsertNotifications(ctx context.Context, eventIds []int64) error {
+	_, err := rep.cli.Exec(ctx,
+		`INSERT INTO inbox_notification(user_id, event_id)
+			   SELECT r.user_id, e.id FROM outbox_event e
+				 JOIN tender t ON t.id = e.tender_id
+				 JOIN bid b ON b.id = e.bid_id
+				 JOIN organization_responsible r ON r.organization_id = t.organization_id
+				WHERE e.id = ANY($1::bigint[]) AND e.type = 'BidSubmitted' AND r.user_id <> b.author_id
+			   UNION ALL
+			   SELECT b.author_id, e.id FROM outbox_event e
+				 JOIN bid b ON b.id = e.bid_id
+				WHERE e.id = ANY($1::bigint[]) AND e.type IN ('BidApproved', 'BidRejected', 'BidFeedback')
+			   UNION ALL
+			   SELECT COALESCE(r.user_id, i.user_id), e.id FROM outbox_event e
+				 JOIN tender_invitation i ON i.id = (e.payload->>'invitationId')::uuid
+				 LEFT JOIN organization_responsible r ON r.organization_id = i.organization_id
+				WHERE e.id = ANY($1::bigint[]) AND e.type = 'TenderInvited' AND COALESCE(r.user_id, i.user_id) IS NOT NULL
+			 ON CONFLICT (user_id, event_id) DO NOTHING`, eventIds)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Inbox.InsertNotifications")
+	}
+
+	return nil
+}
+
+// GetNotifications returns the inbox of the user from the newest notification, only the unread ones if asked.
+func (rep *InboxRep) GetNotifications(ctx context.Context, userId string, unreadOnly bool, offset, limit int) ([]model.InboxNotification, error) {
+	query := `SELECT n.id, n.event_id, e.type, e.tender_id, c.name AS tender_name, t.anonymized, e.bid_id, e.payload, n.read_at, n.created_at
+				FROM inbox_notification n
+				JOIN outbox_event e ON e.id = n.event_id
+				JOIN tender t ON t.id = e.tender_id
+				JOIN tender_content c ON c.tender_id = t.id AND c.version = t.version
+			   WHERE n.user_id = $1 AND (NOT $2 OR n.read_at IS NULL)
+			  ORDER BY n.event_id DESC OFFSET $3`
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	var (
+		err           error
+		notifications []model.InboxNotification
+	)
+	if limit > 0 {
+		query += ` LIMIT $4`
+		err = rep.cli.Select(ctx, &notifications, query, userId, unreadOnly, offset, limit)
+	} else {
+		err = rep.cli.Select(ctx, &notifications, query, userId, unreadOnly, offset)
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Inbox.GetNotifications with user id: "+userId)
+	}
+
+	return notifications, nil
+}
+
+func (rep *InboxRep) UnreadCount(ctx context.Context, userId string) (int, error) {
+	var unread int
+	err := rep.cli.SelectRow(ctx, &unread,
+		`SELECT count(*) FROM inbox_notification WHERE user_id = $1 AND read_at IS NULL`, userId)
+
+	if err != nil {
+		return 0, errors.WithMessage(err, "Repository.Inbox.UnreadCount with user id: "+userId)
+	}
+
+	return unread, nil
+}
+
+// MarkRead marks the notification of the user read, the read one keeps the time it was read.
+func (rep *InboxRep) MarkRead(ctx context.Context, userId, notificationId string) error {
+	res, err := rep.cli.Exec(ctx,
+		`UPDATE inbox_notification SET read_at = COALESCE(read_at, (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours'))
+			  WHERE id = $1 AND user_id = $2`, notificationId, userId)
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+		return domain.ErrNotificationDoesNotExist
+	}
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Inbox.MarkRead with id: "+notificationId)
+	}
+
+	if num, err := res.RowsAffected(); err != nil || num == 0 {
+		return domain.ErrNotificationDoesNotExist
+	}
+
+	return nil
+}
+
+// MarkAllRead marks the unread notifications of the user with the ids read, all of them
+// when the ids are empty, and returns the number of the notifications marked.
+func (rep *InboxRep) MarkAllRead(ctx context.Context, userId string, ids []string) (int64, error) {
+	res, err := rep.cli.Exec(ctx,
+		`UPDATE inbox_notification SET read_at = (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+			  WHERE user_id = $1 AND read_at IS NULL AND (COALESCE(cardinality($2::uuid[]), 0) = 0 OR id = ANY($2::uuid[]))`,
+		userId, ids)
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+		return 0, domain.ErrNotificationDoesNotExist
+	}
+
+	if err != nil {
+		return 0, errors.WithMessage(err, "Repository.Inbox.MarkAllRead with user id: "+userId)
+	}
+
+	read, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.WithMessage(err, "Repository.Inbox.MarkAllRead with user id: "+userId)
+	}
+
+	return read, nil
+}
+
+// DeleteOlderThan removes the notifications kept longer than the retention.
+func (rep *InboxRep) DeleteOlderThan(ctx context.Context, retention time.Duration) (int64, error) {
+	res, err := rep.cli.Exec(ctx,
+		`DELETE FROM inbox_notification
+			  WHERE created_at < (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours') - $1::bigint * INTERVAL '1 millisecond'`,
+		retention.Milliseconds())
+
+	if err != nil {
+		return 0, errors.WithMessage(err, "Repository.Inbox.DeleteOlderThan")
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.WithMessage(err, "Repository.Inbox.DeleteOlderThan")
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"avito/db"
+	"avito/db/model"
+	"avito/log"
+	"context"
+	"database/sql"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type NotificationRep struct {
+	cli    db.DB
+	logger log.Logger
+}
+
+func NewNotificationRep(logger log.Logger, cli db.DB) *NotificationRep {
+	return &NotificationRep{
+		logger: logger,
+		cli:    cli,
+	}
+}
+
+// GetPreference returns the notification preference of the user, the default one if it was never set.
+func (rep *NotificationRep) GetPreference(ctx context.Context, userId string) (*model.NotificationPreference, error) {
+	var preference model.NotificationPreference
+	err := rep.cli.SelectRow(ctx, &preference,
+		`SELECT user_id, email, locale, email_opt_out, updated_at FROM notification_preference WHERE user_id = $1`, userId)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return &model.NotificationPreference{UserId: userId, Locale: model.LocaleRu}, nil
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Notification.GetPreference with user id: "+userId)
+	}
+
+	return &preference, nil
+}
+
+// UpsertPreference sets the notification preference of the user. The emails are enabled when the user
+// gets an email and is not opted out while it had no email or was opted out, a change of the locale or
+// of the address keeps the time they were enabled at.
+func (rep *NotificationRep) UpsertPreference(ctx context.Context, preference *model.NotificationPreference) error {
+	_, err := rep.cli.Exec(ctx,
+		`INSERT INTO notification_preference(user_id, email, locale, email_opt_out, email_enabled_at)
+			   VALUES ($1, $2, $3, $4, CASE WHEN $2::varchar IS NOT NULL AND NOT $4::boolean
+					THEN (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours') END)
+			   ON CONFLICT (user_id) DO UPDATE SET email = EXCLUDED.email, locale = EXCLUDED.locale,
+				email_opt_out = EXCLUDED.email_opt_out,
+				email_enabled_at = CASE
+					WHEN EXCLUDED.email IS NULL OR EXCLUDED.email_opt_out THEN NULL
+					WHEN notification_preference.email IS NULL OR notification_preference.email_opt_out
+						THEN (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+					ELSE notification_preference.email_enabled_at END,
+				updated_at = (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')`,
+		preference.UserId, preference.Email, preference.Locale, preference.EmailOptOut)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Notification.UpsertPreference with user id: "+preference.UserId)
+	}
+
+	return nil
+}
+
+// GetEmailRecipients returns the bid authors to email about the events of the types. The authors
+// without an email or opted out of the emails are left out, so are the events before the emails
+// were enabled, an SMTP server configured later does not flood the users with the past events.
+func (rep *NotificationRep) GetEmailRecipients(ctx context.Context, eventIds []int64, types []model.EventType) ([]model.EmailRecipient, error) {
+	eventTypes := make([]string, len(types))
+	for i := range types {
+		eventTypes[i] = string(types[i])
+	}
+
+	var recipients []model.EmailRecipient
+	err := rep.cli.Select(ctx, &recipients,
+		`SELECT e.id, e.type, e.tender_id, e.bid_id, e.tender_version, e.bid_version, e.payload, e.created_at,
+				b.author_id AS user_id, p.email, p.locale, c.name AS tender_name
+			   FROM outbox_event e
+			   JOIN bid b ON b.id = e.bid_id
+			   JOIN tender t ON t.id = e.tender_id
+			   JOIN tender_content c ON c.tender_id = t.id AND c.version = t.version
+			   JOIN notification_preference p ON p.user_id = b.author_id
+			  WHERE e.id = ANY($1::bigint[]) AND e.type = ANY($2::text[])
+				AND p.email IS NOT NULL AND NOT p.email_opt_out
+				AND e.created_at >= p.email_enabled_at
+			 ORDER BY e.id`, eventIds, eventTypes)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Notification.GetEmailRecipients")
+	}
+
+	return recipients, nil
+}
+
+// InsertEmails queues the emails, an email of the event already queued for the user is skipped.
+func (rep *NotificationRep) InsertEmails(ctx context.Context, emails []model.EmailNotification) error {
+	userIds := make([]string, len(emails))
+	eventIds := make([]int64, len(emails))
+	addresses := make([]string, len(emails))
+	subjects := make([]string, len(emails))
+	bodies := make([]string, len(emails))
+	for i := range emails {
+		userIds[i] = emails[i].UserId
+		eventIds[i] = emails[i].EventId
+		addresses[i] = emails[i].Email
+		subjects[i] = emails[i].Subject
+		bodies[i] = emails[i].Body
+	}
+
+	_, err := rep.cli.Exec(ctx,
+		`INSERT INTO email_notification(user_id, event_id, email, subject, body)
+			   SELECT m.user_id::uuid, m.event_id, m.email, m.subject, m.body
+				 FROM unnest($1::text[], $2::bigint[], $3::text[], $4::text[], $5::text[]) AS m(user_id, event_id, email, subject, body)
+			 ON CONFLICT (user_id, event_id) DO NOTHING`, userIds, eventIds, addresses, subjects, bodies)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Notification.InsertEmails")
+	}
+
+	return nil
+}
+
+// ClaimDueEmails leases the pending emails due for an attempt, so that the other
+// instances skip them until the lease expires or the attempt is recorded. The emails
+// of the users opted out since they were queued are dead and not returned.
+func (rep *NotificationRep) ClaimDueEmails(ctx context.Context, limit int, lease time.Duration) ([]model.EmailNotification, error) {
+	var emails []model.EmailNotification
+	err := rep.cli.Select(ctx, &emails,
+		`WITH due AS (
+				SELECT n.id, (p.email IS NULL OR p.email_opt_out) IS NOT FALSE AS opted_out
+				  FROM email_notification n LEFT JOIN notification_preference p ON p.user_id = n.user_id
+				 WHERE n.status = 'Pending' AND n.next_attempt_at <= now()
+				 ORDER BY n.next_attempt_at LIMIT $1 FOR UPDATE OF n SKIP LOCKED
+			   ), claimed AS (
+			   UPDATE email_notification n SET next_attempt_at = now() + $2::bigint * INTERVAL '1 millisecond',
+				status = CASE WHEN due.opted_out THEN 'Dead'::email_status ELSE n.status END,
+				last_error = CASE WHEN due.opted_out THEN 'recipient opted out' ELSE n.last_error END
+				 FROM due WHERE n.id = due.id
+			RETURNING n.id, n.user_id, n.event_id, n.email, n.subject, n.body, n.status, n.attempts,
+				n.next_attempt_at, n.last_error, n.sent_at, n.created_at
+			   )
+			   SELECT * FROM claimed WHERE status = 'Pending'`,
+		limit, lease.Milliseconds())
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Notification.ClaimDueEmails")
+	}
+
+	return emails, nil
+}
+
+func (rep *NotificationRep) MarkEmailSent(ctx context.Context, emailId string) error {
+	_, err := rep.cli.Exec(ctx,
+		`UPDATE email_notification SET status = 'Sent', attempts = attempts + 1, last_error = NULL,
+				sent_at = (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+			  WHERE id = $1 AND status = 'Pending'`, emailId)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Notification.MarkEmailSent with id: "+emailId)
+	}
+
+	return nil
+}
+
+// MarkEmailFailed records the failed attempt and schedules the next one after the delay,
+// the email is dead if there is no attempt left.
+func (rep *NotificationRep) MarkEmailFailed(ctx context.Context, emailId, lastError string, retryAfter time.Duration, dead bool) error {
+	_, err := rep.cli.Exec(ctx,
+		`UPDATE email_notification SET attempts = attempts + 1, last_error = $2,
+				status = CASE WHEN $4 THEN 'Dead'::email_status ELSE status END,
+				next_attempt_at = now() + $3::bigint * INTERVAL '1 millisecond'
+			  WHERE id = $1 AND status = 'Pending'`, emailId, lastError, retryAfter.Milliseconds(), dead)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Notification.MarkEmailFailed with id: "+emailId)
+	}
+
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"avito/log"
 	"avito/repository/cache"
 	"context"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pkg/errors"
 )
 
@@ -65,3 +67,31 @@ func (rep *OrganizationRep) EmpBelongs(ctx context.Context, employeeUsername, or
 
 	return belongs, nil
 }
+
+func (rep *OrganizationRep) EmpExists(ctx context.Context, empId string) (bool, error) {
+	var exists bool
+	err := rep.cli.SelectRow(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM employee WHERE id = $1)`, empId)
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, errors.WithMessage(err, "Repository.Org.EmpExists with id: "+empId)
+	}
+
+	return exists, nil
+}
+
+func (rep *OrganizationRep) EmpOrgIds(ctx context.Context, empId string) ([]string, error) {
+	var orgIds []string
+	err := rep.cli.Select(ctx, &orgIds,
+		`SELECT DISTINCT organization_id FROM organization_responsible WHERE user_id = $1`, empId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Org.EmpOrgIds with id: "+empId)
+	}
+
+	return orgIds, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"avito/db"
+	"avito/db/model"
+	"avito/log"
+	"context"
+	"database/sql"
+	"github.com/pkg/errors"
+)
+
+// outboxLockKey is the advisory lock taken by the transactions writing the events. They commit
+// one by one, so a sink never skips an event committed after the events with the greater ids.
+const outboxLockKey = 4519200
+
+type OutboxRep struct {
+	cli    db.DB
+	logger log.Logger
+}
+
+func NewOutboxRep(logger log.Logger, cli db.DB) *OutboxRep {
+	return &OutboxRep{
+		logger: logger,
+		cli:    cli,
+	}
+}
+
+// InsertEvent writes the event in the current transaction and holds the outbox lock till its end.
+// The versions of the tender and the bid are the ones after the change made in the transaction.
+func (rep *OutboxRep) InsertEvent(ctx context.Context, event *model.OutboxEvent) error {
+	_, err := rep.cli.Exec(ctx,
+		`WITH outbox_lock AS (SELECT pg_advisory_xact_lock($1))
+			   INSERT INTO outbox_event(type, tender_id, bid_id, payload, tender_version, bid_version)
+			   SELECT $2, $3::uuid, $4::uuid, $5::jsonb,
+					  (SELECT version FROM tender WHERE id = $3::uuid), (SELECT version FROM bid WHERE id = $4::uuid)
+				 FROM outbox_lock`,
+		outboxLockKey, event.Type, event.TenderId, event.BidId, event.Payload)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Outbox.InsertEvent with tender id: "+event.TenderId)
+	}
+
+	return nil
+}
+
+const outboxEventColumns = `id, type, tender_id, bid_id, tender_version, bid_version, payload, created_at`
+
+func (rep *OutboxRep) GetEventsAfter(ctx context.Context, eventId int64, limit int) ([]model.OutboxEvent, error) {
+	var events []model.OutboxEvent
+	err := rep.cli.Select(ctx, &events,
+		`SELECT `+outboxEventColumns+` FROM outbox_event
+			  WHERE id > $1 ORDER BY id LIMIT $2`, eventId, limit)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Outbox.GetEventsAfter")
+	}
+
+	return events, nil
+}
+
+// GetOffset returns the id of the last event delivered to the sink, zero for a new sink.
+func (rep *OutboxRep) GetOffset(ctx context.Context, sink string) (int64, error) {
+	var eventId int64
+	err := rep.cli.SelectRow(ctx, &eventId, `SELECT event_id FROM outbox_offset WHERE sink = $1`, sink)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, errors.WithMessage(err, "Repository.Outbox.GetOffset with sink: "+sink)
+	}
+
+	return eventId, nil
+}
+
+// SetOffset moves the offset of the sink forward, it never goes back.
+func (rep *OutboxRep) SetOffset(ctx context.Context, sink string, eventId int64) error {
+	_, err := rep.cli.Exec(ctx,
+		`INSERT INTO outbox_offset(sink, event_id) VALUES ($1, $2)
+			   ON CONFLICT (sink) DO UPDATE SET event_id = GREATEST(outbox_offset.event_id, EXCLUDED.event_id),
+				updated_at = (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')`, sink, eventId)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Outbox.SetOffset with sink: "+sink)
+	}
+
+	return nil
+}
+
+// LastEventId returns the id of the last written event, zero for the empty outbox.
+func (rep *OutboxRep) LastEventId(ctx context.Context) (int64, error) {
+	var eventId int64
+	err := rep.cli.SelectRow(ctx, &eventId, `SELECT COALESCE(MAX(id), 0) FROM outbox_event`)
+
+	if err != nil {
+		return 0, errors.WithMessage(err, "Repository.Outbox.LastEventId")
+	}
+
+	return eventId, nil
+}
+
+// GetStreamEvents returns the events with the ids in (afterId, untilId] with the tender organization and the bid author.
+func (rep *OutboxRep) GetStreamEvents(ctx context.Context, afterId, untilId int64, limit int) ([]model.StreamEvent, error) {
+	var events []model.StreamEvent
+	err := rep.cli.Select(ctx, &events,
+		`SELECT e.id, e.type, e.tender_id, e.bid_id, e.tender_version, e.bid_version, e.payload, e.created_at,
+				t.organization_id, b.author_id AS bid_author_id
+			   FROM outbox_event e
+			   JOIN tender t ON t.id = e.tender_id
+			   LEFT JOIN bid b ON b.id = e.bid_id
+			  WHERE e.id > $1 AND e.id <= $2 ORDER BY e.id LIMIT $3`, afterId, untilId, limit)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Outbox.GetStreamEvents")
+	}
+
+	return events, nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"avito/db"
+	"avito/db/model"
+	"avito/domain"
+	"avito/log"
+	"context"
+	"fmt"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+const savedSearchColumns = `id, user_id, name, service_types, keywords, budget_min, budget_max, organization_id, created_at`
+
+type SavedSearchRep struct {
+	cli    db.DB
+	logger log.Logger
+}
+
+func NewSavedSearchRep(logger log.Logger, cli db.DB) *SavedSearchRep {
+	return &SavedSearchRep{
+		logger: logger,
+		cli:    cli,
+	}
+}
+
+func (rep *SavedSearchRep) Insert(ctx context.Context, search *model.SavedSearch) (*model.SavedSearch, error) {
+	var inserted model.SavedSearch
+	err := rep.cli.SelectRow(ctx, &inserted,
+		`INSERT INTO saved_search(user_id, name, service_types, keywords, budget_min, budget_max, organization_id)
+			   VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING `+savedSearchColumns,
+		search.UserId, search.Name, search.ServiceTypes, search.Keywords, search.BudgetMin, search.BudgetMax, search.OrganizationId)
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.ForeignKeyViolation, pgerrcode.InvalidTextRepresentation:
+			return nil, domain.ErrOrganizationDoesNotExist
+		case pgerrcode.CheckViolation:
+			return nil, domain.ErrInvalidSavedSearch
+		}
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.SavedSearch.Insert with name: "+search.Name)
+	}
+
+	return &inserted, nil
+}
+
+func (rep *SavedSearchRep) GetSearches(ctx context.Context, userId string) ([]model.SavedSearch, error) {
+	var searches []model.SavedSearch
+	err := rep.cli.Select(ctx, &searches,
+		`SELECT `+savedSearchColumns+` FROM saved_search WHERE user_id = $1 ORDER BY created_at`, userId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.SavedSearch.GetSearches with user id: "+userId)
+	}
+
+	return searches, nil
+}
+
+// Delete removes the saved search of the user together with its alerts.
+func (rep *SavedSearchRep) Delete(ctx context.Context, userId, searchId string) error {
+	res, err := rep.cli.Exec(ctx, `DELETE FROM saved_search WHERE id = $1 AND user_id = $2`, searchId, userId)
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+		return domain.ErrSavedSearchDoesNotExist
+	}
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.SavedSearch.Delete with id: "+searchId)
+	}
+
+	if num, err := res.RowsAffected(); err != nil || num == 0 {
+		return domain.ErrSavedSearchDoesNotExist
+	}
+
+	return nil
+}
+
+// InsertAlerts matches the tenders published by the events against the saved searches created
+// before the events and stores an alert for every match the owner of the search can see.
+func (rep *SavedSearchRep) InsertAlerts(ctx context.Context, eventIds []int64) error {
+	_, err := rep.cli.Exec(ctx,
+		`INSERT INTO tender_alert(saved_search_id, user_id, tender_id, event_id)
+			   SELECT s.id, s.user_id, t.id, e.id FROM outbox_event e
+				 JOIN tender t ON t.id = e.tender_id
+				 JOIN tender_content c ON c.tender_id = t.id AND c.version = COALESCE(e.tender_version, t.version)
+				 JOIN saved_search s ON s.created_at <= e.created_at
+				WHERE e.id = ANY($1::bigint[]) AND e.type = 'TenderPublished'
+				  AND (s.organization_id IS NULL OR s.organization_id = t.organization_id)
+				  AND (s.budget_min IS NULL OR t.budget >= s.budget_min)
+				  AND (s.budget_max IS NULL OR t.budget <= s.budget_max)
+				  AND (jsonb_array_length(s.service_types) = 0 OR c.service_type IN (
+						WITH RECURSIVE service_types AS (
+							SELECT code FROM service_type WHERE code IN (SELECT jsonb_array_elements_text(s.service_types))
+							UNION
+							SELECT st.code FROM service_type st JOIN service_types sts ON st.parent_code = sts.code)
+						SELECT code FROM service_types))
+				  AND NOT EXISTS(SELECT 1 FROM jsonb_array_elements_text(s.keywords) k
+						WHERE strpos(lower(c.name || ' ' || c.description), lower(k)) = 0)
+				  AND `+fmt.Sprintf(tenderAccessCondition, "s.user_id")+`
+			 ON CONFLICT (saved_search_id, tender_id) DO NOTHING`, eventIds)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.SavedSearch.InsertAlerts")
+	}
+
+	return nil
+}
+
+// GetAlerts returns the alerts of the user from the newest one, only the alerts of the search if it is given.
+func (rep *SavedSearchRep) GetAlerts(ctx context.Context, userId, searchId string, offset, limit int) ([]model.TenderAlert, error) {
+	query := `SELECT a.id, a.saved_search_id, s.name AS search_name, a.tender_id, c.name AS tender_name, c.service_type,
+					 t.budget, t.organization_id, a.created_at
+				FROM tender_alert a
+				JOIN saved_search s ON s.id = a.saved_search_id
+				JOIN tender t ON t.id = a.tender_id
+				JOIN tender_content c ON c.tender_id = t.id AND c.version = t.version
+			   WHERE a.user_id = $1 AND ($2 = '' OR a.saved_search_id::text = $2)
+			  ORDER BY a.event_id DESC OFFSET $3`
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	var (
+		err    error
+		alerts []model.TenderAlert
+	)
+	if limit > 0 {
+		query += ` LIMIT $4`
+		err = rep.cli.Select(ctx, &alerts, query, userId, searchId, offset, limit)
+	} else {
+		err = rep.cli.Select(ctx, &alerts, query, userId, searchId, offset)
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.SavedSearch.GetAlerts with user id: "+userId)
+	}
+
+	return alerts, nil
+}
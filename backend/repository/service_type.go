@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"avito/db"
+	"avito/db/model"
+	"avito/domain"
+	"avito/log"
+	"context"
+	"database/sql"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+type ServiceTypeRep struct {
+	cli    db.DB
+	logger log.Logger
+}
+
+func NewServiceTypeRep(logger log.Logger, cli db.DB) *ServiceTypeRep {
+	return &ServiceTypeRep{
+		logger: logger,
+		cli:    cli,
+	}
+}
+
+func (rep *ServiceTypeRep) GetAll(ctx context.Context) ([]model.ServiceType, error) {
+	var serviceTypes []model.ServiceType
+	err := rep.cli.Select(ctx, &serviceTypes,
+		`SELECT code, name, parent_code, created_at FROM service_type ORDER BY code`)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.ServiceType.GetAll")
+	}
+
+	return serviceTypes, nil
+}
+
+func (rep *ServiceTypeRep) GetByCode(ctx context.Context, code string) (*model.ServiceType, error) {
+	var serviceType model.ServiceType
+	err := rep.cli.SelectRow(ctx, &serviceType,
+		`SELECT code, name, parent_code, created_at FROM service_type WHERE code = $1`, code)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrServiceTypeDoesNotExist
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.ServiceType.GetByCode with code: "+code)
+	}
+
+	return &serviceType, nil
+}
+
+func (rep *ServiceTypeRep) Exists(ctx context.Context, code string) (bool, error) {
+	var exists bool
+	err := rep.cli.SelectRow(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM service_type WHERE code = $1)`, code)
+
+	if err != nil {
+		return false, errors.WithMessage(err, "Repository.ServiceType.Exists with code: "+code)
+	}
+
+	return exists, nil
+}
+
+func (rep *ServiceTypeRep) Insert(ctx context.Context, serviceType *model.ServiceType) error {
+	_, err := rep.cli.Exec(ctx,
+		`INSERT INTO service_type(code, name, parent_code) VALUES ($1, $2, $3)`,
+		serviceType.Code, serviceType.Name, serviceType.ParentCode)
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.UniqueViolation:
+			return domain.ErrServiceTypeExists
+		case pgerrcode.ForeignKeyViolation, pgerrcode.CheckViolation:
+			return domain.ErrInvalidServiceTypeParent
+		}
+	}
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.ServiceType.Insert with code: "+serviceType.Code)
+	}
+
+	return nil
+}
+
+// Update renames the service type and moves it under the new parent.
+// The parent can not be the service type itself or one of its descendants.
+func (rep *ServiceTypeRep) Update(ctx context.Context, serviceType *model.ServiceType) error {
+	res, err := rep.cli.Exec(ctx,
+		`WITH RECURSIVE descendants AS (
+					SELECT code FROM service_type WHERE code = $1
+					UNION
+					SELECT s.code FROM service_type s JOIN descendants d ON s.parent_code = d.code)
+			   UPDATE service_type SET name = $2, parent_code = $3
+			   WHERE code = $1 AND ($3::text IS NULL OR $3::text NOT IN (SELECT code FROM descendants))`,
+		serviceType.Code, serviceType.Name, serviceType.ParentCode)
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+		return domain.ErrInvalidServiceTypeParent
+	}
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.ServiceType.Update with code: "+serviceType.Code)
+	}
+
+	if num, err := res.RowsAffected(); err != nil || num == 0 {
+		return domain.ErrInvalidServiceTypeParent
+	}
+
+	return nil
+}
+
+func (rep *ServiceTypeRep) Delete(ctx context.Context, code string) error {
+	res, err := rep.cli.Exec(ctx, `DELETE FROM service_type WHERE code = $1`, code)
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+		return domain.ErrServiceTypeInUse
+	}
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.ServiceType.Delete with code: "+code)
+	}
+
+	if num, err := res.RowsAffected(); err != nil || num == 0 {
+		return domain.ErrServiceTypeDoesNotExist
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"avito/db"
+	"avito/db/model"
+	"avito/domain"
+	"avito/log"
+	"context"
+	"database/sql"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+type TemplateRep struct {
+	cli    db.DB
+	logger log.Logger
+}
+
+func NewTemplateRep(logger log.Logger, cli db.DB) *TemplateRep {
+	return &TemplateRep{
+		logger: logger,
+		cli:    cli,
+	}
+}
+
+func (rep *TemplateRep) Insert(ctx context.Context, template *model.TenderTemplate) (string, error) {
+	names, weights := splitTemplateCriteria(template.Criteria)
+
+	var templateId string
+	err := rep.cli.SelectRow(ctx, &templateId,
+		`WITH template_id_t AS (
+				INSERT INTO tender_template(organization_id, name, description, service_type, budget)
+				VALUES ($1, $2, $3, $4, $5) RETURNING id),
+			criteria AS (
+				INSERT INTO tender_template_criterion(template_id, name, weight)
+				SELECT (SELECT id FROM template_id_t), c.name, c.weight FROM unnest($6::text[], $7::float8[]) AS c(name, weight))
+			   SELECT id FROM template_id_t`,
+		template.OrganizationId, template.Name, template.Description, template.ServiceType, template.Budget,
+		names, weights)
+
+	if err = templateError(err); err != nil {
+		return "", errors.WithMessage(err, "Repository.Template.Insert with name: "+template.Name)
+	}
+
+	return templateId, nil
+}
+
+func (rep *TemplateRep) Update(ctx context.Context, template *model.TenderTemplate) error {
+	names, weights := splitTemplateCriteria(template.Criteria)
+
+	var templateId string
+	err := rep.cli.SelectRow(ctx, &templateId,
+		`WITH template_id_t AS (
+				UPDATE tender_template SET name = $2, description = $3, service_type = $4, budget = $5,
+					updated_at = (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+				WHERE id = $1 RETURNING id),
+			deleted AS (DELETE FROM tender_template_criterion WHERE template_id = $1),
+			criteria AS (
+				INSERT INTO tender_template_criterion(template_id, name, weight)
+				SELECT t.id, c.name, c.weight FROM template_id_t t, unnest($6::text[], $7::float8[]) AS c(name, weight))
+			   SELECT id FROM template_id_t`,
+		template.Id, template.Name, template.Description, template.ServiceType, template.Budget,
+		names, weights)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrTemplateDoesNotExist
+	}
+
+	if err = templateError(err); err != nil {
+		return errors.WithMessage(err, "Repository.Template.Update with id: "+template.Id)
+	}
+
+	return nil
+}
+
+func (rep *TemplateRep) GetById(ctx context.Context, templateId string) (*model.TenderTemplate, error) {
+	var template model.TenderTemplate
+	err := rep.cli.SelectRow(ctx, &template,
+		`SELECT id, organization_id, name, description, service_type, budget, created_at, updated_at
+			  FROM tender_template WHERE id = $1`, templateId)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrTemplateDoesNotExist
+	}
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+		return nil, domain.ErrTemplateDoesNotExist
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Template.GetById with id: "+templateId)
+	}
+
+	err = rep.cli.Select(ctx, &template.Criteria,
+		`SELECT template_id, name, weight FROM tender_template_criterion WHERE template_id = $1 ORDER BY weight DESC, name`,
+		templateId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Template.GetById criteria with id: "+templateId)
+	}
+
+	return &template, nil
+}
+
+func (rep *TemplateRep) GetByOrganization(ctx context.Context, orgId string) ([]model.TenderTemplate, error) {
+	var templates []model.TenderTemplate
+	err := rep.cli.Select(ctx, &templates,
+		`SELECT id, organization_id, name, description, service_type, budget, created_at, updated_at
+			  FROM tender_template WHERE organization_id = $1 ORDER BY name`, orgId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Template.GetByOrganization with org id: "+orgId)
+	}
+
+	var criteria []model.TemplateCriterion
+	err = rep.cli.Select(ctx, &criteria,
+		`SELECT c.template_id, c.name, c.weight FROM tender_template_criterion c
+			  JOIN tender_template t ON t.id = c.template_id
+			  WHERE t.organization_id = $1 ORDER BY c.weight DESC, c.name`, orgId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Template.GetByOrganization criteria with org id: "+orgId)
+	}
+
+	byTemplate := make(map[string][]model.TemplateCriterion, len(templates))
+	for i := range criteria {
+		byTemplate[criteria[i].TemplateId] = append(byTemplate[criteria[i].TemplateId], criteria[i])
+	}
+	for i := range templates {
+		templates[i].Criteria = byTemplate[templates[i].Id]
+	}
+
+	return templates, nil
+}
+
+func (rep *TemplateRep) Delete(ctx context.Context, templateId string) error {
+	res, err := rep.cli.Exec(ctx, `DELETE FROM tender_template WHERE id = $1`, templateId)
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Template.Delete with id: "+templateId)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Template.Delete with id: "+templateId)
+	}
+	if affected == 0 {
+		return domain.ErrTemplateDoesNotExist
+	}
+
+	return nil
+}
+
+func splitTemplateCriteria(criteria []model.TemplateCriterion) ([]string, []float64) {
+	names := make([]string, len(criteria))
+	weights := make([]float64, len(criteria))
+	for i := range criteria {
+		names[i] = criteria[i].Name
+		weights[i] = criteria[i].Weight
+	}
+
+	return names, weights
+}
+
+func templateError(err error) error {
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.UniqueViolation:
+			return domain.ErrTemplateNameTaken
+		case pgerrcode.ForeignKeyViolation:
+			if pgErr.ConstraintName == "tender_template_service_type_fkey" {
+				return domain.ErrInvalidServiceType
+			}
+			return domain.ErrOrganizationDoesNotExist
+		}
+	}
+
+	return err
+}
@@ -7,13 +7,21 @@ import (
 	"avito/log"
 	"avito/repository/cache"
 	"context"
+	"database/sql"
 	"fmt"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pkg/errors"
-	"strings"
+	"time"
 )
 
+// tenderAccessCondition checks that the tender aliased as t is public, or that the employee
+// is responsible for its organization or invited directly or through one of their organizations.
+const tenderAccessCondition = `(t.visibility = 'Public'
+	OR EXISTS(SELECT 1 FROM organization_responsible r WHERE r.organization_id = t.organization_id AND r.user_id = %[1]s)
+	OR EXISTS(SELECT 1 FROM tender_invitation i WHERE i.tender_id = t.id AND (i.user_id = %[1]s
+		OR i.organization_id IN (SELECT organization_id FROM organization_responsible WHERE user_id = %[1]s))))`
+
 type TenderRep struct {
 	cli                  db.DB
 	logger               log.Logger
@@ -28,12 +36,6 @@ func NewTenderRep(logger log.Logger, cli db.DB, idsCache *cache.Set, usernameIdM
 		idsCache:             idsCache,
 		usernameIdMatchCache: usernameIdMatchCache,
 	}
-	ctx := context.Background()
-	ids, err := tenderRep.GetTenderIds(ctx)
-	if err != nil {
-		logger.Error(ctx, fmt.Sprintf("Get tenders ids error: %v", err))
-	}
-	idsCache.WarmUp(ids)
 
 	return tenderRep
 }
@@ -46,16 +48,25 @@ func (rep *TenderRep) Insert(ctx context.Context, newTender *model.Tender, usern
 
 	var tenderId string
 	err := rep.cli.SelectRow(ctx, &tenderId,
-		`WITH tender_id_t AS (INSERT INTO tender (status, organization_id, user_id) VALUES ($1, $2, $3) RETURNING id)
+		`WITH tender_id_t AS (
+				INSERT INTO tender (status, organization_id, user_id, visibility, publish_at, close_at, budget, template_id,
+					sealed, bid_deadline, two_stage, stage, anonymized, framework)
+				VALUES ($1, $2, $3, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) RETURNING id)
 			   INSERT INTO tender_content(name, description, service_type, tender_id) VALUES ($4, $5, $6, 
 				(SELECT id FROM tender_id_t)) RETURNING (SELECT id FROM tender_id_t)`,
 		newTender.Status, newTender.OrganizationId, userId,
-		newTender.Name, newTender.Description, newTender.ServiceType)
+		newTender.Name, newTender.Description, newTender.ServiceType, newTender.Visibility,
+		newTender.PublishAt, newTender.CloseAt, newTender.Budget, newTender.TemplateId,
+		newTender.Sealed, newTender.BidDeadline, newTender.TwoStage, newTender.Stage,
+		newTender.Anonymized, newTender.Framework)
 
 	pgErr := &pgconn.PgError{}
 	if errors.As(err, &pgErr) {
 		switch pgErr.Code {
 		case pgerrcode.ForeignKeyViolation:
+			if pgErr.ConstraintName == "tender_content_service_type_fkey" {
+				return "", domain.ErrInvalidServiceType
+			}
 			return "", domain.ErrOrganizationDoesNotExist
 		}
 	}
@@ -69,13 +80,27 @@ func (rep *TenderRep) Insert(ctx context.Context, newTender *model.Tender, usern
 	return tenderId, nil
 }
 
-func (rep *TenderRep) GetPublished(ctx context.Context, offset, limit int, types []string) ([]model.Tender, error) {
-	query := `SELECT t.id, c.name, c.description, c.service_type, t.status, t.version, t.created_at FROM tender t
+func (rep *TenderRep) GetPublished(ctx context.Context, offset, limit int, types []string, username string) ([]model.Tender, error) {
+	var userId any
+	if id, found := rep.usernameIdMatchCache.Get(username); found {
+		userId = id
+	}
+
+	query := `SELECT t.id, c.name, c.description, c.service_type, t.status, t.visibility, t.version, t.created_at, 
+       			t.publish_at, t.close_at, t.budget, t.template_id, t.sealed, t.bid_deadline,
+       			t.two_stage, t.stage, t.anonymized, t.framework FROM tender t
  				JOIN tender_content c ON t.id = c.tender_id and t.version = c.version 
- 				WHERE status = 'Published'`
+ 				WHERE status = 'Published' AND ` + fmt.Sprintf(tenderAccessCondition, "$1")
+	args := []any{userId}
 
 	if len(types) != 0 {
-		query += ` WHERE c.service_type IN ('` + strings.Join(types, "', '") + `')`
+		args = append(args, types)
+		query += fmt.Sprintf(` AND c.service_type IN (
+				WITH RECURSIVE service_types AS (
+					SELECT code FROM service_type WHERE code = ANY($%d)
+					UNION
+					SELECT s.code FROM service_type s JOIN service_types st ON s.parent_code = st.code)
+				SELECT code FROM service_types)`, len(args))
 	}
 
 	var (
@@ -87,14 +112,14 @@ func (rep *TenderRep) GetPublished(ctx context.Context, offset, limit int, types
 		offset = 0
 	}
 
-	query += ` ORDER BY c.name OFFSET $1`
+	args = append(args, offset)
+	query += fmt.Sprintf(` ORDER BY c.name OFFSET $%d`, len(args))
 	if limit > 0 {
-		query += ` LIMIT $2`
-		err = rep.cli.Select(ctx, &tenders, query, offset, limit)
-	} else {
-		err = rep.cli.Select(ctx, &tenders, query, offset)
+		args = append(args, limit)
+		query += fmt.Sprintf(` LIMIT $%d`, len(args))
 	}
 
+	err = rep.cli.Select(ctx, &tenders, query, args...)
 	if err != nil {
 		return nil, errors.WithMessage(err, "Repository.Tender.Get")
 	}
@@ -113,8 +138,19 @@ func (rep *TenderRep) GetByUsername(ctx context.Context, offset, limit int, user
 				c.description,
 				c.service_type,
 				t.status,
+				t.visibility,
 				t.version,
-				t.created_at
+				t.created_at,
+				t.publish_at,
+				t.close_at,
+				t.budget,
+				t.template_id,
+				t.sealed,
+				t.bid_deadline,
+				t.two_stage,
+				t.stage,
+				t.anonymized,
+				t.framework
  			FROM tender t JOIN tender_content c ON t.id = c.tender_id and t.version = c.version
             WHERE user_id = $1 ORDER BY name OFFSET $2`
 
@@ -207,8 +243,10 @@ func (rep *TenderRep) GetById(ctx context.Context, tenderId string) (*model.Tend
 
 	var tender model.Tender
 	err := rep.cli.SelectRow(ctx, &tender,
-		`SELECT t.id, c.name, c.description, t.status, c.service_type, t.version, t.created_at FROM tender t 
-    			JOIN tender_content c ON t.id = c.tender_id AND t.version = c.version WHERE id = $1`, tenderId)
+		`SELECT t.id, c.name, c.description, t.status, t.visibility, c.service_type, t.organization_id, t.version, t.created_at,
+       			t.publish_at, t.close_at, t.budget, t.template_id, t.sealed, t.bid_deadline, t.two_stage, t.stage,
+       			t.anonymized, t.framework
+       			FROM tender t JOIN tender_content c ON t.id = c.tender_id AND t.version = c.version WHERE id = $1`, tenderId)
 
 	if err != nil {
 		return nil, errors.WithMessage(err, "Repository.Tender.GetById with id: "+tenderId)
@@ -275,6 +313,238 @@ func (rep *TenderRep) UsernameBelongsToTenderOrg(ctx context.Context, username,
 	return belongs, nil
 }
 
+func (rep *TenderRep) SetVisibility(ctx context.Context, tenderId, visibility string) error {
+	if !rep.idsCache.Exists(tenderId) {
+		return domain.ErrTenderDoesNotExist
+	}
+
+	_, err := rep.cli.Exec(ctx, `UPDATE tender SET visibility = $1 WHERE id = $2`, visibility, tenderId)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Tender.SetVisibility with id: "+tenderId)
+	}
+
+	return nil
+}
+
+func (rep *TenderRep) SetSchedule(ctx context.Context, tenderId string, publishAt, closeAt *time.Time) error {
+	if !rep.idsCache.Exists(tenderId) {
+		return domain.ErrTenderDoesNotExist
+	}
+
+	_, err := rep.cli.Exec(ctx, `UPDATE tender SET publish_at = $1, close_at = $2 WHERE id = $3`, publishAt, closeAt, tenderId)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Tender.SetSchedule with id: "+tenderId)
+	}
+
+	return nil
+}
+
+// PublishScheduled publishes the due tenders and writes their events to the outbox in the same statement.
+func (rep *TenderRep) PublishScheduled(ctx context.Context) ([]string, error) {
+	var ids []string
+	err := rep.cli.Select(ctx, &ids,
+		`WITH outbox_lock AS (SELECT pg_advisory_xact_lock($1)),
+			  published AS (UPDATE tender SET status = 'Published', publish_at = NULL 
+              WHERE status = 'Created' AND publish_at <= now() AND EXISTS (SELECT 1 FROM outbox_lock) RETURNING id, version)
+			   INSERT INTO outbox_event(type, tender_id, tender_version, payload)
+			   SELECT 'TenderPublished', id, version, '{"status": "Published"}' FROM published RETURNING tender_id`, outboxLockKey)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Tender.PublishScheduled")
+	}
+
+	return ids, nil
+}
+
+// CloseScheduled closes the due tenders and writes their events to the outbox in the same statement.
+func (rep *TenderRep) CloseScheduled(ctx context.Context) ([]string, error) {
+	var ids []string
+	err := rep.cli.Select(ctx, &ids,
+		`WITH outbox_lock AS (SELECT pg_advisory_xact_lock($1)),
+			  closed AS (UPDATE tender SET status = 'Closed', close_at = NULL 
+              WHERE status = 'Published' AND close_at <= now() AND EXISTS (SELECT 1 FROM outbox_lock) RETURNING id, version)
+			   INSERT INTO outbox_event(type, tender_id, tender_version, payload)
+			   SELECT 'TenderClosed', id, version, '{"status": "Closed"}' FROM closed RETURNING tender_id`, outboxLockKey)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Tender.CloseScheduled")
+	}
+
+	return ids, nil
+}
+
+// SetStage moves the two-stage tender to the next stage if it is still at the given one.
+func (rep *TenderRep) SetStage(ctx context.Context, tenderId string, from, to model.TenderStage) error {
+	if !rep.idsCache.Exists(tenderId) {
+		return domain.ErrTenderDoesNotExist
+	}
+
+	res, err := rep.cli.Exec(ctx, `UPDATE tender SET stage = $3 WHERE id = $1 AND stage = $2`, tenderId, from, to)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Tender.SetStage with id: "+tenderId)
+	}
+
+	if num, err := res.RowsAffected(); err != nil || num == 0 {
+		return errors.WithMessage(domain.ErrTechnicalStageClosed, "Repository.Tender.SetStage with id: "+tenderId)
+	}
+
+	return nil
+}
+
+func (rep *TenderRep) CloseTenderIfOpen(ctx context.Context, tenderId string) error {
+	if !rep.idsCache.Exists(tenderId) {
+		return domain.ErrTenderDoesNotExist
+	}
+
+	res, err := rep.cli.Exec(ctx,
+		`UPDATE tender SET status = 'Closed', publish_at = NULL, close_at = NULL WHERE id = $1 AND status != 'Closed'`, tenderId)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Tender.CloseTenderIfOpen with id: "+tenderId)
+	}
+
+	if num, err := res.RowsAffected(); err != nil || num == 0 {
+		return domain.ErrTenderIsClosed
+	}
+
+	return nil
+}
+
+func (rep *TenderRep) InsertCancellation(ctx context.Context, cancellation *model.TenderCancellation) error {
+	_, err := rep.cli.Exec(ctx,
+		`INSERT INTO tender_cancellation(tender_id, reason, user_id) VALUES ($1, $2, $3)`,
+		cancellation.TenderId, cancellation.Reason, cancellation.UserId)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Tender.InsertCancellation with id: "+cancellation.TenderId)
+	}
+
+	return nil
+}
+
+func (rep *TenderRep) GetCancellation(ctx context.Context, tenderId string) (*model.TenderCancellation, error) {
+	if !rep.idsCache.Exists(tenderId) {
+		return nil, domain.ErrTenderDoesNotExist
+	}
+
+	var cancellation model.TenderCancellation
+	err := rep.cli.SelectRow(ctx, &cancellation,
+		`SELECT id, tender_id, reason, user_id, created_at FROM tender_cancellation WHERE tender_id = $1`, tenderId)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrTenderNotCanceled
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Tender.GetCancellation with id: "+tenderId)
+	}
+
+	return &cancellation, nil
+}
+
+func (rep *TenderRep) UsernameCanAccess(ctx context.Context, username, tenderId string) (bool, error) {
+	var userId any
+	if id, found := rep.usernameIdMatchCache.Get(username); found {
+		userId = id
+	}
+
+	return rep.canAccess(ctx, userId, tenderId)
+}
+
+func (rep *TenderRep) UserCanAccess(ctx context.Context, userId, tenderId string) (bool, error) {
+	return rep.canAccess(ctx, userId, tenderId)
+}
+
+func (rep *TenderRep) canAccess(ctx context.Context, userId any, tenderId string) (bool, error) {
+	if !rep.idsCache.Exists(tenderId) {
+		return false, domain.ErrTenderDoesNotExist
+	}
+
+	var canAccess bool
+	err := rep.cli.SelectRow(ctx, &canAccess,
+		`SELECT `+fmt.Sprintf(tenderAccessCondition, "$2::uuid")+` FROM tender t WHERE t.id = $1`, tenderId, userId)
+
+	if err != nil {
+		return false, errors.WithMessage(err, "Repository.Tender.CanAccess with id: "+tenderId)
+	}
+
+	return canAccess, nil
+}
+
+func (rep *TenderRep) InsertInvitation(ctx context.Context, invitation *model.TenderInvitation) (string, error) {
+	if !rep.idsCache.Exists(invitation.TenderId) {
+		return "", domain.ErrTenderDoesNotExist
+	}
+
+	var invitationId string
+	err := rep.cli.SelectRow(ctx, &invitationId,
+		`INSERT INTO tender_invitation(tender_id, organization_id, user_id) VALUES ($1, $2, $3) RETURNING id`,
+		invitation.TenderId, invitation.OrganizationId, invitation.UserId)
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.ForeignKeyViolation, pgerrcode.InvalidTextRepresentation:
+			return "", domain.ErrInviteeDoesNotExist
+		case pgerrcode.UniqueViolation:
+			return "", domain.ErrAlreadyInvited
+		}
+	}
+
+	if err != nil {
+		return "", errors.WithMessage(err, "Repository.Tender.InsertInvitation with tender id: "+invitation.TenderId)
+	}
+
+	return invitationId, nil
+}
+
+func (rep *TenderRep) GetInvitationById(ctx context.Context, invitationId string) (*model.TenderInvitation, error) {
+	var invitation model.TenderInvitation
+	err := rep.cli.SelectRow(ctx, &invitation,
+		`SELECT id, tender_id, organization_id, user_id, created_at FROM tender_invitation WHERE id = $1`, invitationId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Tender.GetInvitationById with id: "+invitationId)
+	}
+
+	return &invitation, nil
+}
+
+func (rep *TenderRep) GetInvitations(ctx context.Context, tenderId string) ([]model.TenderInvitation, error) {
+	if !rep.idsCache.Exists(tenderId) {
+		return nil, domain.ErrTenderDoesNotExist
+	}
+
+	var invitations []model.TenderInvitation
+	err := rep.cli.Select(ctx, &invitations,
+		`SELECT id, tender_id, organization_id, user_id, created_at FROM tender_invitation
+				WHERE tender_id = $1 ORDER BY created_at`, tenderId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Tender.GetInvitations with tender id: "+tenderId)
+	}
+
+	return invitations, nil
+}
+
+func (rep *TenderRep) DeleteInvitation(ctx context.Context, tenderId, invitationId string) error {
+	res, err := rep.cli.Exec(ctx,
+		`DELETE FROM tender_invitation WHERE id::text = $1 AND tender_id = $2`, invitationId, tenderId)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Tender.DeleteInvitation with id: "+invitationId)
+	}
+
+	if num, err := res.RowsAffected(); err != nil || num == 0 {
+		return domain.ErrInvitationDoesNotExist
+	}
+
+	return nil
+}
+
 func (rep *TenderRep) GetTenderIds(ctx context.Context) ([]string, error) {
 	var ids []string
 	err := rep.cli.Select(ctx, &ids, `SELECT id FROM tender`)
@@ -285,3 +555,12 @@ func (rep *TenderRep) GetTenderIds(ctx context.Context) ([]string, error) {
 
 	return ids, nil
 }
+
+func (rep *TenderRep) GetUserIdByName(ctx context.Context, username string) (string, error) {
+	userId, found := rep.usernameIdMatchCache.Get(username)
+	if !found {
+		return "", domain.ErrUserWithNameNotFound
+	}
+
+	return userId, nil
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"avito/db"
+	"avito/db/model"
+	"avito/domain"
+	"avito/log"
+	"context"
+	"database/sql"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type WebhookRep struct {
+	cli    db.DB
+	logger log.Logger
+}
+
+func NewWebhookRep(logger log.Logger, cli db.DB) *WebhookRep {
+	return &WebhookRep{
+		logger: logger,
+		cli:    cli,
+	}
+}
+
+func (rep *WebhookRep) InsertWebhook(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error) {
+	var inserted model.Webhook
+	err := rep.cli.SelectRow(ctx, &inserted,
+		`INSERT INTO webhook(organization_id, url, event_types, secret, user_id)
+			   VALUES ($1, $2, $3::jsonb, $4, $5) RETURNING `+webhookColumns,
+		webhook.OrganizationId, webhook.Url, webhook.EventTypes, webhook.Secret, webhook.UserId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Webhook.InsertWebhook with organization id: "+webhook.OrganizationId)
+	}
+
+	return &inserted, nil
+}
+
+const webhookColumns = `id, organization_id, url, event_types, secret, user_id, created_at`
+
+func (rep *WebhookRep) GetWebhook(ctx context.Context, webhookId string) (*model.Webhook, error) {
+	var webhook model.Webhook
+	err := rep.cli.SelectRow(ctx, &webhook, `SELECT `+webhookColumns+` FROM webhook WHERE id = $1`, webhookId)
+
+	pgErr := &pgconn.PgError{}
+	if errors.Is(err, sql.ErrNoRows) || errors.As(err, &pgErr) && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+		return nil, domain.ErrWebhookDoesNotExist
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Webhook.GetWebhook with id: "+webhookId)
+	}
+
+	return &webhook, nil
+}
+
+func (rep *WebhookRep) GetWebhooks(ctx context.Context, orgId string) ([]model.Webhook, error) {
+	var webhooks []model.Webhook
+	err := rep.cli.Select(ctx, &webhooks,
+		`SELECT `+webhookColumns+` FROM webhook WHERE organization_id = $1 ORDER BY created_at`, orgId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Webhook.GetWebhooks with organization id: "+orgId)
+	}
+
+	return webhooks, nil
+}
+
+func (rep *WebhookRep) DeleteWebhook(ctx context.Context, webhookId string) error {
+	res, err := rep.cli.Exec(ctx, `DELETE FROM webhook WHERE id = $1`, webhookId)
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Webhook.DeleteWebhook with id: "+webhookId)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Webhook.DeleteWebhook with id: "+webhookId)
+	}
+	if affected == 0 {
+		return domain.ErrWebhookDoesNotExist
+	}
+
+	return nil
+}
+
+// InsertDeliveries queues the events for the webhooks of the tender organizations subscribed to them.
+func (rep *WebhookRep) InsertDeliveries(ctx context.Context, eventIds []int64) error {
+	_, err := rep.cli.Exec(ctx,
+		`INSERT INTO webhook_delivery(webhook_id, event_id)
+			   SELECT w.id, e.id FROM outbox_event e
+				JOIN tender t ON t.id = e.tender_id
+				JOIN webhook w ON w.organization_id = t.organization_id AND w.event_types @> jsonb_build_array(e.type)
+			  WHERE e.id = ANY($1::bigint[])
+			 ON CONFLICT (webhook_id, event_id) DO NOTHING`, eventIds)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Webhook.InsertDeliveries")
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries leases the pending deliveries due for an attempt, so that the other
+// instances skip them until the lease expires or the attempt is recorded.
+func (rep *WebhookRep) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDispatch, error) {
+	var dispatches []model.WebhookDispatch
+	err := rep.cli.Select(ctx, &dispatches,
+		`WITH due AS (
+				SELECT id FROM webhook_delivery WHERE status = 'Pending' AND next_attempt_at <= now()
+				 ORDER BY next_attempt_at LIMIT $1 FOR UPDATE SKIP LOCKED
+			   ), claimed AS (
+				UPDATE webhook_delivery d SET next_attempt_at = now() + $2::bigint * INTERVAL '1 millisecond'
+				  FROM due WHERE d.id = due.id RETURNING d.id, d.webhook_id, d.event_id, d.attempts
+			   )
+			   SELECT c.id AS delivery_id, c.attempts, w.url, w.secret, t.anonymized,
+					  e.id, e.type, e.tender_id, e.bid_id, e.payload, e.created_at
+				 FROM claimed c
+				 JOIN webhook w ON w.id = c.webhook_id
+				 JOIN outbox_event e ON e.id = c.event_id
+				 JOIN tender t ON t.id = e.tender_id
+				ORDER BY e.id`, limit, lease.Milliseconds())
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Webhook.ClaimDueDeliveries")
+	}
+
+	return dispatches, nil
+}
+
+func (rep *WebhookRep) MarkDelivered(ctx context.Context, deliveryId string, responseStatus int) error {
+	_, err := rep.cli.Exec(ctx,
+		`UPDATE webhook_delivery SET status = 'Delivered', attempts = attempts + 1, response_status = $2,
+				last_error = NULL, delivered_at = (CURRENT_TIMESTAMP AT TIME ZONE 'UTC' + INTERVAL '3 hours')
+			  WHERE id = $1 AND status = 'Pending'`, deliveryId, responseStatus)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Webhook.MarkDelivered with id: "+deliveryId)
+	}
+
+	return nil
+}
+
+// MarkFailed records the failed attempt and schedules the next one after the delay,
+// the delivery goes to the dead letters if there is no attempt left.
+func (rep *WebhookRep) MarkFailed(ctx context.Context, deliveryId string, responseStatus *int, lastError string,
+	retryAfter time.Duration, dead bool) error {
+	_, err := rep.cli.Exec(ctx,
+		`UPDATE webhook_delivery SET attempts = attempts + 1, response_status = $2, last_error = $3,
+				status = CASE WHEN $5 THEN 'Dead'::webhook_delivery_status ELSE status END,
+				next_attempt_at = now() + $4::bigint * INTERVAL '1 millisecond'
+			  WHERE id = $1 AND status = 'Pending'`, deliveryId, responseStatus, lastError, retryAfter.Milliseconds(), dead)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Webhook.MarkFailed with id: "+deliveryId)
+	}
+
+	return nil
+}
+
+const webhookDeliveryColumns = `d.id, d.webhook_id, d.event_id, e.type AS event_type, d.status, d.attempts,
+	d.next_attempt_at, d.last_error, d.response_status, d.delivered_at, d.created_at`
+
+func (rep *WebhookRep) GetDelivery(ctx context.Context, deliveryId string) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	err := rep.cli.SelectRow(ctx, &delivery,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_delivery d
+			   JOIN outbox_event e ON e.id = d.event_id WHERE d.id = $1`, deliveryId)
+
+	pgErr := &pgconn.PgError{}
+	if errors.Is(err, sql.ErrNoRows) || errors.As(err, &pgErr) && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+		return nil, domain.ErrDeliveryDoesNotExist
+	}
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Webhook.GetDelivery with id: "+deliveryId)
+	}
+
+	return &delivery, nil
+}
+
+// GetDeliveries returns the delivery history of the webhook, all the statuses if the status is empty.
+func (rep *WebhookRep) GetDeliveries(ctx context.Context, webhookId string, status model.WebhookDeliveryStatus) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	err := rep.cli.Select(ctx, &deliveries,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_delivery d
+			   JOIN outbox_event e ON e.id = d.event_id
+			  WHERE d.webhook_id = $1 AND ($2 = '' OR d.status::text = $2)
+			 ORDER BY d.event_id DESC`, webhookId, status)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Webhook.GetDeliveries with webhook id: "+webhookId)
+	}
+
+	return deliveries, nil
+}
+
+func (rep *WebhookRep) GetDeadLetters(ctx context.Context, orgId string) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	err := rep.cli.Select(ctx, &deliveries,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_delivery d
+			   JOIN outbox_event e ON e.id = d.event_id
+			   JOIN webhook w ON w.id = d.webhook_id
+			  WHERE w.organization_id = $1 AND d.status = 'Dead'
+			 ORDER BY d.event_id DESC`, orgId)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, "Repository.Webhook.GetDeadLetters with organization id: "+orgId)
+	}
+
+	return deliveries, nil
+}
+
+// ReplayDelivery returns the dead delivery to the queue with a fresh attempt budget.
+func (rep *WebhookRep) ReplayDelivery(ctx context.Context, deliveryId string) error {
+	res, err := rep.cli.Exec(ctx,
+		`UPDATE webhook_delivery SET status = 'Pending', attempts = 0, next_attempt_at = now()
+			  WHERE id = $1 AND status = 'Dead'`, deliveryId)
+
+	if err != nil {
+		return errors.WithMessage(err, "Repository.Webhook.ReplayDelivery with id: "+deliveryId)
+	}
+
+	if num, err := res.RowsAffected(); err != nil || num == 0 {
+		return errors.WithMessage(domain.ErrDeliveryNotFailed, "Repository.Webhook.ReplayDelivery with id: "+deliveryId)
+	}
+
+	return nil
+}
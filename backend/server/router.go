@@ -18,6 +18,20 @@ type Controllers struct {
 	TenderCnt *controllers.TenderController
 	OrgCnt    *controllers.OrganizationController
 	BidCnt    *controllers.BidController
+	EvalCnt   *controllers.EvaluationController
+	TplCnt    *controllers.TemplateController
+	StCnt     *controllers.ServiceTypeController
+	EnvCnt    *controllers.EnvelopeController
+	AucCnt    *controllers.AuctionController
+	CtrCnt    *controllers.ContractController
+	MlsCnt    *controllers.MilestoneController
+	CmpCnt    *controllers.ComplaintController
+	FrmCnt    *controllers.FrameworkController
+	WhkCnt    *controllers.WebhookController
+	StrCnt    *controllers.StreamController
+	NtfCnt    *controllers.NotificationController
+	InbCnt    *controllers.InboxController
+	SrhCnt    *controllers.SavedSearchController
 }
 
 func NewRouter(logger log.Logger) *Router {
@@ -32,9 +46,41 @@ func (r *Router) AddRoutes(m *Middleware, cts Controllers) {
 	register("/api/ping", "GET", m.Wrap(cts.DummyCnt.Ping))
 
 	register("/api/auth/signup", "POST", m.Wrap(cts.UserCnt.Signup))
+	register("/api/users/notifications", "GET", m.Wrap(cts.NtfCnt.GetPreference))
+	register("/api/users/notifications", "PUT", m.Wrap(cts.NtfCnt.SetPreference))
+
+	register("/api/notifications", "GET", m.Wrap(cts.InbCnt.Get))
+	register("/api/notifications/unread_count", "GET", m.Wrap(cts.InbCnt.UnreadCount))
+	register("/api/notifications/read", "PUT", m.Wrap(cts.InbCnt.MarkAllRead))
+	register("/api/notifications/{notificationId}/read", "PUT", m.Wrap(cts.InbCnt.MarkRead))
+
+	register("/api/users/searches", "POST", m.Wrap(cts.SrhCnt.Create))
+	register("/api/users/searches", "GET", m.Wrap(cts.SrhCnt.GetSearches))
+	register("/api/users/searches/{searchId}", "DELETE", m.Wrap(cts.SrhCnt.Delete))
+	register("/api/users/alerts", "GET", m.Wrap(cts.SrhCnt.GetAlerts))
 
 	register("/api/organizations/new", "POST", m.Wrap(cts.OrgCnt.Create))
 	register("/api/organizations/bond", "POST", m.Wrap(cts.OrgCnt.MakeResponsible))
+	register("/api/organizations/{organizationId}/templates", "POST", m.Wrap(cts.TplCnt.Create))
+	register("/api/organizations/{organizationId}/templates", "GET", m.Wrap(cts.TplCnt.GetByOrganization))
+	register("/api/organizations/{organizationId}/milestones/overdue", "GET", m.Wrap(cts.MlsCnt.Overdue))
+	register("/api/organizations/{organizationId}/webhooks", "POST", m.Wrap(cts.WhkCnt.Create))
+	register("/api/organizations/{organizationId}/webhooks", "GET", m.Wrap(cts.WhkCnt.GetByOrganization))
+	register("/api/organizations/{organizationId}/webhooks/dead_letters", "GET", m.Wrap(cts.WhkCnt.DeadLetters))
+	register("/api/organizations/{organizationId}/stream", "GET", cts.StrCnt.Organization)
+	register("/api/webhooks/{webhookId}", "DELETE", m.Wrap(cts.WhkCnt.Delete))
+	register("/api/webhooks/{webhookId}/deliveries", "GET", m.Wrap(cts.WhkCnt.Deliveries))
+	register("/api/webhook_deliveries/{deliveryId}/replay", "PUT", m.Wrap(cts.WhkCnt.Replay))
+
+	register("/api/templates/{templateId}", "GET", m.Wrap(cts.TplCnt.GetById))
+	register("/api/templates/{templateId}", "PUT", m.Wrap(cts.TplCnt.Update))
+	register("/api/templates/{templateId}", "DELETE", m.Wrap(cts.TplCnt.Delete))
+	register("/api/templates/{templateId}/tenders", "POST", m.Wrap(cts.TenderCnt.CreateFromTemplate))
+
+	register("/api/service_types", "GET", m.Wrap(cts.StCnt.GetAll))
+	register("/api/service_types", "POST", m.Wrap(cts.StCnt.Create))
+	register("/api/service_types/{code}", "PUT", m.Wrap(cts.StCnt.Update))
+	register("/api/service_types/{code}", "DELETE", m.Wrap(cts.StCnt.Delete))
 
 	register("/api/tenders/new", "POST", m.Wrap(cts.TenderCnt.Create))
 	register("/api/tenders/{tenderId}/status", "GET", m.Wrap(cts.TenderCnt.GetStatus))
@@ -43,9 +89,46 @@ func (r *Router) AddRoutes(m *Middleware, cts Controllers) {
 	register("/api/tenders", "GET", m.Wrap(cts.TenderCnt.GetPublished))
 	register("/api/tenders/{tenderId}/edit", "PATCH", m.Wrap(cts.TenderCnt.Edit))
 	register("/api/tenders/{tenderId}/rollback/{version}", "PUT", m.Wrap(cts.TenderCnt.Rollback))
+	register("/api/tenders/{tenderId}/visibility", "PUT", m.Wrap(cts.TenderCnt.SetVisibility))
+	register("/api/tenders/{tenderId}/invitations", "POST", m.Wrap(cts.TenderCnt.Invite))
+	register("/api/tenders/{tenderId}/invitations", "GET", m.Wrap(cts.TenderCnt.GetInvitations))
+	register("/api/tenders/{tenderId}/invitations/{invitationId}", "DELETE", m.Wrap(cts.TenderCnt.RevokeInvitation))
+	register("/api/tenders/{tenderId}/schedule", "PUT", m.Wrap(cts.TenderCnt.Schedule))
+	register("/api/tenders/{tenderId}/schedule", "DELETE", m.Wrap(cts.TenderCnt.CancelSchedule))
+	register("/api/tenders/{tenderId}/cancel", "PUT", m.Wrap(cts.TenderCnt.Cancel))
+	register("/api/tenders/{tenderId}/cancellation", "GET", m.Wrap(cts.TenderCnt.GetCancellation))
+	register("/api/tenders/{tenderId}/criteria", "PUT", m.Wrap(cts.EvalCnt.SetCriteria))
+	register("/api/tenders/{tenderId}/criteria", "GET", m.Wrap(cts.EvalCnt.GetCriteria))
+	register("/api/tenders/{tenderId}/ranking", "GET", m.Wrap(cts.EvalCnt.Ranking))
+	register("/api/tenders/{tenderId}/shortlist", "PUT", m.Wrap(cts.EvalCnt.Shortlist))
+	register("/api/tenders/{tenderId}/shortlist", "GET", m.Wrap(cts.EvalCnt.GetShortlist))
+	register("/api/tenders/{tenderId}/envelopes/open", "PUT", m.Wrap(cts.EnvCnt.Open))
+	register("/api/tenders/{tenderId}/envelopes", "GET", m.Wrap(cts.EnvCnt.GetOpening))
+	register("/api/tenders/{tenderId}/auction", "PUT", m.Wrap(cts.AucCnt.Set))
+	register("/api/tenders/{tenderId}/auction", "GET", m.Wrap(cts.AucCnt.Get))
+	register("/api/tenders/{tenderId}/auction/ranking", "GET", m.Wrap(cts.AucCnt.Ranking))
+	register("/api/tenders/{tenderId}/auction/stream", "GET", cts.AucCnt.Stream)
+	register("/api/tenders/{tenderId}/stream", "GET", cts.StrCnt.Tender)
+	register("/api/tenders/{tenderId}/contract", "GET", m.Wrap(cts.CtrCnt.Get))
+	register("/api/tenders/{tenderId}/contract/sign", "PUT", m.Wrap(cts.CtrCnt.Sign))
+	register("/api/tenders/{tenderId}/milestones", "POST", m.Wrap(cts.MlsCnt.Create))
+	register("/api/tenders/{tenderId}/milestones", "GET", m.Wrap(cts.MlsCnt.GetByTenderId))
+	register("/api/tenders/{tenderId}/call_off_orders", "POST", m.Wrap(cts.FrmCnt.IssueOrder))
+	register("/api/tenders/{tenderId}/call_off_orders", "GET", m.Wrap(cts.FrmCnt.GetOrders))
+
+	register("/api/milestones/{milestoneId}/deliver", "PUT", m.Wrap(cts.MlsCnt.Deliver))
+	register("/api/milestones/{milestoneId}/accept", "PUT", m.Wrap(cts.MlsCnt.Accept))
+	register("/api/milestones/{milestoneId}/dispute", "PUT", m.Wrap(cts.MlsCnt.Dispute))
+
+	register("/api/tenders/{tenderId}/complaints", "POST", m.Wrap(cts.CmpCnt.File))
+	register("/api/tenders/{tenderId}/complaints", "GET", m.Wrap(cts.CmpCnt.GetByTenderId))
+	register("/api/complaints/{complaintId}", "GET", m.Wrap(cts.CmpCnt.Get))
+	register("/api/complaints/{complaintId}/responses", "POST", m.Wrap(cts.CmpCnt.Respond))
+	register("/api/complaints/{complaintId}/status", "PUT", m.Wrap(cts.CmpCnt.SetStatus))
 
 	register("/api/bids/new", "POST", m.Wrap(cts.BidCnt.Create))
 	register("/api/bids/my", "GET", m.Wrap(cts.BidCnt.GetByUsername))
+	register("/api/bids/my/stream", "GET", cts.StrCnt.MyBids)
 	register("/api/bids/{tenderId}/list", "GET", m.Wrap(cts.BidCnt.GetByTenderId))
 	register("/api/bids/{bidId}/status", "GET", m.Wrap(cts.BidCnt.GetStatus))
 	register("/api/bids/{bidId}/status", "PUT", m.Wrap(cts.BidCnt.SetStatus))
@@ -53,5 +136,12 @@ func (r *Router) AddRoutes(m *Middleware, cts Controllers) {
 	register("/api/bids/{bidId}/submit_decision", "PUT", m.Wrap(cts.BidCnt.SubmitDecision))
 	register("/api/bids/{bidId}/feedback", "PUT", m.Wrap(cts.BidCnt.SubmitFeedback))
 	register("/api/bids/{bidId}/rollback/{version}", "PUT", m.Wrap(cts.BidCnt.Rollback))
+	register("/api/bids/{bidId}/withdraw", "PUT", m.Wrap(cts.BidCnt.Withdraw))
+	register("/api/bids/{bidId}/resubmit", "PUT", m.Wrap(cts.BidCnt.Resubmit))
+	register("/api/bids/{bidId}/content", "GET", m.Wrap(cts.BidCnt.Content))
+	register("/api/bids/{bidId}/commercial", "GET", m.Wrap(cts.BidCnt.Commercial))
 	register("/api/bids/{tenderId}/reviews", "GET", m.Wrap(cts.BidCnt.Reviews))
+	register("/api/bids/{tenderId}/conflict_overrides", "GET", m.Wrap(cts.BidCnt.ConflictOverrides))
+	register("/api/bids/{bidId}/scores", "PUT", m.Wrap(cts.EvalCnt.Score))
+	register("/api/bids/{bidId}/auction_price", "PUT", m.Wrap(cts.AucCnt.PlacePrice))
 }
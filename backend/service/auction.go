@@ -0,0 +1,352 @@
+package service
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"context"
+	"github.com/pkg/errors"
+	"sync"
+	"time"
+)
+
+type AuctionRep interface {
+	UpsertAuction(ctx context.Context, auction *model.Auction) error
+	GetAuction(ctx context.Context, tenderId string) (*model.Auction, error)
+	GetAuctionState(ctx context.Context, tenderId string) (*model.AuctionState, error)
+	AuctionRanking(ctx context.Context, tenderId string) ([]model.AuctionRanking, error)
+}
+
+type AuctionTransaction interface {
+	LockAuction(ctx context.Context, tenderId string) (*model.Auction, error)
+	GetAuctionState(ctx context.Context, tenderId string) (*model.AuctionState, error)
+	InsertPrice(ctx context.Context, price *model.AuctionPrice) error
+	ExtendAuction(ctx context.Context, tenderId string, endAt time.Time) error
+}
+
+// auctionSubscriberBuffer is the number of the states kept for a slow subscriber,
+// every state is complete so the older ones are dropped when it is full.
+const auctionSubscriberBuffer = 16
+
+// AuctionHub delivers the auction states to the subscribers of the tender streams.
+type AuctionHub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan domain.AuctionResp]struct{}
+}
+
+func NewAuctionHub() *AuctionHub {
+	return &AuctionHub{subscribers: make(map[string]map[chan domain.AuctionResp]struct{})}
+}
+
+func (h *AuctionHub) Subscribe(tenderId string) (chan domain.AuctionResp, func()) {
+	updates := make(chan domain.AuctionResp, auctionSubscriberBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[tenderId] == nil {
+		h.subscribers[tenderId] = make(map[chan domain.AuctionResp]struct{})
+	}
+	h.subscribers[tenderId][updates] = struct{}{}
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subscribers[tenderId], updates)
+		if len(h.subscribers[tenderId]) == 0 {
+			delete(h.subscribers, tenderId)
+		}
+	}
+
+	return updates, unsubscribe
+}
+
+func (h *AuctionHub) Publish(state domain.AuctionResp) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for updates := range h.subscribers[state.TenderId] {
+		select {
+		case updates <- state:
+		default:
+		}
+	}
+}
+
+// AuctionService runs the reverse auctions of the tenders. The best price is broadcast
+// to the participants and the final ranking decides which bid may be approved.
+type AuctionService struct {
+	auctionRep AuctionRep
+	tenderRep  TenderRep
+	bidRep     BidRep
+	txMan      TxManager
+	hub        *AuctionHub
+}
+
+func NewAuctionService(auctionRep AuctionRep, tenderRep TenderRep, bidRep BidRep, txMan TxManager, hub *AuctionHub) AuctionService {
+	return AuctionService{auctionRep: auctionRep, tenderRep: tenderRep, bidRep: bidRep, txMan: txMan, hub: hub}
+}
+
+// Set configures the auction of the tender, it can be changed until the first price is placed.
+func (s AuctionService) Set(ctx context.Context, username, tenderId string, req *domain.SetAuctionReq) (*domain.AuctionResp, error) {
+	isResponsible, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	tender, err := s.tenderRep.GetById(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if tender.Status == model.TenderStatusClosed {
+		return nil, domain.ErrTenderIsNotPublished
+	}
+	if tender.Sealed || req.MinStep <= 0 || req.ExtensionSeconds < 0 ||
+		!req.StartAt.Before(req.EndAt) || !req.EndAt.After(time.Now()) {
+		return nil, domain.ErrInvalidAuction
+	}
+
+	state, err := s.auctionRep.GetAuctionState(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if state.Prices > 0 {
+		return nil, domain.ErrAuctionStarted
+	}
+
+	err = s.auctionRep.UpsertAuction(ctx, &model.Auction{
+		TenderId:         tenderId,
+		StartAt:          req.StartAt,
+		EndAt:            req.EndAt,
+		MinStep:          req.MinStep,
+		ExtensionSeconds: req.ExtensionSeconds,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Auction set")
+	}
+
+	return s.state(ctx, tenderId)
+}
+
+func (s AuctionService) Get(ctx context.Context, username, tenderId string) (*domain.AuctionResp, error) {
+	canAccess, err := s.tenderRep.UsernameCanAccess(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, domain.ErrTenderNotAccessible
+	}
+
+	return s.state(ctx, tenderId)
+}
+
+// PlacePrice places the new price of the published bid. It must beat the best price by
+// the minimum step, and a price placed in the anti-sniping window extends the auction.
+// Only the bids shortlisted for the commercial stage bid on the two-stage tender.
+func (s AuctionService) PlacePrice(ctx context.Context, username, bidId string, req *domain.PlacePriceReq) (*domain.AuctionPriceResp, error) {
+	if req.Price <= 0 {
+		return nil, domain.ErrInvalidPrice
+	}
+
+	canManage, err := s.bidRep.UsernameCanManage(ctx, username, bidId)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, domain.ErrNotBidAuthor
+	}
+
+	bidStatus, err := s.bidRep.GetBidStatus(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+	if bidStatus != string(model.BidStatusPublished) {
+		return nil, domain.ErrBidIsNotPublished
+	}
+
+	tenderId, err := s.bidRep.GetTenderId(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+
+	tender, err := s.tenderRep.GetById(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if tender.Status != model.TenderStatusPublished {
+		return nil, domain.ErrTenderIsNotPublished
+	}
+	if tender.TwoStage {
+		if err = checkShortlisted(ctx, s.bidRep, tender, bidId); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		placed model.AuctionPrice
+		endAt  time.Time
+	)
+	err = s.txMan.AuctionTransaction(ctx, func(ctx context.Context, tx AuctionTransaction) error {
+		auction, err := tx.LockAuction(ctx, tenderId)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if now.Before(auction.StartAt) || !now.Before(auction.EndAt) {
+			return domain.ErrAuctionNotRunning
+		}
+
+		state, err := tx.GetAuctionState(ctx, tenderId)
+		if err != nil {
+			return err
+		}
+		if state.BestPrice != nil && req.Price > *state.BestPrice-auction.MinStep {
+			return domain.ErrPriceStepTooSmall
+		}
+
+		placed = model.AuctionPrice{TenderId: tenderId, BidId: bidId, Price: req.Price}
+		if err = tx.InsertPrice(ctx, &placed); err != nil {
+			return err
+		}
+
+		endAt = auction.EndAt
+		extension := time.Duration(auction.ExtensionSeconds) * time.Second
+		if auction.EndAt.Sub(now) < extension {
+			endAt = now.Add(extension)
+			return tx.ExtendAuction(ctx, tenderId, endAt)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Auction place price")
+	}
+
+	state, err := s.state(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	s.hub.Publish(*state)
+
+	return &domain.AuctionPriceResp{
+		Id:        placed.Id,
+		BidId:     placed.BidId,
+		Price:     placed.Price,
+		CreatedAt: placed.CreatedAt,
+		EndAt:     endAt,
+	}, nil
+}
+
+func (s AuctionService) Ranking(ctx context.Context, username, tenderId string) ([]domain.AuctionRankingResp, error) {
+	isResponsible, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	if _, err = s.auctionRep.GetAuction(ctx, tenderId); err != nil {
+		return nil, err
+	}
+
+	ranking, err := s.auctionRep.AuctionRanking(ctx, tenderId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Auction ranking")
+	}
+
+	resp := make([]domain.AuctionRankingResp, len(ranking))
+	for i := range ranking {
+		resp[i] = domain.AuctionRankingResp{
+			Rank:     i + 1,
+			BidId:    ranking[i].BidId,
+			Price:    ranking[i].Price,
+			PlacedAt: ranking[i].PlacedAt,
+		}
+	}
+
+	return resp, nil
+}
+
+// Subscribe streams the auction states to the tender organization and the bidders.
+// The current state is the first one in the channel.
+func (s AuctionService) Subscribe(ctx context.Context, username, tenderId string) (<-chan domain.AuctionResp, func(), error) {
+	isResponsible, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !isResponsible {
+		hasBids, err := s.bidRep.UsernameHasBids(ctx, username, tenderId)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !hasBids {
+			return nil, nil, domain.ErrBidsNotVisible
+		}
+	}
+
+	updates, unsubscribe := s.hub.Subscribe(tenderId)
+
+	state, err := s.state(ctx, tenderId)
+	if err != nil {
+		unsubscribe()
+		return nil, nil, err
+	}
+	updates <- *state
+
+	return updates, unsubscribe, nil
+}
+
+// CheckDecision allows approving only the best bid of the auction once it is over.
+func (s AuctionService) CheckDecision(ctx context.Context, tenderId, bidId, decision string) error {
+	auction, err := s.auctionRep.GetAuction(ctx, tenderId)
+	if errors.Is(err, domain.ErrAuctionDoesNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if decision != string(model.Approved) {
+		return nil
+	}
+
+	if time.Now().Before(auction.EndAt) {
+		return domain.ErrAuctionNotFinished
+	}
+
+	ranking, err := s.auctionRep.AuctionRanking(ctx, tenderId)
+	if err != nil {
+		return err
+	}
+	if len(ranking) == 0 || ranking[0].BidId != bidId {
+		return domain.ErrNotAuctionWinner
+	}
+
+	return nil
+}
+
+func (s AuctionService) state(ctx context.Context, tenderId string) (*domain.AuctionResp, error) {
+	auction, err := s.auctionRep.GetAuction(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.auctionRep.GetAuctionState(ctx, tenderId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Auction get state")
+	}
+
+	return &domain.AuctionResp{
+		TenderId:         auction.TenderId,
+		StartAt:          auction.StartAt,
+		EndAt:            auction.EndAt,
+		MinStep:          auction.MinStep,
+		ExtensionSeconds: auction.ExtensionSeconds,
+		BestPrice:        state.BestPrice,
+		Prices:           state.Prices,
+	}, nil
+}
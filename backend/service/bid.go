@@ -6,13 +6,16 @@ import (
 	"avito/domain"
 	"context"
 	"github.com/pkg/errors"
+	"strings"
+	"time"
 )
 
 type BidRep interface {
 	Insert(ctx context.Context, newTender *model.Bid) (string, error)
 	GetById(ctx context.Context, bidId string) (*model.Bid, error)
 	GetByUsername(ctx context.Context, offset, limit int, username string) ([]model.Bid, error)
-	GetVisibleByTenderId(ctx context.Context, offset, limit int, tenderId string) ([]model.Bid, error)
+	GetVisibleByTenderId(ctx context.Context, offset, limit int, tenderId, username string, isResponsible bool) ([]model.Bid, error)
+	UsernameHasBids(ctx context.Context, username, tenderId string) (bool, error)
 	GetBidStatus(ctx context.Context, bidId string) (string, error)
 	SetBidStatus(ctx context.Context, bidId, status string) error
 	UpdateById(ctx context.Context, bid *model.Bid) error
@@ -20,6 +23,17 @@ type BidRep interface {
 	Rollback(ctx context.Context, bidId string, version int) error
 	GetAuthorId(ctx context.Context, bidId string) (string, error)
 	GetOrgIdByBidId(ctx context.Context, bidId string) (string, error)
+	GetTenderId(ctx context.Context, bidId string) (string, error)
+	UsernameCanManage(ctx context.Context, username, bidId string) (bool, error)
+	Withdraw(ctx context.Context, withdrawal *model.BidWithdrawal) error
+	Resubmit(ctx context.Context, bidId string) error
+	GetContent(ctx context.Context, bidId string) (*model.BidContent, error)
+	UpsertCommercial(ctx context.Context, part *model.CommercialPart) error
+	GetCommercial(ctx context.Context, bidId string) (*model.CommercialPart, error)
+	IsShortlisted(ctx context.Context, bidId string) (bool, error)
+	GetShortlist(ctx context.Context, tenderId string) ([]model.ShortlistEntry, error)
+	GetPseudonyms(ctx context.Context, tenderId string) ([]model.BidPseudonym, error)
+	GetConflictOverrides(ctx context.Context, tenderId string) ([]model.ConflictOverride, error)
 	GetUserIdByName(ctx context.Context, username string) (string, error)
 }
 
@@ -29,12 +43,76 @@ type FeedbackRep interface {
 }
 
 type DecisionTransaction interface {
+	EventWriter
 	SetBidStatusIfOpen(ctx context.Context, bidId string, status string) (string, error)
 	SetTenderStatusIfOpen(ctx context.Context, tenderId, status string) error
+	InsertContract(ctx context.Context, bidId string) error
+}
+
+type CancelTransaction interface {
+	EventWriter
+	CloseTenderIfOpen(ctx context.Context, tenderId string) error
+	CloseOpenBids(ctx context.Context, tenderId string) error
+	InsertCancellation(ctx context.Context, cancellation *model.TenderCancellation) error
+}
+
+type TenderTransaction interface {
+	EventWriter
+	GetTenderStatus(ctx context.Context, tenderId string) (string, error)
+	Insert(ctx context.Context, newTender *model.Tender, authorUsername string) (string, error)
+	SetTenderStatus(ctx context.Context, tenderId, status string) error
+	UpdateById(ctx context.Context, tender *model.Tender) error
+	Rollback(ctx context.Context, tenderId string, version int) error
+	InsertInvitation(ctx context.Context, invitation *model.TenderInvitation) (string, error)
+	GetById(ctx context.Context, tenderId string) (*model.Tender, error)
+	SetSchedule(ctx context.Context, tenderId string, publishAt, closeAt *time.Time) error
+}
+
+type BidTransaction interface {
+	EventWriter
+	Insert(ctx context.Context, newBid *model.Bid) (string, error)
+	InsertConflictOverride(ctx context.Context, override *model.ConflictOverride) error
+	SetBidStatus(ctx context.Context, bidId, status string) error
+	UpdateById(ctx context.Context, bid *model.Bid) error
+	UpsertCommercial(ctx context.Context, part *model.CommercialPart) error
+	Withdraw(ctx context.Context, withdrawal *model.BidWithdrawal) error
+	Resubmit(ctx context.Context, bidId string) error
+	Rollback(ctx context.Context, bidId string, version int) error
+	SaveFeedback(ctx context.Context, feedback *model.Feedback) error
+}
+
+type ShortlistTransaction interface {
+	SetStage(ctx context.Context, tenderId string, from, to model.TenderStage) error
+	Shortlist(ctx context.Context, tenderId string, bidIds []string, userId string) (int64, error)
+}
+
+type TemplateTransaction interface {
+	EventWriter
+	Insert(ctx context.Context, newTender *model.Tender, authorUsername string) (string, error)
+	ReplaceCriteria(ctx context.Context, tenderId string, criteria []model.Criterion) error
 }
 
 type TxManager interface {
 	DecisionTransaction(ctx context.Context, pTx func(ctx context.Context, tx DecisionTransaction) error) error
+	CancelTransaction(ctx context.Context, pTx func(ctx context.Context, tx CancelTransaction) error) error
+	TemplateTransaction(ctx context.Context, pTx func(ctx context.Context, tx TemplateTransaction) error) error
+	OpenTransaction(ctx context.Context, pTx func(ctx context.Context, tx OpenTransaction) error) error
+	ShortlistTransaction(ctx context.Context, pTx func(ctx context.Context, tx ShortlistTransaction) error) error
+	AuctionTransaction(ctx context.Context, pTx func(ctx context.Context, tx AuctionTransaction) error) error
+	ComplaintTransaction(ctx context.Context, pTx func(ctx context.Context, tx ComplaintTransaction) error) error
+	TenderTransaction(ctx context.Context, pTx func(ctx context.Context, tx TenderTransaction) error) error
+	BidTransaction(ctx context.Context, pTx func(ctx context.Context, tx BidTransaction) error) error
+}
+
+type Envelopes interface {
+	SealContent(ctx context.Context, tender *model.Tender, name, description string) ([]byte, error)
+	UnsealContent(ctx context.Context, tenderId string, sealed []byte) (string, string, error)
+	CheckSubmissionsOpen(ctx context.Context, tender *model.Tender) error
+	CheckOpened(ctx context.Context, tender *model.Tender) error
+}
+
+type Auctions interface {
+	CheckDecision(ctx context.Context, tenderId, bidId, decision string) error
 }
 
 type BidService struct {
@@ -42,11 +120,27 @@ type BidService struct {
 	feedbackRep FeedbackRep
 	tenderRep   TenderRep
 	orgRep      OrganizationRep
+	envelopes   Envelopes
+	auctions    Auctions
 	txMan       TxManager
 }
 
-func NewBidService(bidRep BidRep, feedbackRep FeedbackRep, tenderRep TenderRep, orgRep OrganizationRep, txMan TxManager) BidService {
-	return BidService{bidRep: bidRep, feedbackRep: feedbackRep, tenderRep: tenderRep, orgRep: orgRep, txMan: txMan}
+func NewBidService(bidRep BidRep,
+	feedbackRep FeedbackRep,
+	tenderRep TenderRep,
+	orgRep OrganizationRep,
+	envelopes Envelopes,
+	auctions Auctions,
+	txMan TxManager) BidService {
+	return BidService{
+		bidRep:      bidRep,
+		feedbackRep: feedbackRep,
+		tenderRep:   tenderRep,
+		orgRep:      orgRep,
+		envelopes:   envelopes,
+		auctions:    auctions,
+		txMan:       txMan,
+	}
 }
 
 func (s BidService) Create(ctx context.Context, bidDom *domain.CreateBidReq) (*domain.CreateBidResp, error) {
@@ -57,8 +151,79 @@ func (s BidService) Create(ctx context.Context, bidDom *domain.CreateBidReq) (*d
 		AuthorType:  bidDom.AuthorType,
 		AuthorId:    bidDom.AuthorId,
 	}
+	if bidDom.OrganizationId != "" {
+		bidMod.OrganizationId = &bidDom.OrganizationId
+	}
+
+	err := s.validateNewBid(ctx, bidMod)
+	if err != nil {
+		return nil, err
+	}
+
+	canAccess, err := s.tenderRep.UserCanAccess(ctx, bidDom.AuthorId, bidDom.TenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, domain.ErrTenderNotAccessible
+	}
+
+	tender, err := s.tenderRep.GetById(ctx, bidDom.TenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	conflict, err := s.hasConflictOfInterest(ctx, bidMod, tender)
+	if err != nil {
+		return nil, err
+	}
+
+	justification := strings.TrimSpace(bidDom.ConflictJustification)
+	if conflict && justification == "" {
+		return nil, domain.ErrConflictOfInterest
+	}
+
+	if tender.TwoStage {
+		if bidDom.Commercial == nil {
+			return nil, domain.ErrCommercialPartRequired
+		}
+		if err = checkTechnicalStage(tender); err != nil {
+			return nil, err
+		}
+		bidMod.Commercial = &model.CommercialPart{Price: bidDom.Commercial.Price, Terms: bidDom.Commercial.Terms}
+	} else if bidDom.Commercial != nil {
+		return nil, domain.ErrTenderNotTwoStage
+	}
+
+	if err = s.sealContent(ctx, tender, bidMod); err != nil {
+		return nil, err
+	}
+
+	var bidId string
+	err = s.txMan.BidTransaction(ctx, func(ctx context.Context, tx BidTransaction) error {
+		var err error
+		bidId, err = tx.Insert(ctx, bidMod)
+		if err != nil {
+			return err
+		}
+
+		if conflict {
+			err = tx.InsertConflictOverride(ctx, &model.ConflictOverride{
+				BidId:          bidId,
+				TenderId:       bidMod.TenderId,
+				UserId:         bidMod.AuthorId,
+				OrganizationId: bidMod.OrganizationId,
+				Justification:  justification,
+			})
+			if err != nil {
+				return err
+			}
+		}
 
-	bidId, err := s.bidRep.Insert(ctx, bidMod)
+		return tx.InsertEvent(ctx, bidEvent(model.EventBidCreated, bidMod.TenderId, bidId, model.EventPayload{
+			Status: string(model.BidStatusCreated),
+		}))
+	})
 	if err != nil {
 		return nil, errors.WithMessage(err, "Service.Bid insert")
 	}
@@ -81,6 +246,316 @@ func (s BidService) Create(ctx context.Context, bidDom *domain.CreateBidReq) (*d
 	return resp, nil
 }
 
+// validateNewBid checks that the bid is placed on a published tender by an existing author.
+// Organization authors must be responsible for the bidding organization, which is resolved
+// from the author when it is not specified.
+func (s BidService) validateNewBid(ctx context.Context, bid *model.Bid) error {
+	if bid.AuthorType != model.BidAuthorTypeUser && bid.AuthorType != model.BidAuthorTypeOrganization {
+		return domain.ErrInvalidAuthorType
+	}
+
+	status, err := s.tenderRep.GetTenderStatus(ctx, bid.TenderId)
+	if err != nil {
+		return err
+	}
+	if status != string(model.TenderStatusPublished) {
+		return domain.ErrTenderIsNotPublished
+	}
+
+	exists, err := s.orgRep.EmpExists(ctx, bid.AuthorId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrAuthorDoesNotExist
+	}
+
+	if bid.AuthorType != model.BidAuthorTypeOrganization {
+		bid.OrganizationId = nil
+		return nil
+	}
+
+	orgIds, err := s.orgRep.EmpOrgIds(ctx, bid.AuthorId)
+	if err != nil {
+		return err
+	}
+	if len(orgIds) == 0 {
+		return domain.ErrAuthorHasNoOrganization
+	}
+
+	if bid.OrganizationId == nil {
+		if len(orgIds) > 1 {
+			return domain.ErrOrganizationRequired
+		}
+		bid.OrganizationId = &orgIds[0]
+		return nil
+	}
+
+	for _, orgId := range orgIds {
+		if orgId == *bid.OrganizationId {
+			return nil
+		}
+	}
+
+	return domain.ErrUserNotResponsible
+}
+
+// hasConflictOfInterest reports whether the author is responsible for the tender organization
+// or bids on behalf of the tender organization itself.
+func (s BidService) hasConflictOfInterest(ctx context.Context, bid *model.Bid, tender *model.Tender) (bool, error) {
+	if bid.OrganizationId != nil && *bid.OrganizationId == tender.OrganizationId {
+		return true, nil
+	}
+
+	orgIds, err := s.orgRep.EmpOrgIds(ctx, bid.AuthorId)
+	if err != nil {
+		return false, err
+	}
+	for _, orgId := range orgIds {
+		if orgId == tender.OrganizationId {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// sealContent moves the name and description of the bid on a sealed tender into the encrypted content.
+func (s BidService) sealContent(ctx context.Context, tender *model.Tender, bid *model.Bid) error {
+	if !tender.Sealed {
+		return nil
+	}
+
+	sealed, err := s.envelopes.SealContent(ctx, tender, bid.Name, bid.Description)
+	if err != nil {
+		return err
+	}
+
+	bid.SealedContent = sealed
+	bid.Name, bid.Description = "", ""
+
+	return nil
+}
+
+// checkBidsOpen rejects the changes of the bids once the sealed tender envelopes
+// or the technical stage of the two-stage tender are closed.
+func (s BidService) checkBidsOpen(ctx context.Context, tender *model.Tender) error {
+	if err := checkTechnicalStage(tender); err != nil {
+		return err
+	}
+
+	return s.envelopes.CheckSubmissionsOpen(ctx, tender)
+}
+
+func checkTechnicalStage(tender *model.Tender) error {
+	if tender.TwoStage && (tender.Stage == nil || *tender.Stage != model.TenderStageTechnical) {
+		return domain.ErrTechnicalStageClosed
+	}
+
+	return nil
+}
+
+// Commercial returns the commercial part of the bid on the two-stage tender. The tender
+// organization sees it only for the shortlisted bids once the technical stage is over.
+func (s BidService) Commercial(ctx context.Context, username, bidId string) (*domain.CommercialPartResp, error) {
+	tender, err := s.bidTender(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+	if !tender.TwoStage {
+		return nil, domain.ErrTenderNotTwoStage
+	}
+
+	canManage, err := s.bidRep.UsernameCanManage(ctx, username, bidId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canManage {
+		isResponsible, err := s.orgRep.EmpBelongs(ctx, username, tender.OrganizationId)
+		if err != nil {
+			return nil, err
+		}
+		if !isResponsible {
+			return nil, domain.ErrUserNotResponsible
+		}
+
+		if err = checkShortlisted(ctx, s.bidRep, tender, bidId); err != nil {
+			return nil, err
+		}
+	}
+
+	part, err := s.bidRep.GetCommercial(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CommercialPartResp{
+		BidId:     part.BidId,
+		Price:     part.Price,
+		Terms:     part.Terms,
+		UpdatedAt: part.UpdatedAt,
+	}, nil
+}
+
+// checkShortlisted allows the bid to take part in the commercial stage of the two-stage tender.
+func checkShortlisted(ctx context.Context, bidRep BidRep, tender *model.Tender, bidId string) error {
+	if tender.Stage == nil || *tender.Stage == model.TenderStageTechnical {
+		return domain.ErrTechnicalStage
+	}
+
+	shortlisted, err := bidRep.IsShortlisted(ctx, bidId)
+	if err != nil {
+		return err
+	}
+	if !shortlisted {
+		return domain.ErrBidNotShortlisted
+	}
+
+	return nil
+}
+
+// authorPseudonyms maps the bid authors of the anonymized tender to their pseudonyms.
+// It returns nil once the tender is closed or if it is not anonymized at all.
+func authorPseudonyms(ctx context.Context, bidRep BidRep, tender *model.Tender) (map[string]string, error) {
+	if !tender.Anonymized || tender.Status == model.TenderStatusClosed {
+		return nil, nil
+	}
+
+	pseudonyms, err := bidRep.GetPseudonyms(ctx, tender.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	byAuthor := make(map[string]string, len(pseudonyms))
+	for i := range pseudonyms {
+		byAuthor[pseudonyms[i].AuthorId] = pseudonyms[i].Pseudonym
+	}
+
+	return byAuthor, nil
+}
+
+func pseudonymize(pseudonyms map[string]string, authorId string) string {
+	if pseudonym, ok := pseudonyms[authorId]; ok {
+		return pseudonym
+	}
+
+	return authorId
+}
+
+func (s BidService) bidTender(ctx context.Context, bidId string) (*model.Tender, error) {
+	tenderId, err := s.bidRep.GetTenderId(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.tenderRep.GetById(ctx, tenderId)
+}
+
+// Content returns the name and description of the bid. Bid contents of the sealed tenders
+// are available only to the bid authors until the envelopes are opened.
+func (s BidService) Content(ctx context.Context, username, bidId string) (*domain.BidContentResp, error) {
+	canManage, err := s.bidRep.UsernameCanManage(ctx, username, bidId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canManage {
+		orgId, err := s.bidRep.GetOrgIdByBidId(ctx, bidId)
+		if err != nil {
+			return nil, err
+		}
+
+		isResponsible, err := s.orgRep.EmpBelongs(ctx, username, orgId)
+		if err != nil {
+			return nil, err
+		}
+		if !isResponsible {
+			return nil, domain.ErrUserNotResponsible
+		}
+	}
+
+	content, err := s.bidRep.GetContent(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+
+	if content.SealedContent != nil {
+		if !canManage {
+			return nil, domain.ErrEnvelopesSealed
+		}
+
+		tenderId, err := s.bidRep.GetTenderId(ctx, bidId)
+		if err != nil {
+			return nil, err
+		}
+
+		content.Name, content.Description, err = s.envelopes.UnsealContent(ctx, tenderId, content.SealedContent)
+		if err != nil {
+			return nil, errors.WithMessage(err, "Service.Bid unseal content")
+		}
+	}
+
+	return &domain.BidContentResp{
+		BidId:       content.BidId,
+		Name:        content.Name,
+		Description: content.Description,
+		Version:     content.Version,
+	}, nil
+}
+
+// ConflictOverrides returns the audit trail of the bids created despite the conflict of interest.
+// The authors of the anonymized tender are shown by their pseudonyms until the tender is closed.
+func (s BidService) ConflictOverrides(ctx context.Context, username, tenderId string) ([]domain.ConflictOverrideResp, error) {
+	isResponsible, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	tender, err := s.tenderRep.GetById(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.bidRep.GetConflictOverrides(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	pseudonyms, err := authorPseudonyms(ctx, s.bidRep, tender)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]domain.ConflictOverrideResp, len(overrides))
+	for i := range overrides {
+		resp[i] = domain.ConflictOverrideResp{
+			Id:             overrides[i].Id,
+			BidId:          overrides[i].BidId,
+			TenderId:       overrides[i].TenderId,
+			UserId:         overrides[i].UserId,
+			OrganizationId: overrides[i].OrganizationId,
+			Justification:  overrides[i].Justification,
+			CreatedAt:      overrides[i].CreatedAt,
+		}
+
+		// the pseudonym stands for the organization of the bid, or for the user bidding alone
+		if pseudonyms != nil {
+			authorId := overrides[i].UserId
+			if overrides[i].OrganizationId != nil {
+				authorId = *overrides[i].OrganizationId
+			}
+			resp[i].UserId = pseudonymize(pseudonyms, authorId)
+			resp[i].OrganizationId = nil
+		}
+	}
+
+	return resp, nil
+}
+
 func (s BidService) GetByUsername(ctx context.Context, offset, limit int, username string) ([]domain.GetBidResp, error) {
 	bids, err := s.bidRep.GetByUsername(ctx, offset, limit, username)
 	if err != nil {
@@ -103,12 +578,41 @@ func (s BidService) GetByUsername(ctx context.Context, offset, limit int, userna
 	return resp, nil
 }
 
-func (s BidService) GetByTenderId(ctx context.Context, offset, limit int, tenderId string) ([]domain.GetBidResp, error) {
-	bids, err := s.bidRep.GetVisibleByTenderId(ctx, offset, limit, tenderId)
+// GetByTenderId lists the tender bids: the responsible employees of the tender organization see
+// all the bids except drafts, the bid authors see their own bids. Everyone else is forbidden.
+func (s BidService) GetByTenderId(ctx context.Context, offset, limit int, tenderId, username string) ([]domain.GetBidResp, error) {
+	tender, err := s.tenderRep.GetById(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	isResponsible, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
 	if err != nil {
 		return nil, err
 	}
 
+	if !isResponsible {
+		hasBids, err := s.bidRep.UsernameHasBids(ctx, username, tenderId)
+		if err != nil {
+			return nil, err
+		}
+		if !hasBids {
+			return nil, domain.ErrBidsNotVisible
+		}
+	}
+
+	bids, err := s.bidRep.GetVisibleByTenderId(ctx, offset, limit, tenderId, username, isResponsible)
+	if err != nil {
+		return nil, err
+	}
+
+	var pseudonyms map[string]string
+	if isResponsible {
+		if pseudonyms, err = authorPseudonyms(ctx, s.bidRep, tender); err != nil {
+			return nil, err
+		}
+	}
+
 	resp := make([]domain.GetBidResp, len(bids))
 	for i := range bids {
 		resp[i] = domain.GetBidResp{
@@ -116,7 +620,7 @@ func (s BidService) GetByTenderId(ctx context.Context, offset, limit int, tender
 			Name:       bids[i].Name,
 			Status:     bids[i].Status,
 			AuthorType: bids[i].AuthorType,
-			AuthorId:   bids[i].AuthorId,
+			AuthorId:   pseudonymize(pseudonyms, bids[i].AuthorId),
 			CreatedAt:  bids[i].CreatedAt,
 			Version:    bids[i].Version,
 		}
@@ -126,12 +630,7 @@ func (s BidService) GetByTenderId(ctx context.Context, offset, limit int, tender
 }
 
 func (s BidService) GetStatus(ctx context.Context, bidId, username string) (string, error) {
-	authorId, err := s.bidRep.GetAuthorId(ctx, bidId)
-	if err != nil {
-		return "", err
-	}
-
-	userId, err := s.bidRep.GetUserIdByName(ctx, username)
+	canManage, err := s.bidRep.UsernameCanManage(ctx, username, bidId)
 	if err != nil {
 		return "", err
 	}
@@ -141,38 +640,72 @@ func (s BidService) GetStatus(ctx context.Context, bidId, username string) (stri
 		return "", err
 	}
 
-	if authorId != userId && status == string(model.BidStatusCreated) {
+	if !canManage && status == string(model.BidStatusCreated) {
 		return "", domain.ErrBidDoesNotExist
 	}
 
 	return status, nil
 }
 
+// SetStatus moves the bid of the author between Created and Published. The withdrawn bid
+// comes back through Resubmit and the decided one keeps its status.
 func (s BidService) SetStatus(ctx context.Context, bidId, username, status string) (*domain.SetStatusBidResp, error) {
-	authorId, err := s.bidRep.GetAuthorId(ctx, bidId)
+	canManage, err := s.bidRep.UsernameCanManage(ctx, username, bidId)
 	if err != nil {
 		return nil, err
 	}
+	if !canManage {
+		return nil, domain.ErrNotBidAuthor
+	}
 
-	userId, err := s.bidRep.GetUserIdByName(ctx, username)
+	switch status {
+	case string(model.BidStatusApproved), string(model.BidStatusRejected):
+		return nil, domain.ErrForbiddenApproval
+	case string(model.BidStatusCreated), string(model.BidStatusPublished):
+	default:
+		return nil, domain.ErrInvalidBidStatus
+	}
+
+	bid, err := s.bidRep.GetById(ctx, bidId)
 	if err != nil {
 		return nil, err
 	}
-
-	if authorId != userId {
-		return nil, domain.ErrNotBidAuthor
+	if bid.Status == model.BidStatusCanceled && status == string(model.BidStatusPublished) {
+		return nil, domain.ErrResubmitRequired
 	}
 
-	if status == string(model.BidStatusApproved) || status == string(model.BidStatusRejected) {
-		return nil, domain.ErrForbiddenApproval
+	if status == string(model.BidStatusPublished) && bid.Status != model.BidStatusPublished {
+		tender, err := s.tenderRep.GetById(ctx, bid.TenderId)
+		if err != nil {
+			return nil, err
+		}
+		if tender.Status != model.TenderStatusPublished {
+			return nil, domain.ErrTenderIsNotPublished
+		}
+		if err = s.checkBidsOpen(ctx, tender); err != nil {
+			return nil, err
+		}
 	}
 
-	err = s.bidRep.SetBidStatus(ctx, bidId, status)
+	err = s.txMan.BidTransaction(ctx, func(ctx context.Context, tx BidTransaction) error {
+		if err := tx.SetBidStatus(ctx, bidId, status); err != nil {
+			return err
+		}
+
+		if status != string(model.BidStatusPublished) || bid.Status == model.BidStatusPublished {
+			return nil
+		}
+
+		return tx.InsertEvent(ctx, bidEvent(model.EventBidSubmitted, bid.TenderId, bidId, model.EventPayload{
+			Status:   status,
+			Username: username,
+		}))
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	bid, err := s.bidRep.GetById(ctx, bidId)
+	bid, err = s.bidRep.GetById(ctx, bidId)
 	if err != nil {
 		return nil, err
 	}
@@ -191,18 +724,21 @@ func (s BidService) SetStatus(ctx context.Context, bidId, username, status strin
 }
 
 func (s BidService) Edit(ctx context.Context, username, bidId string, editBid *domain.EditBidReq) (*domain.EditBidResp, error) {
-	authorId, err := s.bidRep.GetAuthorId(ctx, bidId)
+	canManage, err := s.bidRep.UsernameCanManage(ctx, username, bidId)
 	if err != nil {
 		return nil, err
 	}
+	if !canManage {
+		return nil, domain.ErrNotBidAuthor
+	}
 
-	userId, err := s.bidRep.GetUserIdByName(ctx, username)
+	tender, err := s.bidTender(ctx, bidId)
 	if err != nil {
 		return nil, err
 	}
 
-	if authorId != userId {
-		return nil, domain.ErrNotBidAuthor
+	if err = s.checkBidsOpen(ctx, tender); err != nil {
+		return nil, err
 	}
 
 	bidToUpd := &model.Bid{
@@ -211,7 +747,32 @@ func (s BidService) Edit(ctx context.Context, username, bidId string, editBid *d
 		Description: editBid.Description,
 	}
 
-	err = s.bidRep.UpdateById(ctx, bidToUpd)
+	if err = s.sealContent(ctx, tender, bidToUpd); err != nil {
+		return nil, err
+	}
+
+	if editBid.Commercial != nil && !tender.TwoStage {
+		return nil, domain.ErrTenderNotTwoStage
+	}
+
+	err = s.txMan.BidTransaction(ctx, func(ctx context.Context, tx BidTransaction) error {
+		if editBid.Commercial != nil {
+			err := tx.UpsertCommercial(ctx, &model.CommercialPart{
+				BidId: bidId,
+				Price: editBid.Commercial.Price,
+				Terms: editBid.Commercial.Terms,
+			})
+			if err != nil {
+				return errors.WithMessage(err, "edit commercial part")
+			}
+		}
+
+		if err := tx.UpdateById(ctx, bidToUpd); err != nil {
+			return err
+		}
+
+		return tx.InsertEvent(ctx, bidEvent(model.EventBidEdited, tender.Id, bidId, model.EventPayload{Username: username}))
+	})
 	if err != nil {
 		return nil, errors.WithMessage(err, "Service.Bid edit")
 	}
@@ -252,14 +813,38 @@ func (s BidService) SubmitDecision(ctx context.Context, username, bidId, decisio
 		return nil, domain.ErrInvalidDecision
 	}
 
+	tender, err := s.bidTender(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.envelopes.CheckOpened(ctx, tender); err != nil {
+		return nil, err
+	}
+	if tender.TwoStage {
+		if err = checkShortlisted(ctx, s.bidRep, tender, bidId); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.auctions.CheckDecision(ctx, tender.Id, bidId, decision); err != nil {
+		return nil, err
+	}
+
+	// the framework agreement stays open for the decisions on the other bids
+	if tender.Framework && tender.Status != model.TenderStatusPublished {
+		return nil, domain.ErrTenderIsNotPublished
+	}
+
 	err = s.txMan.DecisionTransaction(ctx, func(ctx context.Context, tx DecisionTransaction) error {
 		var (
 			tenderStatus = model.TenderStatusClosed
 			bidStatus    = model.BidStatusRejected
+			bidEventType = model.EventBidRejected
 		)
 
 		if decision == string(model.Approved) {
 			bidStatus = model.BidStatusApproved
+			bidEventType = model.EventBidApproved
 		}
 
 		tenderId, err := tx.SetBidStatusIfOpen(ctx, bidId, string(bidStatus))
@@ -267,11 +852,33 @@ func (s BidService) SubmitDecision(ctx context.Context, username, bidId, decisio
 			return domain.ErrBidIsNotPublished
 		}
 
+		err = tx.InsertEvent(ctx, bidEvent(bidEventType, tenderId, bidId, model.EventPayload{
+			Status:   string(bidStatus),
+			Username: username,
+		}))
+		if err != nil {
+			return err
+		}
+
+		// the approved suppliers of the framework receive call-off orders instead of a contract
+		if tender.Framework {
+			return nil
+		}
+
 		err = tx.SetTenderStatusIfOpen(ctx, tenderId, string(tenderStatus))
 		if err != nil {
 			return domain.ErrTenderIsNotPublished
 		}
 
+		err = tx.InsertEvent(ctx, tenderStatusEvent(tenderId, tenderStatus, username))
+		if err != nil {
+			return err
+		}
+
+		if bidStatus == model.BidStatusApproved {
+			return tx.InsertContract(ctx, bidId)
+		}
+
 		return nil
 	})
 
@@ -284,12 +891,23 @@ func (s BidService) SubmitDecision(ctx context.Context, username, bidId, decisio
 		return nil, errors.WithMessage(err, "Service.Bid get")
 	}
 
+	// the approval closes the tender and reveals the authors
+	tender, err = s.tenderRep.GetById(ctx, tender.Id)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Bid get tender")
+	}
+
+	pseudonyms, err := authorPseudonyms(ctx, s.bidRep, tender)
+	if err != nil {
+		return nil, err
+	}
+
 	bidDom := &domain.SubmitDecisionBidResp{
 		Id:         bid.Id,
 		Name:       bid.Name,
 		Status:     bid.Status,
 		AuthorType: bid.AuthorType,
-		AuthorId:   bid.AuthorId,
+		AuthorId:   pseudonymize(pseudonyms, bid.AuthorId),
 		CreatedAt:  bid.CreatedAt,
 		Version:    bid.Version,
 	}
@@ -328,14 +946,33 @@ func (s BidService) SubmitFeedback(ctx context.Context, content, bidId, authorUs
 		ReceiverId: receiverId,
 	}
 
-	err = s.feedbackRep.SaveFeedback(ctx, feedback)
+	bid, err := s.bidRep.GetById(ctx, feedback.BidId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Bid get")
+	}
+
+	err = s.txMan.BidTransaction(ctx, func(ctx context.Context, tx BidTransaction) error {
+		if err := tx.SaveFeedback(ctx, feedback); err != nil {
+			return err
+		}
+
+		return tx.InsertEvent(ctx, bidEvent(model.EventBidFeedback, bid.TenderId, bidId, model.EventPayload{
+			Feedback: content,
+			Username: authorUsername,
+		}))
+	})
 	if err != nil {
 		return nil, errors.WithMessage(err, "Service.Bid submit feedback")
 	}
 
-	bid, err := s.bidRep.GetById(ctx, feedback.BidId)
+	tender, err := s.bidTender(ctx, bid.Id)
 	if err != nil {
-		return nil, errors.WithMessage(err, "Service.Bid get")
+		return nil, errors.WithMessage(err, "Service.Bid get tender")
+	}
+
+	pseudonyms, err := authorPseudonyms(ctx, s.bidRep, tender)
+	if err != nil {
+		return nil, err
 	}
 
 	bidDom := &domain.FeedbackBidResp{
@@ -343,7 +980,7 @@ func (s BidService) SubmitFeedback(ctx context.Context, content, bidId, authorUs
 		Name:       bid.Name,
 		Status:     bid.Status,
 		AuthorType: bid.AuthorType,
-		AuthorId:   bid.AuthorId,
+		AuthorId:   pseudonymize(pseudonyms, bid.AuthorId),
 		CreatedAt:  bid.CreatedAt,
 		Version:    bid.Version,
 	}
@@ -352,21 +989,30 @@ func (s BidService) SubmitFeedback(ctx context.Context, content, bidId, authorUs
 }
 
 func (s BidService) Rollback(ctx context.Context, username, bidId string, version int) (*domain.RollbackBidResp, error) {
-	authorId, err := s.bidRep.GetAuthorId(ctx, bidId)
+	canManage, err := s.bidRep.UsernameCanManage(ctx, username, bidId)
 	if err != nil {
 		return nil, err
 	}
+	if !canManage {
+		return nil, domain.ErrNotBidAuthor
+	}
 
-	userId, err := s.bidRep.GetUserIdByName(ctx, username)
+	tender, err := s.bidTender(ctx, bidId)
 	if err != nil {
 		return nil, err
 	}
 
-	if authorId != userId {
-		return nil, domain.ErrNotBidAuthor
+	if err = s.checkBidsOpen(ctx, tender); err != nil {
+		return nil, err
 	}
 
-	err = s.bidRep.Rollback(ctx, bidId, version)
+	err = s.txMan.BidTransaction(ctx, func(ctx context.Context, tx BidTransaction) error {
+		if err := tx.Rollback(ctx, bidId, version); err != nil {
+			return err
+		}
+
+		return tx.InsertEvent(ctx, bidEvent(model.EventBidEdited, tender.Id, bidId, model.EventPayload{Username: username}))
+	})
 	if err != nil {
 		return nil, errors.WithMessage(err, "Service.Bid rollback")
 	}
@@ -389,6 +1035,124 @@ func (s BidService) Rollback(ctx context.Context, username, bidId string, versio
 	return resp, nil
 }
 
+// Withdraw cancels the active bid of the author, so that the bidder may resubmit it later.
+func (s BidService) Withdraw(ctx context.Context, username, bidId, reason string) (*domain.WithdrawBidResp, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, domain.ErrWithdrawReasonRequired
+	}
+
+	canManage, err := s.bidRep.UsernameCanManage(ctx, username, bidId)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, domain.ErrNotBidAuthor
+	}
+
+	userId, err := s.bidRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	bid, err := s.bidRep.GetById(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txMan.BidTransaction(ctx, func(ctx context.Context, tx BidTransaction) error {
+		err := tx.Withdraw(ctx, &model.BidWithdrawal{
+			BidId:  bidId,
+			Reason: reason,
+			UserId: userId,
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.InsertEvent(ctx, bidEvent(model.EventBidWithdrawn, bid.TenderId, bidId, model.EventPayload{
+			Status:   string(model.BidStatusCanceled),
+			Reason:   reason,
+			Username: username,
+		}))
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Bid withdraw")
+	}
+
+	bid, err = s.bidRep.GetById(ctx, bidId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Bid get")
+	}
+
+	resp := &domain.WithdrawBidResp{
+		Id:         bid.Id,
+		Name:       bid.Name,
+		Status:     bid.Status,
+		AuthorType: bid.AuthorType,
+		AuthorId:   bid.AuthorId,
+		Reason:     reason,
+		CreatedAt:  bid.CreatedAt,
+		Version:    bid.Version,
+	}
+
+	return resp, nil
+}
+
+// Resubmit reopens the withdrawn bid as a new version while the tender is still published.
+func (s BidService) Resubmit(ctx context.Context, username, bidId string) (*domain.ResubmitBidResp, error) {
+	canManage, err := s.bidRep.UsernameCanManage(ctx, username, bidId)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, domain.ErrNotBidAuthor
+	}
+
+	tender, err := s.bidTender(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+	if tender.Status != model.TenderStatusPublished {
+		return nil, domain.ErrTenderIsNotPublished
+	}
+
+	if err = s.checkBidsOpen(ctx, tender); err != nil {
+		return nil, err
+	}
+
+	err = s.txMan.BidTransaction(ctx, func(ctx context.Context, tx BidTransaction) error {
+		if err := tx.Resubmit(ctx, bidId); err != nil {
+			return err
+		}
+
+		return tx.InsertEvent(ctx, bidEvent(model.EventBidResubmitted, tender.Id, bidId, model.EventPayload{
+			Status:   string(model.BidStatusCreated),
+			Username: username,
+		}))
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Bid resubmit")
+	}
+
+	bid, err := s.bidRep.GetById(ctx, bidId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Bid get")
+	}
+
+	resp := &domain.ResubmitBidResp{
+		Id:         bid.Id,
+		Name:       bid.Name,
+		Status:     bid.Status,
+		AuthorType: bid.AuthorType,
+		AuthorId:   bid.AuthorId,
+		CreatedAt:  bid.CreatedAt,
+		Version:    bid.Version,
+	}
+
+	return resp, nil
+}
+
 func (s BidService) Reviews(ctx context.Context, requesterName, authorName, tenderId string, offset, limit int) ([]domain.ReviewResp, error) {
 	if realAuthor, err := s.tenderRep.AuthorByTenderId(ctx, tenderId); err != nil || realAuthor != authorName {
 		return nil, domain.ErrAuthorIsIncorrect
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"context"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+)
+
+type ComplaintRep interface {
+	ComplaintWindowOpen(ctx context.Context, tenderId string, window time.Duration) (bool, error)
+	InsertComplaint(ctx context.Context, complaint *model.Complaint) (string, error)
+	GetComplaint(ctx context.Context, complaintId string) (*model.Complaint, error)
+	GetComplaints(ctx context.Context, tenderId string) ([]model.Complaint, error)
+	InsertResponse(ctx context.Context, response *model.ComplaintResponse) error
+	GetResponses(ctx context.Context, complaintIds []string) ([]model.ComplaintResponse, error)
+	AwardFrozen(ctx context.Context, tenderId string) (bool, error)
+}
+
+type ComplaintTransaction interface {
+	SetComplaintStatus(ctx context.Context, complaintId string, from []model.ComplaintStatus, to model.ComplaintStatus) error
+	InsertResponse(ctx context.Context, response *model.ComplaintResponse) error
+}
+
+// Complaints guards the award of the tender against the open complaints.
+type Complaints interface {
+	CheckAward(ctx context.Context, tenderId string) error
+}
+
+// ComplaintService handles the complaints of the bid authors against the decisions:
+// a complaint is filed within the window after the tender is closed and the tender
+// organization reviews it, responds and upholds or dismisses it.
+type ComplaintService struct {
+	complaintRep ComplaintRep
+	tenderRep    TenderRep
+	bidRep       BidRep
+	txMan        TxManager
+	window       time.Duration
+}
+
+func NewComplaintService(complaintRep ComplaintRep, tenderRep TenderRep, bidRep BidRep, txMan TxManager,
+	window time.Duration) ComplaintService {
+	return ComplaintService{complaintRep: complaintRep, tenderRep: tenderRep, bidRep: bidRep, txMan: txMan, window: window}
+}
+
+// File files the complaint of the bid author against the decision on the tender.
+// The approved bid has nothing to complain about.
+func (s ComplaintService) File(ctx context.Context, username, tenderId string, req *domain.FileComplaintReq) (*domain.ComplaintResp, error) {
+	if strings.TrimSpace(req.Reason) == "" || req.BidId == "" {
+		return nil, domain.ErrInvalidComplaint
+	}
+
+	bid, err := s.bidRep.GetById(ctx, req.BidId)
+	if err != nil {
+		return nil, err
+	}
+	if bid.TenderId != tenderId || bid.Status == model.BidStatusApproved {
+		return nil, domain.ErrInvalidComplaint
+	}
+
+	canManage, err := s.bidRep.UsernameCanManage(ctx, username, req.BidId)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	isOpen, err := s.complaintRep.ComplaintWindowOpen(ctx, tenderId, s.window)
+	if err != nil {
+		return nil, err
+	}
+	if !isOpen {
+		return nil, domain.ErrComplaintWindowClosed
+	}
+
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	complaintId, err := s.complaintRep.InsertComplaint(ctx, &model.Complaint{
+		TenderId:    tenderId,
+		BidId:       req.BidId,
+		UserId:      userId,
+		Reason:      req.Reason,
+		FreezeAward: req.FreezeAward,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Complaint file")
+	}
+
+	return s.get(ctx, complaintId)
+}
+
+// GetByTenderId lists all the complaints to the tender organization
+// and the complaints against the own bids to the bid authors.
+func (s ComplaintService) GetByTenderId(ctx context.Context, username, tenderId string) ([]domain.ComplaintResp, error) {
+	isBuyer, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	complaints, err := s.complaintRep.GetComplaints(ctx, tenderId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Complaint get complaints")
+	}
+
+	if !isBuyer {
+		visible := make([]model.Complaint, 0, len(complaints))
+		for _, complaint := range complaints {
+			canManage, err := s.bidRep.UsernameCanManage(ctx, username, complaint.BidId)
+			if err != nil {
+				return nil, err
+			}
+			if canManage {
+				visible = append(visible, complaint)
+			}
+		}
+		complaints = visible
+	}
+
+	return s.complaintsResp(ctx, complaints)
+}
+
+func (s ComplaintService) Get(ctx context.Context, username, complaintId string) (*domain.ComplaintResp, error) {
+	complaint, err := s.complaintRep.GetComplaint(ctx, complaintId)
+	if err != nil {
+		return nil, err
+	}
+
+	isBuyer, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, complaint.TenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isBuyer {
+		canManage, err := s.bidRep.UsernameCanManage(ctx, username, complaint.BidId)
+		if err != nil {
+			return nil, err
+		}
+		if !canManage {
+			return nil, domain.ErrUserNotResponsible
+		}
+	}
+
+	return s.get(ctx, complaintId)
+}
+
+// Respond adds the response of the tender organization to the open complaint.
+func (s ComplaintService) Respond(ctx context.Context, username, complaintId, message string) (*domain.ComplaintResp, error) {
+	if strings.TrimSpace(message) == "" {
+		return nil, domain.ErrComplaintResponseMissing
+	}
+
+	complaint, userId, err := s.checkBuyer(ctx, username, complaintId)
+	if err != nil {
+		return nil, err
+	}
+
+	if complaint.Status != model.ComplaintStatusFiled && complaint.Status != model.ComplaintStatusUnderReview {
+		return nil, domain.ErrComplaintTransition
+	}
+
+	err = s.complaintRep.InsertResponse(ctx, &model.ComplaintResponse{
+		ComplaintId: complaintId,
+		UserId:      userId,
+		Message:     message,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Complaint respond")
+	}
+
+	return s.get(ctx, complaintId)
+}
+
+// SetStatus takes the complaint under review or resolves it, the resolution must be explained.
+func (s ComplaintService) SetStatus(ctx context.Context, username, complaintId, status, message string) (*domain.ComplaintResp, error) {
+	var from []model.ComplaintStatus
+	switch model.ComplaintStatus(status) {
+	case model.ComplaintStatusUnderReview:
+		from = []model.ComplaintStatus{model.ComplaintStatusFiled}
+	case model.ComplaintStatusUpheld, model.ComplaintStatusDismissed:
+		if strings.TrimSpace(message) == "" {
+			return nil, domain.ErrComplaintResponseMissing
+		}
+		from = []model.ComplaintStatus{model.ComplaintStatusFiled, model.ComplaintStatusUnderReview}
+	default:
+		return nil, domain.ErrComplaintTransition
+	}
+
+	_, userId, err := s.checkBuyer(ctx, username, complaintId)
+	if err != nil {
+		return nil, err
+	}
+
+	to := model.ComplaintStatus(status)
+	err = s.txMan.ComplaintTransaction(ctx, func(ctx context.Context, tx ComplaintTransaction) error {
+		if err := tx.SetComplaintStatus(ctx, complaintId, from, to); err != nil {
+			return err
+		}
+
+		if strings.TrimSpace(message) == "" {
+			return nil
+		}
+
+		return tx.InsertResponse(ctx, &model.ComplaintResponse{
+			ComplaintId: complaintId,
+			UserId:      userId,
+			Status:      &to,
+			Message:     message,
+		})
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Complaint set status")
+	}
+
+	return s.get(ctx, complaintId)
+}
+
+func (s ComplaintService) CheckAward(ctx context.Context, tenderId string) error {
+	isFrozen, err := s.complaintRep.AwardFrozen(ctx, tenderId)
+	if err != nil {
+		return err
+	}
+	if isFrozen {
+		return domain.ErrAwardFrozen
+	}
+
+	return nil
+}
+
+func (s ComplaintService) checkBuyer(ctx context.Context, username, complaintId string) (*model.Complaint, string, error) {
+	complaint, err := s.complaintRep.GetComplaint(ctx, complaintId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	isBuyer, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, complaint.TenderId)
+	if err != nil {
+		return nil, "", err
+	}
+	if !isBuyer {
+		return nil, "", domain.ErrUserNotResponsible
+	}
+
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return complaint, userId, nil
+}
+
+func (s ComplaintService) get(ctx context.Context, complaintId string) (*domain.ComplaintResp, error) {
+	complaint, err := s.complaintRep.GetComplaint(ctx, complaintId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Complaint get")
+	}
+
+	resp, err := s.complaintsResp(ctx, []model.Complaint{*complaint})
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp[0], nil
+}
+
+func (s ComplaintService) complaintsResp(ctx context.Context, complaints []model.Complaint) ([]domain.ComplaintResp, error) {
+	ids := make([]string, len(complaints))
+	for i := range complaints {
+		ids[i] = complaints[i].Id
+	}
+
+	responses, err := s.complaintRep.GetResponses(ctx, ids)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Complaint get responses")
+	}
+
+	byComplaint := make(map[string][]domain.ComplaintResponseResp, len(complaints))
+	for _, response := range responses {
+		byComplaint[response.ComplaintId] = append(byComplaint[response.ComplaintId], domain.ComplaintResponseResp{
+			Id:        response.Id,
+			UserId:    response.UserId,
+			Status:    response.Status,
+			Message:   response.Message,
+			CreatedAt: response.CreatedAt,
+		})
+	}
+
+	resp := make([]domain.ComplaintResp, len(complaints))
+	for i, complaint := range complaints {
+		complaintResponses := byComplaint[complaint.Id]
+		if complaintResponses == nil {
+			complaintResponses = make([]domain.ComplaintResponseResp, 0)
+		}
+
+		resp[i] = domain.ComplaintResp{
+			Id:          complaint.Id,
+			TenderId:    complaint.TenderId,
+			BidId:       complaint.BidId,
+			UserId:      complaint.UserId,
+			Reason:      complaint.Reason,
+			FreezeAward: complaint.FreezeAward,
+			Status:      complaint.Status,
+			ResolvedAt:  complaint.ResolvedAt,
+			CreatedAt:   complaint.CreatedAt,
+			Responses:   complaintResponses,
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"context"
+	"github.com/pkg/errors"
+)
+
+type ContractRep interface {
+	GetContract(ctx context.Context, tenderId string) (*model.Contract, error)
+	SignContract(ctx context.Context, tenderId string, side model.ContractSide, userId string) error
+}
+
+// ContractService shows the contracts awarded by the decisions to the both parties
+// and collects their signatures.
+type ContractService struct {
+	contractRep ContractRep
+	tenderRep   TenderRep
+	bidRep      BidRep
+	complaints  Complaints
+}
+
+func NewContractService(contractRep ContractRep, tenderRep TenderRep, bidRep BidRep, complaints Complaints) ContractService {
+	return ContractService{contractRep: contractRep, tenderRep: tenderRep, bidRep: bidRep, complaints: complaints}
+}
+
+func (s ContractService) Get(ctx context.Context, username, tenderId string) (*domain.ContractResp, error) {
+	contract, err := s.contractRep.GetContract(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	isBuyer, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isBuyer {
+		isSupplier, err := s.bidRep.UsernameCanManage(ctx, username, contract.BidId)
+		if err != nil {
+			return nil, err
+		}
+		if !isSupplier {
+			return nil, domain.ErrUserNotResponsible
+		}
+	}
+
+	return contractResp(contract), nil
+}
+
+// Sign signs the contract on behalf of the side: the tender organization employees sign
+// as the buyer, the bid managers as the supplier. The contract is not signed while the award is frozen.
+func (s ContractService) Sign(ctx context.Context, username, tenderId, side string) (*domain.ContractResp, error) {
+	contract, err := s.contractRep.GetContract(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	var canSign bool
+	switch model.ContractSide(side) {
+	case model.ContractSideBuyer:
+		canSign, err = s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	case model.ContractSideSupplier:
+		canSign, err = s.bidRep.UsernameCanManage(ctx, username, contract.BidId)
+	default:
+		return nil, domain.ErrInvalidContractSide
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !canSign {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	if err = s.complaints.CheckAward(ctx, tenderId); err != nil {
+		return nil, err
+	}
+
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.contractRep.SignContract(ctx, tenderId, model.ContractSide(side), userId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Contract sign")
+	}
+
+	contract, err = s.contractRep.GetContract(ctx, tenderId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Contract get")
+	}
+
+	return contractResp(contract), nil
+}
+
+func contractResp(contract *model.Contract) *domain.ContractResp {
+	status := model.ContractStatusPending
+	if contract.BuyerSignedAt != nil && contract.SupplierSignedAt != nil {
+		status = model.ContractStatusSigned
+	}
+
+	return &domain.ContractResp{
+		Id:                     contract.Id,
+		TenderId:               contract.TenderId,
+		BidId:                  contract.BidId,
+		BidVersion:             contract.BidVersion,
+		Price:                  contract.Price,
+		Status:                 status,
+		BuyerOrganizationId:    contract.BuyerOrganizationId,
+		SupplierType:           contract.SupplierType,
+		SupplierId:             contract.SupplierId,
+		SupplierOrganizationId: contract.SupplierOrganizationId,
+		BuyerSignerId:          contract.BuyerSignerId,
+		BuyerSignedAt:          contract.BuyerSignedAt,
+		SupplierSignerId:       contract.SupplierSignerId,
+		SupplierSignedAt:       contract.SupplierSignedAt,
+		CreatedAt:              contract.CreatedAt,
+	}
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/utils"
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type EnvelopeRep interface {
+	GetOrCreateKey(ctx context.Context, tenderId string, encryptedKey []byte) ([]byte, error)
+	GetKey(ctx context.Context, tenderId string) ([]byte, error)
+	GetOpening(ctx context.Context, tenderId string) (*model.EnvelopeOpening, error)
+	DueOpenings(ctx context.Context) ([]string, error)
+}
+
+type OpenTransaction interface {
+	InsertOpening(ctx context.Context, opening *model.EnvelopeOpening) error
+	GetKey(ctx context.Context, tenderId string) ([]byte, error)
+	GetSealedContents(ctx context.Context, tenderId string) ([]model.BidContent, error)
+	UnsealContent(ctx context.Context, content *model.BidContent) error
+}
+
+// sealedContent is the plaintext of the encrypted bid content.
+type sealedContent struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// EnvelopeService keeps the bid contents of the sealed tenders encrypted with a per-tender key
+// until the bid deadline or an explicit opening. The tender keys are encrypted with the master key.
+type EnvelopeService struct {
+	envelopeRep EnvelopeRep
+	tenderRep   TenderRep
+	txMan       TxManager
+	master      utils.Cipher
+}
+
+func NewEnvelopeService(envelopeRep EnvelopeRep, tenderRep TenderRep, txMan TxManager, master utils.Cipher) EnvelopeService {
+	return EnvelopeService{envelopeRep: envelopeRep, tenderRep: tenderRep, txMan: txMan, master: master}
+}
+
+// CheckSubmissionsOpen rejects the changes of the sealed tender bids after the deadline or the opening.
+func (s EnvelopeService) CheckSubmissionsOpen(ctx context.Context, tender *model.Tender) error {
+	if !tender.Sealed {
+		return nil
+	}
+
+	if tender.BidDeadline != nil && !tender.BidDeadline.After(time.Now()) {
+		return domain.ErrSubmissionsClosed
+	}
+
+	_, err := s.envelopeRep.GetOpening(ctx, tender.Id)
+	if err == nil {
+		return domain.ErrSubmissionsClosed
+	}
+	if !errors.Is(err, domain.ErrEnvelopesNotOpened) {
+		return err
+	}
+
+	return nil
+}
+
+// CheckOpened requires the envelopes of the sealed tender to be opened, the bids
+// can not be decided on or scored while their contents are sealed.
+func (s EnvelopeService) CheckOpened(ctx context.Context, tender *model.Tender) error {
+	if !tender.Sealed {
+		return nil
+	}
+
+	_, err := s.envelopeRep.GetOpening(ctx, tender.Id)
+	return err
+}
+
+func (s EnvelopeService) SealContent(ctx context.Context, tender *model.Tender, name, description string) ([]byte, error) {
+	if err := s.CheckSubmissionsOpen(ctx, tender); err != nil {
+		return nil, err
+	}
+
+	encryptedKey, err := s.envelopeRep.GetKey(ctx, tender.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if encryptedKey == nil {
+		key, err := utils.NewKey()
+		if err != nil {
+			return nil, err
+		}
+
+		encryptedKey, err = s.master.Encrypt(key)
+		if err != nil {
+			return nil, errors.WithMessage(err, "Service.Envelope encrypt tender key")
+		}
+
+		encryptedKey, err = s.envelopeRep.GetOrCreateKey(ctx, tender.Id, encryptedKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tenderCipher, err := s.tenderCipher(encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := json.Marshal(sealedContent{Name: name, Description: description})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Envelope marshal content")
+	}
+
+	return tenderCipher.Encrypt(plaintext)
+}
+
+func (s EnvelopeService) UnsealContent(ctx context.Context, tenderId string, sealed []byte) (string, string, error) {
+	encryptedKey, err := s.envelopeRep.GetKey(ctx, tenderId)
+	if err != nil {
+		return "", "", err
+	}
+
+	tenderCipher, err := s.tenderCipher(encryptedKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	content, err := unseal(tenderCipher, sealed)
+	if err != nil {
+		return "", "", err
+	}
+
+	return content.Name, content.Description, nil
+}
+
+// Open reveals the bid contents of the sealed tender before the deadline.
+func (s EnvelopeService) Open(ctx context.Context, username, tenderId string) (*domain.EnvelopeOpeningResp, error) {
+	isResponsible, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	tender, err := s.tenderRep.GetById(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !tender.Sealed {
+		return nil, domain.ErrTenderNotSealed
+	}
+
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.open(ctx, tenderId, &userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetOpening(ctx, username, tenderId)
+}
+
+// OpenDue opens the envelopes of the sealed tenders whose bid deadline has passed.
+func (s EnvelopeService) OpenDue(ctx context.Context) ([]string, error) {
+	ids, err := s.envelopeRep.DueOpenings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opened := make([]string, 0, len(ids))
+	for _, tenderId := range ids {
+		err = s.open(ctx, tenderId, nil)
+		if errors.Is(err, domain.ErrEnvelopesOpened) {
+			continue
+		}
+		if err != nil {
+			return opened, err
+		}
+		opened = append(opened, tenderId)
+	}
+
+	return opened, nil
+}
+
+// open records the opening and decrypts all the stored versions of the tender bids in one transaction.
+func (s EnvelopeService) open(ctx context.Context, tenderId string, userId *string) error {
+	return s.txMan.OpenTransaction(ctx, func(ctx context.Context, tx OpenTransaction) error {
+		err := tx.InsertOpening(ctx, &model.EnvelopeOpening{TenderId: tenderId, UserId: userId})
+		if err != nil {
+			return err
+		}
+
+		encryptedKey, err := tx.GetKey(ctx, tenderId)
+		if err != nil || encryptedKey == nil {
+			return err
+		}
+
+		tenderCipher, err := s.tenderCipher(encryptedKey)
+		if err != nil {
+			return err
+		}
+
+		contents, err := tx.GetSealedContents(ctx, tenderId)
+		if err != nil {
+			return err
+		}
+
+		for i := range contents {
+			content, err := unseal(tenderCipher, contents[i].SealedContent)
+			if err != nil {
+				return err
+			}
+
+			contents[i].Name = content.Name
+			contents[i].Description = content.Description
+			if err = tx.UnsealContent(ctx, &contents[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s EnvelopeService) GetOpening(ctx context.Context, username, tenderId string) (*domain.EnvelopeOpeningResp, error) {
+	canAccess, err := s.tenderRep.UsernameCanAccess(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, domain.ErrTenderNotAccessible
+	}
+
+	opening, err := s.envelopeRep.GetOpening(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.EnvelopeOpeningResp{
+		TenderId:  opening.TenderId,
+		OpenedBy:  opening.UserId,
+		Automatic: opening.UserId == nil,
+		OpenedAt:  opening.CreatedAt,
+	}, nil
+}
+
+func (s EnvelopeService) tenderCipher(encryptedKey []byte) (utils.Cipher, error) {
+	key, err := s.master.Decrypt(encryptedKey)
+	if err != nil {
+		return utils.Cipher{}, errors.WithMessage(err, "Service.Envelope decrypt tender key")
+	}
+
+	return utils.NewCipher(key)
+}
+
+func unseal(tenderCipher utils.Cipher, sealed []byte) (*sealedContent, error) {
+	plaintext, err := tenderCipher.Decrypt(sealed)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Envelope decrypt content")
+	}
+
+	var content sealedContent
+	if err = json.Unmarshal(plaintext, &content); err != nil {
+		return nil, errors.WithMessage(err, "Service.Envelope unmarshal content")
+	}
+
+	return &content, nil
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"context"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+const maxScore = 10
+
+type CriterionRep interface {
+	ReplaceCriteria(ctx context.Context, tenderId string, criteria []model.Criterion) error
+	GetCriteria(ctx context.Context, tenderId string) ([]model.Criterion, error)
+	HasScores(ctx context.Context, tenderId string) (bool, error)
+	SaveScores(ctx context.Context, bidId, userId string, scores []model.BidScore) error
+	GetScores(ctx context.Context, bidId, userId string) ([]model.BidScore, error)
+	Ranking(ctx context.Context, tenderId string) ([]model.BidRanking, error)
+}
+
+type EvaluationService struct {
+	criterionRep CriterionRep
+	tenderRep    TenderRep
+	bidRep       BidRep
+	envelopes    Envelopes
+	txMan        TxManager
+}
+
+func NewEvaluationService(criterionRep CriterionRep, tenderRep TenderRep, bidRep BidRep, envelopes Envelopes, txMan TxManager) EvaluationService {
+	return EvaluationService{criterionRep: criterionRep, tenderRep: tenderRep, bidRep: bidRep, envelopes: envelopes, txMan: txMan}
+}
+
+func (s EvaluationService) SetCriteria(ctx context.Context, username, tenderId string, req *domain.SetCriteriaReq) ([]domain.CriterionResp, error) {
+	isResponsible, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	criteria := make([]model.Criterion, len(req.Criteria))
+	names := make(map[string]struct{}, len(req.Criteria))
+	for i, criterion := range req.Criteria {
+		if _, duplicate := names[criterion.Name]; duplicate || strings.TrimSpace(criterion.Name) == "" || criterion.Weight <= 0 {
+			return nil, domain.ErrInvalidCriteria
+		}
+		names[criterion.Name] = struct{}{}
+		criteria[i] = model.Criterion{Name: criterion.Name, Weight: criterion.Weight}
+	}
+
+	status, err := s.tenderRep.GetTenderStatus(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if status == string(model.TenderStatusClosed) {
+		return nil, domain.ErrCriteriaLocked
+	}
+
+	hasScores, err := s.criterionRep.HasScores(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if hasScores {
+		return nil, domain.ErrCriteriaLocked
+	}
+
+	err = s.criterionRep.ReplaceCriteria(ctx, tenderId, criteria)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Evaluation set criteria")
+	}
+
+	return s.criteriaResp(ctx, tenderId)
+}
+
+func (s EvaluationService) GetCriteria(ctx context.Context, username, tenderId string) ([]domain.CriterionResp, error) {
+	canAccess, err := s.tenderRep.UsernameCanAccess(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, domain.ErrTenderNotAccessible
+	}
+
+	return s.criteriaResp(ctx, tenderId)
+}
+
+// Score saves the scores of the evaluator for a published bid. Scores may be revised
+// until the tender is closed, the bids of the sealed tender are scored after the opening.
+func (s EvaluationService) Score(ctx context.Context, username, bidId string, req *domain.ScoreBidReq) ([]domain.BidScoreResp, error) {
+	tenderId, err := s.bidRep.GetTenderId(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+
+	isResponsible, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	tender, err := s.tenderRep.GetById(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if tender.Status == model.TenderStatusClosed {
+		return nil, domain.ErrScoresLocked
+	}
+	if err = s.envelopes.CheckOpened(ctx, tender); err != nil {
+		return nil, err
+	}
+
+	bidStatus, err := s.bidRep.GetBidStatus(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+	if bidStatus != string(model.BidStatusPublished) {
+		return nil, domain.ErrBidIsNotPublished
+	}
+
+	criteria, err := s.criterionRep.GetCriteria(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	criterionIds := make(map[string]struct{}, len(criteria))
+	for i := range criteria {
+		criterionIds[criteria[i].Id] = struct{}{}
+	}
+
+	scores := make([]model.BidScore, len(req.Scores))
+	scored := make(map[string]struct{}, len(req.Scores))
+	for i, score := range req.Scores {
+		if _, ok := criterionIds[score.CriterionId]; !ok {
+			return nil, domain.ErrCriterionDoesNotExist
+		}
+		if _, duplicate := scored[score.CriterionId]; duplicate || score.Score < 0 || score.Score > maxScore {
+			return nil, domain.ErrInvalidScore
+		}
+		scored[score.CriterionId] = struct{}{}
+		scores[i] = model.BidScore{CriterionId: score.CriterionId, Score: score.Score, Comment: score.Comment}
+	}
+
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.criterionRep.SaveScores(ctx, bidId, userId, scores)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Evaluation score")
+	}
+
+	saved, err := s.criterionRep.GetScores(ctx, bidId, userId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Evaluation get scores")
+	}
+
+	resp := make([]domain.BidScoreResp, len(saved))
+	for i := range saved {
+		resp[i] = domain.BidScoreResp{
+			CriterionId: saved[i].CriterionId,
+			Score:       saved[i].Score,
+			Comment:     saved[i].Comment,
+			UpdatedAt:   saved[i].UpdatedAt,
+		}
+	}
+
+	return resp, nil
+}
+
+func (s EvaluationService) Ranking(ctx context.Context, username, tenderId string) ([]domain.BidRankingResp, error) {
+	isResponsible, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	ranking, err := s.criterionRep.Ranking(ctx, tenderId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Evaluation ranking")
+	}
+
+	tender, err := s.tenderRep.GetById(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	pseudonyms, err := authorPseudonyms(ctx, s.bidRep, tender)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]domain.BidRankingResp, len(ranking))
+	for i := range ranking {
+		resp[i] = domain.BidRankingResp{
+			Rank:           i + 1,
+			BidId:          ranking[i].BidId,
+			Name:           ranking[i].Name,
+			Status:         ranking[i].Status,
+			AuthorType:     ranking[i].AuthorType,
+			AuthorId:       pseudonymize(pseudonyms, ranking[i].AuthorId),
+			Score:          ranking[i].Score,
+			ScoredCriteria: ranking[i].ScoredCriteria,
+		}
+	}
+
+	return resp, nil
+}
+
+// Shortlist closes the technical stage of the two-stage tender and admits the given
+// published bids to the commercial stage.
+func (s EvaluationService) Shortlist(ctx context.Context, username, tenderId string, req *domain.ShortlistReq) ([]domain.ShortlistedBidResp, error) {
+	isResponsible, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	tender, err := s.tenderRep.GetById(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !tender.TwoStage {
+		return nil, domain.ErrTenderNotTwoStage
+	}
+
+	bidIds := make(map[string]struct{}, len(req.BidIds))
+	for _, bidId := range req.BidIds {
+		if _, duplicate := bidIds[bidId]; duplicate {
+			return nil, domain.ErrInvalidShortlist
+		}
+		bidIds[bidId] = struct{}{}
+	}
+
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txMan.ShortlistTransaction(ctx, func(ctx context.Context, tx ShortlistTransaction) error {
+		err := tx.SetStage(ctx, tenderId, model.TenderStageTechnical, model.TenderStageCommercial)
+		if err != nil {
+			return err
+		}
+
+		num, err := tx.Shortlist(ctx, tenderId, req.BidIds, userId)
+		if err != nil {
+			return err
+		}
+		if num != int64(len(req.BidIds)) {
+			return domain.ErrInvalidShortlist
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Evaluation shortlist")
+	}
+
+	return s.shortlistResp(ctx, tenderId)
+}
+
+func (s EvaluationService) GetShortlist(ctx context.Context, username, tenderId string) ([]domain.ShortlistedBidResp, error) {
+	canAccess, err := s.tenderRep.UsernameCanAccess(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, domain.ErrTenderNotAccessible
+	}
+
+	return s.shortlistResp(ctx, tenderId)
+}
+
+func (s EvaluationService) shortlistResp(ctx context.Context, tenderId string) ([]domain.ShortlistedBidResp, error) {
+	shortlist, err := s.bidRep.GetShortlist(ctx, tenderId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Evaluation get shortlist")
+	}
+
+	resp := make([]domain.ShortlistedBidResp, len(shortlist))
+	for i := range shortlist {
+		resp[i] = domain.ShortlistedBidResp{
+			BidId:         shortlist[i].BidId,
+			UserId:        shortlist[i].UserId,
+			ShortlistedAt: shortlist[i].CreatedAt,
+		}
+	}
+
+	return resp, nil
+}
+
+func (s EvaluationService) criteriaResp(ctx context.Context, tenderId string) ([]domain.CriterionResp, error) {
+	criteria, err := s.criterionRep.GetCriteria(ctx, tenderId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Evaluation get criteria")
+	}
+
+	resp := make([]domain.CriterionResp, len(criteria))
+	for i := range criteria {
+		resp[i] = domain.CriterionResp{
+			Id:        criteria[i].Id,
+			Name:      criteria[i].Name,
+			Weight:    criteria[i].Weight,
+			CreatedAt: criteria[i].CreatedAt,
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"context"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+type FrameworkRep interface {
+	InsertCallOffOrder(ctx context.Context, order *model.CallOffOrder) (string, error)
+	GetCallOffOrder(ctx context.Context, orderId string) (*model.CallOffOrder, error)
+	GetCallOffOrders(ctx context.Context, tenderId string) ([]model.CallOffOrder, error)
+}
+
+// FrameworkService issues the call-off orders of the framework agreements
+// to their approved suppliers without running a new tender.
+type FrameworkService struct {
+	frameworkRep FrameworkRep
+	tenderRep    TenderRep
+	bidRep       BidRep
+	complaints   Complaints
+}
+
+func NewFrameworkService(frameworkRep FrameworkRep, tenderRep TenderRep, bidRep BidRep, complaints Complaints) FrameworkService {
+	return FrameworkService{frameworkRep: frameworkRep, tenderRep: tenderRep, bidRep: bidRep, complaints: complaints}
+}
+
+// IssueOrder issues the call-off order to the approved supplier. The canceled framework
+// issues no more orders and an open complaint freezes them as it freezes the award.
+func (s FrameworkService) IssueOrder(ctx context.Context, username, tenderId string, req *domain.CallOffOrderReq) (*domain.CallOffOrderResp, error) {
+	if strings.TrimSpace(req.Description) == "" || req.Amount <= 0 || req.BidId == "" {
+		return nil, domain.ErrInvalidCallOffOrder
+	}
+
+	tender, err := s.tenderRep.GetById(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !tender.Framework {
+		return nil, domain.ErrNotFramework
+	}
+
+	isResponsible, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	bid, err := s.bidRep.GetById(ctx, req.BidId)
+	if err != nil {
+		return nil, err
+	}
+	if bid.TenderId != tenderId || bid.Status != model.BidStatusApproved {
+		return nil, domain.ErrInvalidCallOffOrder
+	}
+
+	_, err = s.tenderRep.GetCancellation(ctx, tenderId)
+	if err == nil {
+		return nil, domain.ErrTenderCanceled
+	}
+	if !errors.Is(err, domain.ErrTenderNotCanceled) {
+		return nil, err
+	}
+
+	if err = s.complaints.CheckAward(ctx, tenderId); err != nil {
+		return nil, err
+	}
+
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	orderId, err := s.frameworkRep.InsertCallOffOrder(ctx, &model.CallOffOrder{
+		TenderId:     tenderId,
+		BidId:        req.BidId,
+		UserId:       userId,
+		Description:  req.Description,
+		Amount:       req.Amount,
+		DeliveryDate: req.DeliveryDate,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Framework issue order")
+	}
+
+	order, err := s.frameworkRep.GetCallOffOrder(ctx, orderId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Framework get order")
+	}
+
+	resp := callOffOrderResp(order)
+	return &resp, nil
+}
+
+// GetOrders lists all the call-off orders to the tender organization
+// and the orders issued to the own bids to the suppliers.
+func (s FrameworkService) GetOrders(ctx context.Context, username, tenderId string) ([]domain.CallOffOrderResp, error) {
+	tender, err := s.tenderRep.GetById(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !tender.Framework {
+		return nil, domain.ErrNotFramework
+	}
+
+	isResponsible, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.frameworkRep.GetCallOffOrders(ctx, tenderId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Framework get orders")
+	}
+
+	resp := make([]domain.CallOffOrderResp, 0, len(orders))
+	canManage := make(map[string]bool)
+	for i := range orders {
+		if !isResponsible {
+			isSupplier, ok := canManage[orders[i].BidId]
+			if !ok {
+				isSupplier, err = s.bidRep.UsernameCanManage(ctx, username, orders[i].BidId)
+				if err != nil {
+					return nil, err
+				}
+				canManage[orders[i].BidId] = isSupplier
+			}
+			if !isSupplier {
+				continue
+			}
+		}
+		resp = append(resp, callOffOrderResp(&orders[i]))
+	}
+
+	return resp, nil
+}
+
+func callOffOrderResp(order *model.CallOffOrder) domain.CallOffOrderResp {
+	return domain.CallOffOrderResp{
+		Id:           order.Id,
+		TenderId:     order.TenderId,
+		BidId:        order.BidId,
+		UserId:       order.UserId,
+		Description:  order.Description,
+		Amount:       order.Amount,
+		DeliveryDate: order.DeliveryDate,
+		CreatedAt:    order.CreatedAt,
+	}
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/log"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type InboxRep interface {
+	InsertNotifications(ctx context.Context, eventIds []int64) error
+	GetNotifications(ctx context.Context, userId string, unreadOnly bool, offset, limit int) ([]model.InboxNotification, error)
+	UnreadCount(ctx context.Context, userId string) (int, error)
+	MarkRead(ctx context.Context, userId, notificationId string) error
+	MarkAllRead(ctx context.Context, userId string, ids []string) (int64, error)
+	DeleteOlderThan(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// InboxService is the in-app inbox of the employees fed by the tender and bid events.
+type InboxService struct {
+	inboxRep  InboxRep
+	tenderRep TenderRep
+}
+
+func NewInboxService(inboxRep InboxRep, tenderRep TenderRep) InboxService {
+	return InboxService{inboxRep: inboxRep, tenderRep: tenderRep}
+}
+
+func (s InboxService) Get(ctx context.Context, username string, unreadOnly bool, offset, limit int) ([]domain.InboxNotificationResp, error) {
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := s.inboxRep.GetNotifications(ctx, userId, unreadOnly, offset, limit)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Inbox get notifications")
+	}
+
+	resp := make([]domain.InboxNotificationResp, 0, len(notifications))
+	for i := range notifications {
+		resp = append(resp, inboxNotificationResp(&notifications[i]))
+	}
+
+	return resp, nil
+}
+
+func (s InboxService) UnreadCount(ctx context.Context, username string) (*domain.InboxUnreadResp, error) {
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.inboxRep.UnreadCount(ctx, userId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Inbox unread count")
+	}
+
+	return &domain.InboxUnreadResp{Unread: unread}, nil
+}
+
+func (s InboxService) MarkRead(ctx context.Context, username, notificationId string) error {
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	return s.inboxRep.MarkRead(ctx, userId, notificationId)
+}
+
+// MarkAllRead marks the notifications with the ids read, all the unread ones without the ids.
+func (s InboxService) MarkAllRead(ctx context.Context, username string, ids []string) (*domain.InboxReadResp, error) {
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	read, err := s.inboxRep.MarkAllRead(ctx, userId, ids)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Inbox mark all read")
+	}
+
+	return &domain.InboxReadResp{Read: int(read)}, nil
+}
+
+// inboxNotificationResp hides the bidder of the anonymized tender who made the change.
+func inboxNotificationResp(notification *model.InboxNotification) domain.InboxNotificationResp {
+	username := notification.Payload.Username
+	if notification.Anonymized && bidderEvent(notification.Type) {
+		username = ""
+	}
+
+	return domain.InboxNotificationResp{
+		Id:         notification.Id,
+		Type:       notification.Type,
+		TenderId:   notification.TenderId,
+		TenderName: notification.TenderName,
+		BidId:      notification.BidId,
+		Status:     notification.Payload.Status,
+		Feedback:   notification.Payload.Feedback,
+		Username:   username,
+		Read:       notification.ReadAt != nil,
+		CreatedAt:  notification.CreatedAt,
+	}
+}
+
+// InboxSink puts the outbox events to the inboxes of the employees they concern.
+type InboxSink struct {
+	inboxRep InboxRep
+}
+
+func NewInboxSink(inboxRep InboxRep) InboxSink {
+	return InboxSink{inboxRep: inboxRep}
+}
+
+func (s InboxSink) Name() string {
+	return "inbox"
+}
+
+func (s InboxSink) Deliver(ctx context.Context, events []model.OutboxEvent) error {
+	eventIds := make([]int64, len(events))
+	for i := range events {
+		eventIds[i] = events[i].Id
+	}
+
+	return s.inboxRep.InsertNotifications(ctx, eventIds)
+}
+
+// InboxCleaner periodically removes the notifications older than the retention.
+type InboxCleaner struct {
+	logger    log.Logger
+	inboxRep  InboxRep
+	interval  time.Duration
+	retention time.Duration
+}
+
+func NewInboxCleaner(logger log.Logger, inboxRep InboxRep, interval, retention time.Duration) InboxCleaner {
+	return InboxCleaner{logger: logger, inboxRep: inboxRep, interval: interval, retention: retention}
+}
+
+func (c InboxCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := c.inboxRep.DeleteOlderThan(ctx, c.retention)
+			if err != nil && ctx.Err() == nil {
+				c.logger.Error(ctx, fmt.Sprintf("clean inbox: %v", err))
+			}
+			if deleted > 0 {
+				c.logger.Info(ctx, fmt.Sprintf("removed %d expired notifications", deleted))
+			}
+		}
+	}
+}
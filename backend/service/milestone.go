@@ -0,0 +1,236 @@
+package service
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"context"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+type MilestoneRep interface {
+	GetContract(ctx context.Context, tenderId string) (*model.Contract, error)
+	InsertMilestone(ctx context.Context, milestone *model.Milestone) (string, error)
+	GetMilestone(ctx context.Context, milestoneId string) (*model.Milestone, error)
+	GetMilestones(ctx context.Context, tenderId string) ([]model.Milestone, error)
+	OverdueMilestones(ctx context.Context, orgId string) ([]model.Milestone, error)
+	SetMilestoneStatus(ctx context.Context, milestoneId string, from []model.MilestoneStatus,
+		to model.MilestoneStatus, disputeReason *string) error
+}
+
+// MilestoneService tracks the deliveries of the awarded contracts: the buyer plans the milestones,
+// the supplier delivers them and the buyer accepts or disputes the deliveries.
+type MilestoneService struct {
+	milestoneRep MilestoneRep
+	tenderRep    TenderRep
+	bidRep       BidRep
+	orgRep       OrganizationRep
+	complaints   Complaints
+}
+
+func NewMilestoneService(milestoneRep MilestoneRep, tenderRep TenderRep, bidRep BidRep, orgRep OrganizationRep,
+	complaints Complaints) MilestoneService {
+	return MilestoneService{milestoneRep: milestoneRep, tenderRep: tenderRep, bidRep: bidRep, orgRep: orgRep, complaints: complaints}
+}
+
+func (s MilestoneService) Create(ctx context.Context, username, tenderId string, req *domain.CreateMilestoneReq) (*domain.MilestoneResp, error) {
+	if strings.TrimSpace(req.Description) == "" || req.Amount < 0 || req.DueDate.IsZero() {
+		return nil, domain.ErrInvalidMilestone
+	}
+
+	contract, err := s.milestoneRep.GetContract(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.checkBuyer(ctx, username, tenderId); err != nil {
+		return nil, err
+	}
+
+	if err = s.complaints.CheckAward(ctx, tenderId); err != nil {
+		return nil, err
+	}
+
+	milestoneId, err := s.milestoneRep.InsertMilestone(ctx, &model.Milestone{
+		ContractId:  contract.Id,
+		Description: req.Description,
+		DueDate:     req.DueDate,
+		Amount:      req.Amount,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Milestone create")
+	}
+
+	return s.get(ctx, milestoneId)
+}
+
+func (s MilestoneService) GetByTenderId(ctx context.Context, username, tenderId string) ([]domain.MilestoneResp, error) {
+	contract, err := s.milestoneRep.GetContract(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	isBuyer, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isBuyer {
+		if err = s.checkSupplier(ctx, username, contract.BidId); err != nil {
+			return nil, err
+		}
+	}
+
+	milestones, err := s.milestoneRep.GetMilestones(ctx, tenderId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Milestone get milestones")
+	}
+
+	return milestonesResp(milestones), nil
+}
+
+// Deliver marks the milestone delivered, the disputed milestones are delivered again.
+func (s MilestoneService) Deliver(ctx context.Context, username, milestoneId string) (*domain.MilestoneResp, error) {
+	milestone, err := s.milestoneRep.GetMilestone(ctx, milestoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := s.milestoneRep.GetContract(ctx, milestone.TenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.checkSupplier(ctx, username, contract.BidId); err != nil {
+		return nil, err
+	}
+
+	if err = s.complaints.CheckAward(ctx, milestone.TenderId); err != nil {
+		return nil, err
+	}
+
+	return s.setStatus(ctx, milestoneId,
+		[]model.MilestoneStatus{model.MilestoneStatusPending, model.MilestoneStatusDisputed}, model.MilestoneStatusDelivered, nil)
+}
+
+func (s MilestoneService) Accept(ctx context.Context, username, milestoneId string) (*domain.MilestoneResp, error) {
+	milestone, err := s.milestoneRep.GetMilestone(ctx, milestoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.checkBuyer(ctx, username, milestone.TenderId); err != nil {
+		return nil, err
+	}
+
+	if err = s.complaints.CheckAward(ctx, milestone.TenderId); err != nil {
+		return nil, err
+	}
+
+	return s.setStatus(ctx, milestoneId,
+		[]model.MilestoneStatus{model.MilestoneStatusDelivered}, model.MilestoneStatusAccepted, nil)
+}
+
+func (s MilestoneService) Dispute(ctx context.Context, username, milestoneId, reason string) (*domain.MilestoneResp, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, domain.ErrDisputeReasonRequired
+	}
+
+	milestone, err := s.milestoneRep.GetMilestone(ctx, milestoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.checkBuyer(ctx, username, milestone.TenderId); err != nil {
+		return nil, err
+	}
+
+	return s.setStatus(ctx, milestoneId,
+		[]model.MilestoneStatus{model.MilestoneStatusDelivered}, model.MilestoneStatusDisputed, &reason)
+}
+
+// Overdue lists the overdue milestones of the contracts where the organization is a party.
+func (s MilestoneService) Overdue(ctx context.Context, username, orgId string) ([]domain.MilestoneResp, error) {
+	isResponsible, err := s.orgRep.EmpBelongs(ctx, username, orgId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	milestones, err := s.milestoneRep.OverdueMilestones(ctx, orgId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Milestone overdue milestones")
+	}
+
+	return milestonesResp(milestones), nil
+}
+
+func (s MilestoneService) setStatus(ctx context.Context, milestoneId string, from []model.MilestoneStatus,
+	to model.MilestoneStatus, disputeReason *string) (*domain.MilestoneResp, error) {
+	err := s.milestoneRep.SetMilestoneStatus(ctx, milestoneId, from, to, disputeReason)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Milestone set status")
+	}
+
+	return s.get(ctx, milestoneId)
+}
+
+func (s MilestoneService) checkBuyer(ctx context.Context, username, tenderId string) error {
+	isBuyer, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return err
+	}
+	if !isBuyer {
+		return domain.ErrUserNotResponsible
+	}
+
+	return nil
+}
+
+func (s MilestoneService) checkSupplier(ctx context.Context, username, bidId string) error {
+	isSupplier, err := s.bidRep.UsernameCanManage(ctx, username, bidId)
+	if err != nil {
+		return err
+	}
+	if !isSupplier {
+		return domain.ErrUserNotResponsible
+	}
+
+	return nil
+}
+
+func (s MilestoneService) get(ctx context.Context, milestoneId string) (*domain.MilestoneResp, error) {
+	milestone, err := s.milestoneRep.GetMilestone(ctx, milestoneId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Milestone get")
+	}
+
+	resp := milestoneResp(milestone)
+	return &resp, nil
+}
+
+func milestonesResp(milestones []model.Milestone) []domain.MilestoneResp {
+	resp := make([]domain.MilestoneResp, len(milestones))
+	for i := range milestones {
+		resp[i] = milestoneResp(&milestones[i])
+	}
+
+	return resp
+}
+
+func milestoneResp(milestone *model.Milestone) domain.MilestoneResp {
+	return domain.MilestoneResp{
+		Id:            milestone.Id,
+		ContractId:    milestone.ContractId,
+		TenderId:      milestone.TenderId,
+		Description:   milestone.Description,
+		DueDate:       milestone.DueDate,
+		Amount:        milestone.Amount,
+		Status:        milestone.Status,
+		DeliveredAt:   milestone.DeliveredAt,
+		ResolvedAt:    milestone.ResolvedAt,
+		DisputeReason: milestone.DisputeReason,
+		CreatedAt:     milestone.CreatedAt,
+	}
+}
@@ -0,0 +1,383 @@
+package service
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/log"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/pkg/errors"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+type NotificationRep interface {
+	GetPreference(ctx context.Context, userId string) (*model.NotificationPreference, error)
+	UpsertPreference(ctx context.Context, preference *model.NotificationPreference) error
+	GetEmailRecipients(ctx context.Context, eventIds []int64, types []model.EventType) ([]model.EmailRecipient, error)
+	InsertEmails(ctx context.Context, emails []model.EmailNotification) error
+	ClaimDueEmails(ctx context.Context, limit int, lease time.Duration) ([]model.EmailNotification, error)
+	MarkEmailSent(ctx context.Context, emailId string) error
+	MarkEmailFailed(ctx context.Context, emailId, lastError string, retryAfter time.Duration, dead bool) error
+}
+
+// NotificationService manages how the users are notified about their bids.
+type NotificationService struct {
+	notificationRep NotificationRep
+	tenderRep       TenderRep
+}
+
+func NewNotificationService(notificationRep NotificationRep, tenderRep TenderRep) NotificationService {
+	return NotificationService{notificationRep: notificationRep, tenderRep: tenderRep}
+}
+
+func (s NotificationService) GetPreference(ctx context.Context, username string) (*domain.NotificationPreferenceResp, error) {
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	preference, err := s.notificationRep.GetPreference(ctx, userId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Notification get preference")
+	}
+
+	return preferenceResp(preference), nil
+}
+
+// SetPreference replaces the preference of the user, an empty email stops the emails and
+// an empty locale is the default Russian one.
+func (s NotificationService) SetPreference(ctx context.Context, username string,
+	req *domain.NotificationPreferenceReq) (*domain.NotificationPreferenceResp, error) {
+	preference := &model.NotificationPreference{Locale: req.Locale, EmailOptOut: req.EmailOptOut}
+	if preference.Locale == "" {
+		preference.Locale = model.LocaleRu
+	}
+	if preference.Locale != model.LocaleRu && preference.Locale != model.LocaleEn {
+		return nil, domain.ErrInvalidNotificationPref
+	}
+
+	if req.Email != nil && strings.TrimSpace(*req.Email) != "" {
+		address, err := mail.ParseAddress(*req.Email)
+		if err != nil || address.Address != *req.Email {
+			return nil, domain.ErrInvalidNotificationPref
+		}
+		preference.Email = &address.Address
+	}
+
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	preference.UserId = userId
+
+	if err = s.notificationRep.UpsertPreference(ctx, preference); err != nil {
+		return nil, errors.WithMessage(err, "Service.Notification set preference")
+	}
+
+	return preferenceResp(preference), nil
+}
+
+func preferenceResp(preference *model.NotificationPreference) *domain.NotificationPreferenceResp {
+	return &domain.NotificationPreferenceResp{
+		Email:       preference.Email,
+		Locale:      preference.Locale,
+		EmailOptOut: preference.EmailOptOut,
+	}
+}
+
+type emailTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+func newEmailTemplate(subject, body string) emailTemplate {
+	return emailTemplate{
+		subject: template.Must(template.New("subject").Parse(subject)),
+		body:    template.Must(template.New("body").Parse(body)),
+	}
+}
+
+// emailData is the data of the email templates.
+type emailData struct {
+	TenderName string
+	Feedback   string
+	Username   string
+}
+
+// emailTemplates are the emails sent to the bid authors by the locale and the event type.
+var emailTemplates = map[model.Locale]map[model.EventType]emailTemplate{
+	model.LocaleRu: {
+		model.EventBidApproved: newEmailTemplate(
+			`Ваше предложение по тендеру «{{.TenderName}}» принято`,
+			"Здравствуйте!\n\nВаше предложение по тендеру «{{.TenderName}}» принято. "+
+				"Организатор тендера свяжется с вами для заключения контракта.\n"),
+		model.EventBidRejected: newEmailTemplate(
+			`Ваше предложение по тендеру «{{.TenderName}}» отклонено`,
+			"Здравствуйте!\n\nК сожалению, ваше предложение по тендеру «{{.TenderName}}» отклонено.\n"),
+		model.EventBidFeedback: newEmailTemplate(
+			`Отзыв на ваше предложение по тендеру «{{.TenderName}}»`,
+			"Здравствуйте!\n\nНа ваше предложение по тендеру «{{.TenderName}}» получен отзыв от {{.Username}}:\n\n{{.Feedback}}\n"),
+	},
+	model.LocaleEn: {
+		model.EventBidApproved: newEmailTemplate(
+			`Your bid for the tender "{{.TenderName}}" is approved`,
+			"Hello!\n\nYour bid for the tender \"{{.TenderName}}\" is approved. "+
+				"The tender organizer will contact you to sign the contract.\n"),
+		model.EventBidRejected: newEmailTemplate(
+			`Your bid for the tender "{{.TenderName}}" is rejected`,
+			"Hello!\n\nUnfortunately, your bid for the tender \"{{.TenderName}}\" is rejected.\n"),
+		model.EventBidFeedback: newEmailTemplate(
+			`Feedback on your bid for the tender "{{.TenderName}}"`,
+			"Hello!\n\n{{.Username}} left feedback on your bid for the tender \"{{.TenderName}}\":\n\n{{.Feedback}}\n"),
+	},
+}
+
+// emailEventTypes are the events the bid authors are emailed about.
+var emailEventTypes = []model.EventType{model.EventBidApproved, model.EventBidRejected, model.EventBidFeedback}
+
+func renderEmail(recipient *model.EmailRecipient) (*model.EmailNotification, error) {
+	tmpl, ok := emailTemplates[recipient.Locale][recipient.Type]
+	if !ok {
+		return nil, errors.Errorf("no %s email template for %s", recipient.Locale, recipient.Type)
+	}
+
+	data := emailData{
+		TenderName: recipient.TenderName,
+		Feedback:   recipient.Payload.Feedback,
+		Username:   recipient.Payload.Username,
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return nil, errors.WithMessage(err, "render email subject")
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return nil, errors.WithMessage(err, "render email body")
+	}
+
+	return &model.EmailNotification{
+		UserId:  recipient.UserId,
+		EventId: recipient.Id,
+		Email:   recipient.Email,
+		Subject: subject.String(),
+		Body:    body.String(),
+	}, nil
+}
+
+// EmailSink renders the emails of the outbox events to the bid authors and queues them,
+// the EmailDispatcher sends the queued emails.
+type EmailSink struct {
+	notificationRep NotificationRep
+}
+
+func NewEmailSink(notificationRep NotificationRep) EmailSink {
+	return EmailSink{notificationRep: notificationRep}
+}
+
+func (s EmailSink) Name() string {
+	return "email"
+}
+
+func (s EmailSink) Deliver(ctx context.Context, events []model.OutboxEvent) error {
+	eventIds := make([]int64, len(events))
+	for i := range events {
+		eventIds[i] = events[i].Id
+	}
+
+	recipients, err := s.notificationRep.GetEmailRecipients(ctx, eventIds, emailEventTypes)
+	if err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	emails := make([]model.EmailNotification, 0, len(recipients))
+	for i := range recipients {
+		email, err := renderEmail(&recipients[i])
+		if err != nil {
+			return err
+		}
+		emails = append(emails, *email)
+	}
+
+	return s.notificationRep.InsertEmails(ctx, emails)
+}
+
+// Mailer sends a plain text email.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// smtpTimeout limits a single SMTP session.
+const smtpTimeout = 30 * time.Second
+
+// SMTPMailer sends the emails through the SMTP server, upgrading the connection to TLS
+// when the server supports it. The credentials are optional.
+type SMTPMailer struct {
+	address string
+	from    string
+	auth    smtp.Auth
+}
+
+func NewSMTPMailer(address, username, password, from string) SMTPMailer {
+	mailer := SMTPMailer{address: address, from: from}
+	if username != "" {
+		host, _, _ := net.SplitHostPort(address)
+		mailer.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return mailer
+}
+
+func (m SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	host, _, err := net.SplitHostPort(m.address)
+	if err != nil {
+		return errors.WithMessage(err, "parse smtp address")
+	}
+
+	dialer := net.Dialer{Timeout: smtpTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", m.address)
+	if err != nil {
+		return errors.WithMessage(err, "dial smtp server")
+	}
+	_ = conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return errors.WithMessage(err, "smtp greeting")
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}); err != nil {
+			return errors.WithMessage(err, "smtp starttls")
+		}
+	}
+	if ok, _ := client.Extension("AUTH"); ok && m.auth != nil {
+		if err = client.Auth(m.auth); err != nil {
+			return errors.WithMessage(err, "smtp auth")
+		}
+	}
+
+	if err = client.Mail(m.from); err != nil {
+		return errors.WithMessage(err, "smtp mail from")
+	}
+	if err = client.Rcpt(to); err != nil {
+		return errors.WithMessage(err, "smtp rcpt to")
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return errors.WithMessage(err, "smtp data")
+	}
+	if _, err = writer.Write(m.message(to, subject, body)); err != nil {
+		return errors.WithMessage(err, "smtp write message")
+	}
+	if err = writer.Close(); err != nil {
+		return errors.WithMessage(err, "smtp message")
+	}
+
+	return client.Quit()
+}
+
+func (m SMTPMailer) message(to, subject, body string) []byte {
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", m.from)
+	fmt.Fprintf(&message, "To: %s\r\n", to)
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	message.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	message.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	return message.Bytes()
+}
+
+const (
+	// emailBatchSize is the number of emails claimed at once.
+	emailBatchSize = 50
+	// emailLease keeps a claimed email from the other dispatchers while it is sent,
+	// it outlasts the batch sent one by one through the SMTP server that does not respond.
+	emailLease = emailBatchSize*smtpTimeout + time.Minute
+)
+
+// EmailDispatcher sends the due emails. A failed email is retried with the
+// exponential backoff and is dead after the last attempt.
+type EmailDispatcher struct {
+	logger          log.Logger
+	notificationRep NotificationRep
+	mailer          Mailer
+	interval        time.Duration
+	backoff         time.Duration
+	maxAttempts     int
+}
+
+func NewEmailDispatcher(logger log.Logger, notificationRep NotificationRep, mailer Mailer, interval, backoff time.Duration,
+	maxAttempts int) EmailDispatcher {
+	return EmailDispatcher{
+		logger:          logger,
+		notificationRep: notificationRep,
+		mailer:          mailer,
+		interval:        interval,
+		backoff:         backoff,
+		maxAttempts:     maxAttempts,
+	}
+}
+
+func (d EmailDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.DispatchDue(ctx); err != nil && ctx.Err() == nil {
+				d.logger.Error(ctx, fmt.Sprintf("dispatch emails: %v", err))
+			}
+		}
+	}
+}
+
+// DispatchDue sends the emails due for an attempt and records the outcome of each of them.
+func (d EmailDispatcher) DispatchDue(ctx context.Context) error {
+	emails, err := d.notificationRep.ClaimDueEmails(ctx, emailBatchSize, emailLease)
+	if err != nil {
+		return err
+	}
+
+	for i := range emails {
+		if err = d.dispatch(ctx, &emails[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d EmailDispatcher) dispatch(ctx context.Context, email *model.EmailNotification) error {
+	err := d.mailer.Send(ctx, email.Email, email.Subject, email.Body)
+	if err == nil {
+		return d.notificationRep.MarkEmailSent(ctx, email.Id)
+	}
+
+	attempt := email.Attempts + 1
+	dead := attempt >= d.maxAttempts
+	if dead {
+		d.logger.Warn(log.AddKeyVal(ctx, "emailId", email.Id), fmt.Sprintf("email is dead after %d attempts: %v", attempt, err))
+	}
+
+	return d.notificationRep.MarkEmailFailed(ctx, email.Id, err.Error(), retryAfter(d.backoff, attempt), dead)
+}
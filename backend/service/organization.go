@@ -10,6 +10,8 @@ type OrganizationRep interface {
 	Insert(ctx context.Context, org *model.Organization) (string, error)
 	MakeResponsible(ctx context.Context, empId, orgId string) (string, error)
 	EmpBelongs(ctx context.Context, employeeUsername, orgId string) (bool, error)
+	EmpExists(ctx context.Context, empId string) (bool, error)
+	EmpOrgIds(ctx context.Context, empId string) ([]string, error)
 }
 
 type OrganizationService struct {
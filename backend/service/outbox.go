@@ -0,0 +1,172 @@
+package service
+
+import (
+	"avito/db/model"
+	"avito/log"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// EventWriter writes the domain events to the outbox in the transaction of the change.
+type EventWriter interface {
+	InsertEvent(ctx context.Context, event *model.OutboxEvent) error
+}
+
+type OutboxRep interface {
+	GetEventsAfter(ctx context.Context, eventId int64, limit int) ([]model.OutboxEvent, error)
+	GetOffset(ctx context.Context, sink string) (int64, error)
+	SetOffset(ctx context.Context, sink string, eventId int64) error
+}
+
+// EventSink receives the outbox events in the order they were written. The delivery is
+// at least once: after a failure the batch is delivered again from the stored offset.
+type EventSink interface {
+	Name() string
+	Deliver(ctx context.Context, events []model.OutboxEvent) error
+}
+
+// outboxBatchSize is the number of events delivered to a sink at once.
+const outboxBatchSize = 100
+
+// OutboxDispatcher periodically delivers the new outbox events to every sink
+// and stores the offset of the sink after each delivered batch.
+type OutboxDispatcher struct {
+	logger    log.Logger
+	outboxRep OutboxRep
+	sinks     []EventSink
+	interval  time.Duration
+}
+
+func NewOutboxDispatcher(logger log.Logger, outboxRep OutboxRep, interval time.Duration, sinks ...EventSink) OutboxDispatcher {
+	return OutboxDispatcher{logger: logger, outboxRep: outboxRep, sinks: sinks, interval: interval}
+}
+
+func (d OutboxDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.DispatchDue(ctx); err != nil && ctx.Err() == nil {
+				d.logger.Error(ctx, fmt.Sprintf("dispatch outbox events: %v", err))
+			}
+		}
+	}
+}
+
+// DispatchDue delivers the pending events to all the sinks, a failing sink does not hold the others.
+func (d OutboxDispatcher) DispatchDue(ctx context.Context) error {
+	var dispatchErr error
+	for _, sink := range d.sinks {
+		if err := d.dispatch(ctx, sink); err != nil && dispatchErr == nil {
+			dispatchErr = err
+		}
+	}
+
+	return dispatchErr
+}
+
+func (d OutboxDispatcher) dispatch(ctx context.Context, sink EventSink) error {
+	offset, err := d.outboxRep.GetOffset(ctx, sink.Name())
+	if err != nil {
+		return err
+	}
+
+	for {
+		events, err := d.outboxRep.GetEventsAfter(ctx, offset, outboxBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		if err = sink.Deliver(ctx, events); err != nil {
+			return errors.WithMessage(err, "deliver events to "+sink.Name())
+		}
+
+		offset = events[len(events)-1].Id
+		if err = d.outboxRep.SetOffset(ctx, sink.Name(), offset); err != nil {
+			return err
+		}
+
+		if len(events) < outboxBatchSize {
+			return nil
+		}
+	}
+}
+
+// LogSink writes the events to the application log.
+type LogSink struct {
+	logger log.Logger
+}
+
+func NewLogSink(logger log.Logger) LogSink {
+	return LogSink{logger: logger}
+}
+
+func (s LogSink) Name() string {
+	return "log"
+}
+
+func (s LogSink) Deliver(ctx context.Context, events []model.OutboxEvent) error {
+	for _, event := range events {
+		eventCtx := log.AddKeyVal(ctx, "tenderId", event.TenderId)
+		if event.BidId != nil {
+			eventCtx = log.AddKeyVal(eventCtx, "bidId", *event.BidId)
+		}
+		s.logger.Info(eventCtx, fmt.Sprintf("outbox event %d %s", event.Id, event.Type))
+	}
+
+	return nil
+}
+
+// maxRetryDelay caps the delay between the delivery attempts.
+const maxRetryDelay = time.Hour
+
+// retryAfter doubles the delay after the first failed attempt with every next one up to maxRetryDelay.
+func retryAfter(backoff time.Duration, attempt int) time.Duration {
+	delay := backoff
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
+
+func tenderEvent(eventType model.EventType, tenderId string, payload model.EventPayload) *model.OutboxEvent {
+	return &model.OutboxEvent{Type: eventType, TenderId: tenderId, Payload: payload}
+}
+
+func bidEvent(eventType model.EventType, tenderId, bidId string, payload model.EventPayload) *model.OutboxEvent {
+	return &model.OutboxEvent{Type: eventType, TenderId: tenderId, BidId: &bidId, Payload: payload}
+}
+
+// bidderEvent reports whether the event is made by the bid author,
+// the username of such an event reveals the bidder of the anonymized tender.
+func bidderEvent(eventType model.EventType) bool {
+	switch eventType {
+	case model.EventBidSubmitted, model.EventBidEdited, model.EventBidWithdrawn, model.EventBidResubmitted:
+		return true
+	default:
+		return false
+	}
+}
+
+// tenderStatusEvent is the event of the tender moved to the status, none for a tender moved back to Created.
+func tenderStatusEvent(tenderId string, status model.TenderStatus, username string) *model.OutboxEvent {
+	payload := model.EventPayload{Status: string(status), Username: username}
+	switch status {
+	case model.TenderStatusPublished:
+		return tenderEvent(model.EventTenderPublished, tenderId, payload)
+	case model.TenderStatusClosed:
+		return tenderEvent(model.EventTenderClosed, tenderId, payload)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"context"
+	"github.com/pkg/errors"
+	"strings"
+	"unicode/utf8"
+)
+
+type SavedSearchRep interface {
+	Insert(ctx context.Context, search *model.SavedSearch) (*model.SavedSearch, error)
+	GetSearches(ctx context.Context, userId string) ([]model.SavedSearch, error)
+	Delete(ctx context.Context, userId, searchId string) error
+	InsertAlerts(ctx context.Context, eventIds []int64) error
+	GetAlerts(ctx context.Context, userId, searchId string, offset, limit int) ([]model.TenderAlert, error)
+}
+
+// SavedSearchService keeps the search criteria of the suppliers and the alerts about
+// the published tenders matching them.
+type SavedSearchService struct {
+	savedSearchRep SavedSearchRep
+	tenderRep      TenderRep
+	serviceTypeRep ServiceTypeRep
+}
+
+func NewSavedSearchService(savedSearchRep SavedSearchRep, tenderRep TenderRep, serviceTypeRep ServiceTypeRep) SavedSearchService {
+	return SavedSearchService{savedSearchRep: savedSearchRep, tenderRep: tenderRep, serviceTypeRep: serviceTypeRep}
+}
+
+func (s SavedSearchService) Create(ctx context.Context, username string, req *domain.CreateSavedSearchReq) (*domain.SavedSearchResp, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > 100 {
+		return nil, domain.ErrInvalidSavedSearch
+	}
+	if (req.BudgetMin != nil && *req.BudgetMin < 0) || (req.BudgetMax != nil && *req.BudgetMax < 0) ||
+		(req.BudgetMin != nil && req.BudgetMax != nil && *req.BudgetMin > *req.BudgetMax) {
+		return nil, domain.ErrInvalidSavedSearch
+	}
+
+	keywords := make(model.SearchTerms, len(req.Keywords))
+	for i := range req.Keywords {
+		keywords[i] = strings.TrimSpace(req.Keywords[i])
+		if keywords[i] == "" {
+			return nil, domain.ErrInvalidSavedSearch
+		}
+	}
+
+	serviceTypes := make(model.SearchTerms, len(req.ServiceTypes))
+	for i := range req.ServiceTypes {
+		exists, err := s.serviceTypeRep.Exists(ctx, string(req.ServiceTypes[i]))
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, domain.ErrInvalidServiceType
+		}
+		serviceTypes[i] = string(req.ServiceTypes[i])
+	}
+
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := s.savedSearchRep.Insert(ctx, &model.SavedSearch{
+		UserId:         userId,
+		Name:           name,
+		ServiceTypes:   serviceTypes,
+		Keywords:       keywords,
+		BudgetMin:      req.BudgetMin,
+		BudgetMax:      req.BudgetMax,
+		OrganizationId: req.OrganizationId,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.SavedSearch insert")
+	}
+
+	return savedSearchResp(search), nil
+}
+
+func (s SavedSearchService) GetSearches(ctx context.Context, username string) ([]domain.SavedSearchResp, error) {
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	searches, err := s.savedSearchRep.GetSearches(ctx, userId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.SavedSearch get searches")
+	}
+
+	resp := make([]domain.SavedSearchResp, 0, len(searches))
+	for i := range searches {
+		resp = append(resp, *savedSearchResp(&searches[i]))
+	}
+
+	return resp, nil
+}
+
+func (s SavedSearchService) Delete(ctx context.Context, username, searchId string) error {
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	return s.savedSearchRep.Delete(ctx, userId, searchId)
+}
+
+// GetAlerts returns the alerts of the user, only the ones of the search if the search id is given.
+func (s SavedSearchService) GetAlerts(ctx context.Context, username, searchId string, offset, limit int) ([]domain.TenderAlertResp, error) {
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := s.savedSearchRep.GetAlerts(ctx, userId, searchId, offset, limit)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.SavedSearch get alerts")
+	}
+
+	resp := make([]domain.TenderAlertResp, len(alerts))
+	for i := range alerts {
+		resp[i] = domain.TenderAlertResp{
+			Id:             alerts[i].Id,
+			SearchId:       alerts[i].SavedSearchId,
+			SearchName:     alerts[i].SearchName,
+			TenderId:       alerts[i].TenderId,
+			TenderName:     alerts[i].TenderName,
+			ServiceType:    alerts[i].ServiceType,
+			Budget:         alerts[i].Budget,
+			OrganizationId: alerts[i].OrganizationId,
+			CreatedAt:      alerts[i].CreatedAt,
+		}
+	}
+
+	return resp, nil
+}
+
+func savedSearchResp(search *model.SavedSearch) *domain.SavedSearchResp {
+	serviceTypes := make([]model.TenderServiceType, len(search.ServiceTypes))
+	for i := range search.ServiceTypes {
+		serviceTypes[i] = model.TenderServiceType(search.ServiceTypes[i])
+	}
+
+	return &domain.SavedSearchResp{
+		Id:             search.Id,
+		Name:           search.Name,
+		ServiceTypes:   serviceTypes,
+		Keywords:       search.Keywords,
+		BudgetMin:      search.BudgetMin,
+		BudgetMax:      search.BudgetMax,
+		OrganizationId: search.OrganizationId,
+		CreatedAt:      search.CreatedAt,
+	}
+}
+
+// SavedSearchSink matches the published tenders against the saved searches.
+type SavedSearchSink struct {
+	savedSearchRep SavedSearchRep
+}
+
+func NewSavedSearchSink(savedSearchRep SavedSearchRep) SavedSearchSink {
+	return SavedSearchSink{savedSearchRep: savedSearchRep}
+}
+
+func (s SavedSearchSink) Name() string {
+	return "saved_search"
+}
+
+func (s SavedSearchSink) Deliver(ctx context.Context, events []model.OutboxEvent) error {
+	eventIds := make([]int64, 0, len(events))
+	for i := range events {
+		if events[i].Type == model.EventTenderPublished {
+			eventIds = append(eventIds, events[i].Id)
+		}
+	}
+	if len(eventIds) == 0 {
+		return nil
+	}
+
+	return s.savedSearchRep.InsertAlerts(ctx, eventIds)
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"avito/log"
+	"context"
+	"fmt"
+	"time"
+)
+
+type ScheduleRep interface {
+	PublishScheduled(ctx context.Context) ([]string, error)
+	CloseScheduled(ctx context.Context) ([]string, error)
+}
+
+type EnvelopeOpener interface {
+	OpenDue(ctx context.Context) ([]string, error)
+}
+
+// TenderScheduler periodically applies the status transitions that
+// responsible employees scheduled for their tenders and opens the envelopes
+// of the sealed tenders after the bid deadline.
+type TenderScheduler struct {
+	logger      log.Logger
+	scheduleRep ScheduleRep
+	opener      EnvelopeOpener
+	interval    time.Duration
+}
+
+func NewTenderScheduler(logger log.Logger, scheduleRep ScheduleRep, opener EnvelopeOpener, interval time.Duration) TenderScheduler {
+	return TenderScheduler{logger: logger, scheduleRep: scheduleRep, opener: opener, interval: interval}
+}
+
+func (s TenderScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.ApplyDue(ctx); err != nil && ctx.Err() == nil {
+				s.logger.Error(ctx, fmt.Sprintf("apply scheduled tender transitions: %v", err))
+			}
+		}
+	}
+}
+
+func (s TenderScheduler) ApplyDue(ctx context.Context) error {
+	published, err := s.scheduleRep.PublishScheduled(ctx)
+	if err != nil {
+		return err
+	}
+	for _, tenderId := range published {
+		s.logger.Info(log.AddKeyVal(ctx, "tenderId", tenderId), "scheduled tender published")
+	}
+
+	closed, err := s.scheduleRep.CloseScheduled(ctx)
+	if err != nil {
+		return err
+	}
+	for _, tenderId := range closed {
+		s.logger.Info(log.AddKeyVal(ctx, "tenderId", tenderId), "scheduled tender closed")
+	}
+
+	opened, err := s.opener.OpenDue(ctx)
+	for _, tenderId := range opened {
+		s.logger.Info(log.AddKeyVal(ctx, "tenderId", tenderId), "sealed tender envelopes opened")
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"context"
+	"github.com/pkg/errors"
+)
+
+type ServiceTypeRep interface {
+	GetAll(ctx context.Context) ([]model.ServiceType, error)
+	GetByCode(ctx context.Context, code string) (*model.ServiceType, error)
+	Exists(ctx context.Context, code string) (bool, error)
+	Insert(ctx context.Context, serviceType *model.ServiceType) error
+	Update(ctx context.Context, serviceType *model.ServiceType) error
+	Delete(ctx context.Context, code string) error
+}
+
+// ServiceTypeService manages the service type catalogue. The catalogue is readable by everyone,
+// changes are allowed to the administrators listed in the config only.
+type ServiceTypeService struct {
+	serviceTypeRep ServiceTypeRep
+	admins         map[string]struct{}
+}
+
+func NewServiceTypeService(serviceTypeRep ServiceTypeRep, admins []string) ServiceTypeService {
+	adminSet := make(map[string]struct{}, len(admins))
+	for _, admin := range admins {
+		adminSet[admin] = struct{}{}
+	}
+
+	return ServiceTypeService{serviceTypeRep: serviceTypeRep, admins: adminSet}
+}
+
+func (s ServiceTypeService) GetAll(ctx context.Context) ([]domain.ServiceTypeResp, error) {
+	serviceTypes, err := s.serviceTypeRep.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]domain.ServiceTypeResp, len(serviceTypes))
+	for i := range serviceTypes {
+		resp[i] = *serviceTypeResp(&serviceTypes[i])
+	}
+
+	return resp, nil
+}
+
+func (s ServiceTypeService) Create(ctx context.Context, username string, req *domain.CreateServiceTypeReq) (*domain.ServiceTypeResp, error) {
+	if !s.isAdmin(username) {
+		return nil, domain.ErrNotAdmin
+	}
+
+	err := s.serviceTypeRep.Insert(ctx, &model.ServiceType{
+		Code:       req.Code,
+		Name:       req.Name,
+		ParentCode: req.ParentCode,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.ServiceType insert")
+	}
+
+	return s.get(ctx, req.Code)
+}
+
+func (s ServiceTypeService) Update(ctx context.Context, username, code string, req *domain.UpdateServiceTypeReq) (*domain.ServiceTypeResp, error) {
+	if !s.isAdmin(username) {
+		return nil, domain.ErrNotAdmin
+	}
+
+	_, err := s.serviceTypeRep.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.serviceTypeRep.Update(ctx, &model.ServiceType{
+		Code:       code,
+		Name:       req.Name,
+		ParentCode: req.ParentCode,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.ServiceType update")
+	}
+
+	return s.get(ctx, code)
+}
+
+func (s ServiceTypeService) Delete(ctx context.Context, username, code string) error {
+	if !s.isAdmin(username) {
+		return domain.ErrNotAdmin
+	}
+
+	return s.serviceTypeRep.Delete(ctx, code)
+}
+
+func (s ServiceTypeService) get(ctx context.Context, code string) (*domain.ServiceTypeResp, error) {
+	serviceType, err := s.serviceTypeRep.GetByCode(ctx, code)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.ServiceType get")
+	}
+
+	return serviceTypeResp(serviceType), nil
+}
+
+func (s ServiceTypeService) isAdmin(username string) bool {
+	_, ok := s.admins[username]
+	return ok
+}
+
+func serviceTypeResp(serviceType *model.ServiceType) *domain.ServiceTypeResp {
+	return &domain.ServiceTypeResp{
+		Code:       serviceType.Code,
+		Name:       serviceType.Name,
+		ParentCode: serviceType.ParentCode,
+		CreatedAt:  serviceType.CreatedAt,
+	}
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/log"
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+type StreamRep interface {
+	LastEventId(ctx context.Context) (int64, error)
+	GetStreamEvents(ctx context.Context, afterId, untilId int64, limit int) ([]model.StreamEvent, error)
+}
+
+const (
+	// streamSubscriberBuffer is the number of the events kept for a slow subscriber, the events
+	// must not be lost so the subscriber is dropped when it is full and resumes on reconnect.
+	streamSubscriberBuffer = 64
+	// streamBatchSize is the number of events read from the outbox at once.
+	streamBatchSize = 100
+)
+
+type streamSubscriber struct {
+	filter  func(event *model.StreamEvent) bool
+	afterId int64
+	updates chan domain.StreamEventResp
+}
+
+// StreamHub follows the outbox and delivers the new events to the subscribers of the streams.
+type StreamHub struct {
+	logger    log.Logger
+	streamRep StreamRep
+	interval  time.Duration
+
+	mu          sync.Mutex
+	lastId      int64
+	subscribers map[*streamSubscriber]struct{}
+}
+
+func NewStreamHub(logger log.Logger, streamRep StreamRep, interval time.Duration) *StreamHub {
+	return &StreamHub{
+		logger:      logger,
+		streamRep:   streamRep,
+		interval:    interval,
+		subscribers: make(map[*streamSubscriber]struct{}),
+	}
+}
+
+// Init starts following the outbox from its last event.
+func (h *StreamHub) Init(ctx context.Context) error {
+	lastId, err := h.streamRep.LastEventId(ctx)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	h.lastId = lastId
+	h.mu.Unlock()
+
+	return nil
+}
+
+func (h *StreamHub) Run(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := h.poll(ctx); err != nil && ctx.Err() == nil {
+				h.logger.Error(ctx, fmt.Sprintf("poll stream events: %v", err))
+			}
+		}
+	}
+}
+
+func (h *StreamHub) poll(ctx context.Context) error {
+	for {
+		h.mu.Lock()
+		lastId := h.lastId
+		h.mu.Unlock()
+
+		events, err := h.streamRep.GetStreamEvents(ctx, lastId, math.MaxInt64, streamBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		h.publish(events)
+
+		if len(events) < streamBatchSize {
+			return nil
+		}
+	}
+}
+
+func (h *StreamHub) publish(events []model.StreamEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i := range events {
+		for subscriber := range h.subscribers {
+			if events[i].Id <= subscriber.afterId || !subscriber.filter(&events[i]) {
+				continue
+			}
+
+			select {
+			case subscriber.updates <- streamEventResp(&events[i]):
+			default:
+				close(subscriber.updates)
+				delete(h.subscribers, subscriber)
+			}
+		}
+	}
+
+	h.lastId = events[len(events)-1].Id
+}
+
+// Subscribe delivers the events matching the filter with the ids greater than afterId. It returns
+// the id of the last event published by the hub, the earlier events are left to the caller.
+func (h *StreamHub) Subscribe(filter func(event *model.StreamEvent) bool, afterId int64) (<-chan domain.StreamEventResp, int64, func()) {
+	subscriber := &streamSubscriber{
+		filter:  filter,
+		afterId: afterId,
+		updates: make(chan domain.StreamEventResp, streamSubscriberBuffer),
+	}
+
+	h.mu.Lock()
+	h.subscribers[subscriber] = struct{}{}
+	lastId := h.lastId
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subscribers, subscriber)
+	}
+
+	return subscriber.updates, lastId, unsubscribe
+}
+
+// StreamService streams the status and version changes of the tenders and the bids
+// to their parties, a reconnected client resumes after the last received event.
+type StreamService struct {
+	hub       *StreamHub
+	streamRep StreamRep
+	tenderRep TenderRep
+	bidRep    BidRep
+	orgRep    OrganizationRep
+}
+
+func NewStreamService(hub *StreamHub, streamRep StreamRep, tenderRep TenderRep, bidRep BidRep, orgRep OrganizationRep) StreamService {
+	return StreamService{hub: hub, streamRep: streamRep, tenderRep: tenderRep, bidRep: bidRep, orgRep: orgRep}
+}
+
+// Subscribe opens the stream of the scope, the events after lastEventId are replayed first.
+func (s StreamService) Subscribe(ctx context.Context, username string, scope domain.StreamScope,
+	lastEventId int64) (*domain.StreamSubscription, error) {
+	filter, err := s.filter(ctx, username, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	updates, hubLastId, unsubscribe := s.hub.Subscribe(filter, lastEventId)
+
+	backlog := make([]domain.StreamEventResp, 0)
+	for afterId := lastEventId; lastEventId > 0 && afterId < hubLastId; {
+		events, err := s.streamRep.GetStreamEvents(ctx, afterId, hubLastId, streamBatchSize)
+		if err != nil {
+			unsubscribe()
+			return nil, err
+		}
+		if len(events) == 0 {
+			break
+		}
+
+		for i := range events {
+			if filter(&events[i]) {
+				backlog = append(backlog, streamEventResp(&events[i]))
+			}
+		}
+		afterId = events[len(events)-1].Id
+	}
+
+	return &domain.StreamSubscription{Backlog: backlog, Updates: updates, Close: unsubscribe}, nil
+}
+
+// filter checks the access to the scope: the tender stream is open to the tender organization and
+// the bidders, who see only their own bids, the organization stream to its employees.
+// Without a tender or an organization the stream has the events of the user bids.
+func (s StreamService) filter(ctx context.Context, username string, scope domain.StreamScope) (func(event *model.StreamEvent) bool, error) {
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	ownBid := func(event *model.StreamEvent) bool {
+		return event.BidAuthorId != nil && *event.BidAuthorId == userId
+	}
+
+	switch {
+	case scope.TenderId != "":
+		isResponsible, err := s.tenderRep.UsernameBelongsToTenderOrg(ctx, username, scope.TenderId)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isResponsible {
+			hasBids, err := s.bidRep.UsernameHasBids(ctx, username, scope.TenderId)
+			if err != nil {
+				return nil, err
+			}
+			if !hasBids {
+				return nil, domain.ErrBidsNotVisible
+			}
+		}
+
+		return func(event *model.StreamEvent) bool {
+			return event.TenderId == scope.TenderId && (isResponsible || event.BidId == nil || ownBid(event))
+		}, nil
+	case scope.OrganizationId != "":
+		isResponsible, err := s.orgRep.EmpBelongs(ctx, username, scope.OrganizationId)
+		if err != nil {
+			return nil, err
+		}
+		if !isResponsible {
+			return nil, domain.ErrUserNotResponsible
+		}
+
+		return func(event *model.StreamEvent) bool {
+			return event.OrganizationId == scope.OrganizationId
+		}, nil
+	}
+
+	return ownBid, nil
+}
+
+func streamEventResp(event *model.StreamEvent) domain.StreamEventResp {
+	return domain.StreamEventResp{
+		Id:            event.Id,
+		Type:          event.Type,
+		TenderId:      event.TenderId,
+		BidId:         event.BidId,
+		Status:        event.Payload.Status,
+		TenderVersion: event.TenderVersion,
+		BidVersion:    event.BidVersion,
+		CreatedAt:     event.CreatedAt,
+	}
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"context"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+type TemplateRep interface {
+	Insert(ctx context.Context, template *model.TenderTemplate) (string, error)
+	Update(ctx context.Context, template *model.TenderTemplate) error
+	GetById(ctx context.Context, templateId string) (*model.TenderTemplate, error)
+	GetByOrganization(ctx context.Context, orgId string) ([]model.TenderTemplate, error)
+	Delete(ctx context.Context, templateId string) error
+}
+
+type TemplateService struct {
+	templateRep TemplateRep
+	orgRep      OrganizationRep
+}
+
+func NewTemplateService(templateRep TemplateRep, orgRep OrganizationRep) TemplateService {
+	return TemplateService{templateRep: templateRep, orgRep: orgRep}
+}
+
+func (s TemplateService) Create(ctx context.Context, username, orgId string, req *domain.TemplateReq) (*domain.TemplateResp, error) {
+	if err := s.checkResponsible(ctx, username, orgId); err != nil {
+		return nil, err
+	}
+
+	template, err := newTemplate(req)
+	if err != nil {
+		return nil, err
+	}
+	template.OrganizationId = orgId
+
+	templateId, err := s.templateRep.Insert(ctx, template)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Template insert template")
+	}
+
+	return s.get(ctx, templateId)
+}
+
+func (s TemplateService) GetByOrganization(ctx context.Context, username, orgId string) ([]domain.TemplateResp, error) {
+	if err := s.checkResponsible(ctx, username, orgId); err != nil {
+		return nil, err
+	}
+
+	templates, err := s.templateRep.GetByOrganization(ctx, orgId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Template get templates")
+	}
+
+	resp := make([]domain.TemplateResp, len(templates))
+	for i := range templates {
+		resp[i] = *templateResp(&templates[i])
+	}
+
+	return resp, nil
+}
+
+func (s TemplateService) GetById(ctx context.Context, username, templateId string) (*domain.TemplateResp, error) {
+	template, err := s.templateRep.GetById(ctx, templateId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.checkResponsible(ctx, username, template.OrganizationId); err != nil {
+		return nil, err
+	}
+
+	return templateResp(template), nil
+}
+
+func (s TemplateService) Update(ctx context.Context, username, templateId string, req *domain.TemplateReq) (*domain.TemplateResp, error) {
+	current, err := s.templateRep.GetById(ctx, templateId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.checkResponsible(ctx, username, current.OrganizationId); err != nil {
+		return nil, err
+	}
+
+	template, err := newTemplate(req)
+	if err != nil {
+		return nil, err
+	}
+	template.Id = templateId
+
+	err = s.templateRep.Update(ctx, template)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Template update template")
+	}
+
+	return s.get(ctx, templateId)
+}
+
+func (s TemplateService) Delete(ctx context.Context, username, templateId string) error {
+	template, err := s.templateRep.GetById(ctx, templateId)
+	if err != nil {
+		return err
+	}
+
+	if err = s.checkResponsible(ctx, username, template.OrganizationId); err != nil {
+		return err
+	}
+
+	return s.templateRep.Delete(ctx, templateId)
+}
+
+func (s TemplateService) get(ctx context.Context, templateId string) (*domain.TemplateResp, error) {
+	template, err := s.templateRep.GetById(ctx, templateId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Template get template")
+	}
+
+	return templateResp(template), nil
+}
+
+func (s TemplateService) checkResponsible(ctx context.Context, username, orgId string) error {
+	isResponsible, err := s.orgRep.EmpBelongs(ctx, username, orgId)
+	if err != nil {
+		return err
+	}
+	if !isResponsible {
+		return domain.ErrUserNotResponsible
+	}
+
+	return nil
+}
+
+func newTemplate(req *domain.TemplateReq) (*model.TenderTemplate, error) {
+	criteria := make([]model.TemplateCriterion, len(req.Criteria))
+	names := make(map[string]struct{}, len(req.Criteria))
+	for i, criterion := range req.Criteria {
+		if _, duplicate := names[criterion.Name]; duplicate || strings.TrimSpace(criterion.Name) == "" || criterion.Weight <= 0 {
+			return nil, domain.ErrInvalidCriteria
+		}
+		names[criterion.Name] = struct{}{}
+		criteria[i] = model.TemplateCriterion{Name: criterion.Name, Weight: criterion.Weight}
+	}
+
+	return &model.TenderTemplate{
+		Name:        req.Name,
+		Description: req.Description,
+		ServiceType: req.ServiceType,
+		Budget:      req.Budget,
+		Criteria:    criteria,
+	}, nil
+}
+
+func templateResp(template *model.TenderTemplate) *domain.TemplateResp {
+	criteria := make([]domain.TemplateCriterionResp, len(template.Criteria))
+	for i := range template.Criteria {
+		criteria[i] = domain.TemplateCriterionResp{
+			Name:   template.Criteria[i].Name,
+			Weight: template.Criteria[i].Weight,
+		}
+	}
+
+	return &domain.TemplateResp{
+		Id:             template.Id,
+		OrganizationId: template.OrganizationId,
+		Name:           template.Name,
+		Description:    template.Description,
+		ServiceType:    template.ServiceType,
+		Budget:         template.Budget,
+		Criteria:       criteria,
+		CreatedAt:      template.CreatedAt,
+		UpdatedAt:      template.UpdatedAt,
+	}
+}
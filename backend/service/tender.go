@@ -6,11 +6,14 @@ import (
 	"avito/domain"
 	"context"
 	"github.com/pkg/errors"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 type TenderRep interface {
 	Insert(ctx context.Context, newTender *model.Tender, authorUsername string) (string, error)
-	GetPublished(ctx context.Context, offset, limit int, types []string) ([]model.Tender, error)
+	GetPublished(ctx context.Context, offset, limit int, types []string, username string) ([]model.Tender, error)
 	GetById(ctx context.Context, tenderId string) (*model.Tender, error)
 	GetByUsername(ctx context.Context, offset, limit int, username string) ([]model.Tender, error)
 	GetTenderStatus(ctx context.Context, tenderId string) (string, error)
@@ -19,24 +22,122 @@ type TenderRep interface {
 	Rollback(ctx context.Context, tenderId string, version int) error
 	AuthorByTenderId(ctx context.Context, tenderId string) (string, error)
 	UsernameBelongsToTenderOrg(ctx context.Context, username, tenderId string) (bool, error)
+	SetVisibility(ctx context.Context, tenderId, visibility string) error
+	SetSchedule(ctx context.Context, tenderId string, publishAt, closeAt *time.Time) error
+	GetCancellation(ctx context.Context, tenderId string) (*model.TenderCancellation, error)
+	GetUserIdByName(ctx context.Context, username string) (string, error)
+	UsernameCanAccess(ctx context.Context, username, tenderId string) (bool, error)
+	UserCanAccess(ctx context.Context, userId, tenderId string) (bool, error)
+	InsertInvitation(ctx context.Context, invitation *model.TenderInvitation) (string, error)
+	GetInvitationById(ctx context.Context, invitationId string) (*model.TenderInvitation, error)
+	GetInvitations(ctx context.Context, tenderId string) ([]model.TenderInvitation, error)
+	DeleteInvitation(ctx context.Context, tenderId, invitationId string) error
 }
 
 type TenderService struct {
-	tenderRep TenderRep
-	orgRep    OrganizationRep
+	tenderRep      TenderRep
+	orgRep         OrganizationRep
+	templateRep    TemplateRep
+	serviceTypeRep ServiceTypeRep
+	txMan          TxManager
 }
 
-func NewTenderService(tenderRep TenderRep, orgRep OrganizationRep) TenderService {
-	return TenderService{tenderRep: tenderRep, orgRep: orgRep}
+func NewTenderService(tenderRep TenderRep,
+	orgRep OrganizationRep,
+	templateRep TemplateRep,
+	serviceTypeRep ServiceTypeRep,
+	txMan TxManager) TenderService {
+	return TenderService{
+		tenderRep:      tenderRep,
+		orgRep:         orgRep,
+		templateRep:    templateRep,
+		serviceTypeRep: serviceTypeRep,
+		txMan:          txMan,
+	}
 }
 
 func (t TenderService) Create(ctx context.Context, tender *domain.CreateTenderReq) (*domain.CreateTenderResp, error) {
+	return t.create(ctx, tender, nil)
+}
+
+// CreateFromTemplate creates a tender pre-filled from the template of the organization.
+// Name, description and budget given in the request override the template ones,
+// the template criteria are copied to the tender.
+func (t TenderService) CreateFromTemplate(ctx context.Context, templateId string, req *domain.CreateTenderFromTemplateReq) (*domain.CreateTenderResp, error) {
+	template, err := t.templateRep.GetById(ctx, templateId)
+	if err != nil {
+		return nil, err
+	}
+
+	tender := &domain.CreateTenderReq{
+		Name:            template.Name,
+		Description:     template.Description,
+		ServiceType:     template.ServiceType,
+		Status:          req.Status,
+		Visibility:      req.Visibility,
+		OrganizationId:  template.OrganizationId,
+		CreatorUsername: req.CreatorUsername,
+		PublishAt:       req.PublishAt,
+		CloseAt:         req.CloseAt,
+		Budget:          template.Budget,
+		Sealed:          req.Sealed,
+		BidDeadline:     req.BidDeadline,
+		TwoStage:        req.TwoStage,
+		Anonymized:      req.Anonymized,
+		Framework:       req.Framework,
+	}
+	if req.Name != "" {
+		tender.Name = req.Name
+	}
+	if req.Description != "" {
+		tender.Description = req.Description
+	}
+	if req.Budget != nil {
+		tender.Budget = req.Budget
+	}
+	if tender.Status == "" {
+		tender.Status = model.TenderStatusCreated
+	}
+
+	return t.create(ctx, tender, template)
+}
+
+func (t TenderService) create(ctx context.Context, tender *domain.CreateTenderReq, template *model.TenderTemplate) (*domain.CreateTenderResp, error) {
 	tenderDom := &model.Tender{
 		Name:           tender.Name,
 		Description:    tender.Description,
 		Status:         tender.Status,
+		Visibility:     tender.Visibility,
 		ServiceType:    tender.ServiceType,
 		OrganizationId: tender.OrganizationId,
+		PublishAt:      tender.PublishAt,
+		CloseAt:        tender.CloseAt,
+		Budget:         tender.Budget,
+		Sealed:         tender.Sealed,
+		BidDeadline:    tender.BidDeadline,
+		TwoStage:       tender.TwoStage,
+		Anonymized:     tender.Anonymized,
+		Framework:      tender.Framework,
+	}
+	if tenderDom.TwoStage {
+		stage := model.TenderStageTechnical
+		tenderDom.Stage = &stage
+	}
+
+	if tenderDom.Visibility == "" {
+		tenderDom.Visibility = model.TenderVisibilityPublic
+	}
+	if !isValidVisibility(string(tenderDom.Visibility)) {
+		return nil, domain.ErrInvalidVisibility
+	}
+	if err := validateSchedule(tenderDom.Status, tenderDom.PublishAt, tenderDom.CloseAt); err != nil {
+		return nil, err
+	}
+	if err := validateSeal(tenderDom.Sealed, tenderDom.BidDeadline, tenderDom.CloseAt); err != nil {
+		return nil, err
+	}
+	if err := t.validateServiceType(ctx, tenderDom.ServiceType); err != nil {
+		return nil, err
 	}
 
 	isResponsible, err := t.orgRep.EmpBelongs(ctx, tender.CreatorUsername, tender.OrganizationId)
@@ -47,7 +148,36 @@ func (t TenderService) Create(ctx context.Context, tender *domain.CreateTenderRe
 		return nil, domain.ErrUserNotResponsible
 	}
 
-	tenderId, err := t.tenderRep.Insert(ctx, tenderDom, tender.CreatorUsername)
+	var tenderId string
+	if template == nil {
+		err = t.txMan.TenderTransaction(ctx, func(ctx context.Context, tx TenderTransaction) error {
+			tenderId, err = tx.Insert(ctx, tenderDom, tender.CreatorUsername)
+			if err != nil {
+				return err
+			}
+
+			return insertCreatedEvents(ctx, tx, tenderId, tenderDom.Status, tender.CreatorUsername)
+		})
+	} else {
+		tenderDom.TemplateId = &template.Id
+		err = t.txMan.TemplateTransaction(ctx, func(ctx context.Context, tx TemplateTransaction) error {
+			tenderId, err = tx.Insert(ctx, tenderDom, tender.CreatorUsername)
+			if err != nil {
+				return err
+			}
+
+			criteria := make([]model.Criterion, len(template.Criteria))
+			for i := range template.Criteria {
+				criteria[i] = model.Criterion{Name: template.Criteria[i].Name, Weight: template.Criteria[i].Weight}
+			}
+
+			if err = tx.ReplaceCriteria(ctx, tenderId, criteria); err != nil {
+				return err
+			}
+
+			return insertCreatedEvents(ctx, tx, tenderId, tenderDom.Status, tender.CreatorUsername)
+		})
+	}
 	if err != nil {
 		return nil, errors.WithMessage(err, "Service.Tender insert tender")
 	}
@@ -62,14 +192,25 @@ func (t TenderService) Create(ctx context.Context, tender *domain.CreateTenderRe
 		Name:        tenderNew.Name,
 		Description: tenderNew.Description,
 		Status:      tenderNew.Status,
+		Visibility:  tenderNew.Visibility,
+		PublishAt:   tenderNew.PublishAt,
+		CloseAt:     tenderNew.CloseAt,
+		Budget:      tenderNew.Budget,
+		TemplateId:  tenderNew.TemplateId,
+		Sealed:      tenderNew.Sealed,
+		BidDeadline: tenderNew.BidDeadline,
+		TwoStage:    tenderNew.TwoStage,
+		Stage:       tenderNew.Stage,
+		Anonymized:  tenderNew.Anonymized,
+		Framework:   tenderNew.Framework,
 		ServiceType: tenderNew.ServiceType,
 		CreatedAt:   tenderNew.CreatedAt,
 		Version:     tenderNew.Version,
 	}, nil
 }
 
-func (t TenderService) GetPublished(ctx context.Context, offset, limit int, types []string) ([]domain.GetTendersResp, error) {
-	tenders, err := t.tenderRep.GetPublished(ctx, offset, limit, types)
+func (t TenderService) GetPublished(ctx context.Context, offset, limit int, types []string, username string) ([]domain.GetTendersResp, error) {
+	tenders, err := t.tenderRep.GetPublished(ctx, offset, limit, types, username)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +222,17 @@ func (t TenderService) GetPublished(ctx context.Context, offset, limit int, type
 			Name:        tenders[i].Name,
 			Description: tenders[i].Description,
 			Status:      tenders[i].Status,
+			Visibility:  tenders[i].Visibility,
+			PublishAt:   tenders[i].PublishAt,
+			CloseAt:     tenders[i].CloseAt,
+			Budget:      tenders[i].Budget,
+			TemplateId:  tenders[i].TemplateId,
+			Sealed:      tenders[i].Sealed,
+			BidDeadline: tenders[i].BidDeadline,
+			TwoStage:    tenders[i].TwoStage,
+			Stage:       tenders[i].Stage,
+			Anonymized:  tenders[i].Anonymized,
+			Framework:   tenders[i].Framework,
 			ServiceType: tenders[i].ServiceType,
 			CreatedAt:   tenders[i].CreatedAt,
 			Version:     tenders[i].Version,
@@ -103,6 +255,17 @@ func (t TenderService) GetByUsername(ctx context.Context, offset, limit int, use
 			Name:        tenders[i].Name,
 			Description: tenders[i].Description,
 			Status:      tenders[i].Status,
+			Visibility:  tenders[i].Visibility,
+			PublishAt:   tenders[i].PublishAt,
+			CloseAt:     tenders[i].CloseAt,
+			Budget:      tenders[i].Budget,
+			TemplateId:  tenders[i].TemplateId,
+			Sealed:      tenders[i].Sealed,
+			BidDeadline: tenders[i].BidDeadline,
+			TwoStage:    tenders[i].TwoStage,
+			Stage:       tenders[i].Stage,
+			Anonymized:  tenders[i].Anonymized,
+			Framework:   tenders[i].Framework,
 			ServiceType: tenders[i].ServiceType,
 			CreatedAt:   tenders[i].CreatedAt,
 			Version:     tenders[i].Version,
@@ -128,6 +291,14 @@ func (t TenderService) GetStatus(ctx context.Context, tenderId, username string)
 		return "", err
 	}
 
+	canAccess, err := t.tenderRep.UsernameCanAccess(ctx, username, tenderId)
+	if err != nil {
+		return "", err
+	}
+	if !canAccess {
+		return "", domain.ErrTenderNotAccessible
+	}
+
 	return status, nil
 }
 
@@ -140,7 +311,27 @@ func (t TenderService) SetStatus(ctx context.Context, tenderId, status, username
 		return nil, domain.ErrUserNotResponsible
 	}
 
-	err = t.tenderRep.SetTenderStatus(ctx, tenderId, status)
+	err = t.txMan.TenderTransaction(ctx, func(ctx context.Context, tx TenderTransaction) error {
+		prevStatus, err := tx.GetTenderStatus(ctx, tenderId)
+		if err != nil {
+			return err
+		}
+
+		if err = tx.SetTenderStatus(ctx, tenderId, status); err != nil {
+			return err
+		}
+
+		if err = clearSchedule(ctx, tx, tenderId, model.TenderStatus(status)); err != nil {
+			return err
+		}
+
+		event := tenderStatusEvent(tenderId, model.TenderStatus(status), username)
+		if event == nil || prevStatus == status {
+			return nil
+		}
+
+		return tx.InsertEvent(ctx, event)
+	})
 
 	if err != nil {
 		return nil, err
@@ -156,6 +347,17 @@ func (t TenderService) SetStatus(ctx context.Context, tenderId, status, username
 		Name:        tenderUpdated.Name,
 		Description: tenderUpdated.Description,
 		Status:      tenderUpdated.Status,
+		Visibility:  tenderUpdated.Visibility,
+		PublishAt:   tenderUpdated.PublishAt,
+		CloseAt:     tenderUpdated.CloseAt,
+		Budget:      tenderUpdated.Budget,
+		TemplateId:  tenderUpdated.TemplateId,
+		Sealed:      tenderUpdated.Sealed,
+		BidDeadline: tenderUpdated.BidDeadline,
+		TwoStage:    tenderUpdated.TwoStage,
+		Stage:       tenderUpdated.Stage,
+		Anonymized:  tenderUpdated.Anonymized,
+		Framework:   tenderUpdated.Framework,
 		CreatedAt:   tenderUpdated.CreatedAt,
 		Version:     tenderUpdated.Version,
 		ServiceType: tenderUpdated.ServiceType,
@@ -180,7 +382,23 @@ func (t TenderService) Edit(ctx context.Context, username, tenderId string, tend
 		return nil, domain.ErrUserNotResponsible
 	}
 
-	err = t.tenderRep.UpdateById(ctx, &tenderEdit)
+	if err = t.validateServiceType(ctx, tenderEdit.ServiceType); err != nil {
+		return nil, err
+	}
+
+	err = t.txMan.TenderTransaction(ctx, func(ctx context.Context, tx TenderTransaction) error {
+		if tender.PublishAt != nil || tender.CloseAt != nil {
+			if err := reschedule(ctx, tx, tenderId, tender.PublishAt, tender.CloseAt, true); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.UpdateById(ctx, &tenderEdit); err != nil {
+			return err
+		}
+
+		return tx.InsertEvent(ctx, tenderEvent(model.EventTenderEdited, tenderId, model.EventPayload{Username: username}))
+	})
 	if err != nil {
 		return nil, errors.WithMessage(err, "Service.Tender edit tender")
 	}
@@ -195,6 +413,17 @@ func (t TenderService) Edit(ctx context.Context, username, tenderId string, tend
 		Name:        tenderUpdated.Name,
 		Description: tenderUpdated.Description,
 		Status:      tenderUpdated.Status,
+		Visibility:  tenderUpdated.Visibility,
+		PublishAt:   tenderUpdated.PublishAt,
+		CloseAt:     tenderUpdated.CloseAt,
+		Budget:      tenderUpdated.Budget,
+		TemplateId:  tenderUpdated.TemplateId,
+		Sealed:      tenderUpdated.Sealed,
+		BidDeadline: tenderUpdated.BidDeadline,
+		TwoStage:    tenderUpdated.TwoStage,
+		Stage:       tenderUpdated.Stage,
+		Anonymized:  tenderUpdated.Anonymized,
+		Framework:   tenderUpdated.Framework,
 		CreatedAt:   tenderUpdated.CreatedAt,
 		Version:     tenderUpdated.Version,
 		ServiceType: tenderUpdated.ServiceType,
@@ -211,7 +440,13 @@ func (t TenderService) Rollback(ctx context.Context, username string, tenderId s
 		return nil, domain.ErrUserNotResponsible
 	}
 
-	err = t.tenderRep.Rollback(ctx, tenderId, version)
+	err = t.txMan.TenderTransaction(ctx, func(ctx context.Context, tx TenderTransaction) error {
+		if err := tx.Rollback(ctx, tenderId, version); err != nil {
+			return err
+		}
+
+		return tx.InsertEvent(ctx, tenderEvent(model.EventTenderEdited, tenderId, model.EventPayload{Username: username}))
+	})
 	if err != nil {
 		return nil, errors.WithMessage(err, "Service.Tender rollback tender")
 	}
@@ -226,6 +461,17 @@ func (t TenderService) Rollback(ctx context.Context, username string, tenderId s
 		Name:        tenderUpdated.Name,
 		Description: tenderUpdated.Description,
 		Status:      tenderUpdated.Status,
+		Visibility:  tenderUpdated.Visibility,
+		PublishAt:   tenderUpdated.PublishAt,
+		CloseAt:     tenderUpdated.CloseAt,
+		Budget:      tenderUpdated.Budget,
+		TemplateId:  tenderUpdated.TemplateId,
+		Sealed:      tenderUpdated.Sealed,
+		BidDeadline: tenderUpdated.BidDeadline,
+		TwoStage:    tenderUpdated.TwoStage,
+		Stage:       tenderUpdated.Stage,
+		Anonymized:  tenderUpdated.Anonymized,
+		Framework:   tenderUpdated.Framework,
 		CreatedAt:   tenderUpdated.CreatedAt,
 		Version:     tenderUpdated.Version,
 		ServiceType: tenderUpdated.ServiceType,
@@ -233,3 +479,420 @@ func (t TenderService) Rollback(ctx context.Context, username string, tenderId s
 
 	return tenderDom, nil
 }
+
+func (t TenderService) SetVisibility(ctx context.Context, tenderId, visibility, username string) (*domain.SetVisibilityTenderResp, error) {
+	if !isValidVisibility(visibility) {
+		return nil, domain.ErrInvalidVisibility
+	}
+
+	isResponsible, err := t.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	err = t.tenderRep.SetVisibility(ctx, tenderId, visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	tenderUpdated, err := t.tenderRep.GetById(ctx, tenderId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Tender set visibility")
+	}
+
+	tenderDom := &domain.SetVisibilityTenderResp{
+		Id:          tenderUpdated.Id,
+		Name:        tenderUpdated.Name,
+		Description: tenderUpdated.Description,
+		Status:      tenderUpdated.Status,
+		Visibility:  tenderUpdated.Visibility,
+		PublishAt:   tenderUpdated.PublishAt,
+		CloseAt:     tenderUpdated.CloseAt,
+		Budget:      tenderUpdated.Budget,
+		TemplateId:  tenderUpdated.TemplateId,
+		Sealed:      tenderUpdated.Sealed,
+		BidDeadline: tenderUpdated.BidDeadline,
+		TwoStage:    tenderUpdated.TwoStage,
+		Stage:       tenderUpdated.Stage,
+		Anonymized:  tenderUpdated.Anonymized,
+		Framework:   tenderUpdated.Framework,
+		CreatedAt:   tenderUpdated.CreatedAt,
+		Version:     tenderUpdated.Version,
+		ServiceType: tenderUpdated.ServiceType,
+	}
+
+	return tenderDom, nil
+}
+
+func (t TenderService) Invite(ctx context.Context, username, tenderId string, req *domain.InviteTenderReq) (*domain.TenderInvitationResp, error) {
+	if (req.OrganizationId == "") == (req.UserId == "") {
+		return nil, domain.ErrInvalidInvitation
+	}
+
+	isResponsible, err := t.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	invitation := &model.TenderInvitation{TenderId: tenderId}
+	if req.OrganizationId != "" {
+		invitation.OrganizationId = &req.OrganizationId
+	} else {
+		invitation.UserId = &req.UserId
+	}
+
+	var invitationId string
+	err = t.txMan.TenderTransaction(ctx, func(ctx context.Context, tx TenderTransaction) error {
+		var err error
+		if invitationId, err = tx.InsertInvitation(ctx, invitation); err != nil {
+			return err
+		}
+
+		return tx.InsertEvent(ctx, tenderEvent(model.EventTenderInvited, tenderId, model.EventPayload{
+			InvitationId: invitationId,
+			Username:     username,
+		}))
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Tender invite")
+	}
+
+	invitation, err = t.tenderRep.GetInvitationById(ctx, invitationId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Tender get invitation")
+	}
+
+	return &domain.TenderInvitationResp{
+		Id:             invitation.Id,
+		TenderId:       invitation.TenderId,
+		OrganizationId: invitation.OrganizationId,
+		UserId:         invitation.UserId,
+		CreatedAt:      invitation.CreatedAt,
+	}, nil
+}
+
+func (t TenderService) GetInvitations(ctx context.Context, username, tenderId string) ([]domain.TenderInvitationResp, error) {
+	isResponsible, err := t.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	invitations, err := t.tenderRep.GetInvitations(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]domain.TenderInvitationResp, len(invitations))
+	for i := range invitations {
+		resp[i] = domain.TenderInvitationResp{
+			Id:             invitations[i].Id,
+			TenderId:       invitations[i].TenderId,
+			OrganizationId: invitations[i].OrganizationId,
+			UserId:         invitations[i].UserId,
+			CreatedAt:      invitations[i].CreatedAt,
+		}
+	}
+
+	return resp, nil
+}
+
+func (t TenderService) RevokeInvitation(ctx context.Context, username, tenderId, invitationId string) error {
+	isResponsible, err := t.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return err
+	}
+	if !isResponsible {
+		return domain.ErrUserNotResponsible
+	}
+
+	err = t.tenderRep.DeleteInvitation(ctx, tenderId, invitationId)
+	if err != nil {
+		return errors.WithMessage(err, "Service.Tender revoke invitation")
+	}
+
+	return nil
+}
+
+func (t TenderService) Schedule(ctx context.Context, username, tenderId string, req *domain.ScheduleTenderReq) (*domain.ScheduleTenderResp, error) {
+	isResponsible, err := t.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	err = reschedule(ctx, t.tenderRep, tenderId, req.PublishAt, req.CloseAt, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.scheduleResp(ctx, tenderId)
+}
+
+func (t TenderService) CancelSchedule(ctx context.Context, username, tenderId string) (*domain.ScheduleTenderResp, error) {
+	isResponsible, err := t.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	err = t.tenderRep.SetSchedule(ctx, tenderId, nil, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Tender cancel schedule")
+	}
+
+	return t.scheduleResp(ctx, tenderId)
+}
+
+// maxCancelReasonLength is the limit of the tender_cancellation reason column.
+const maxCancelReasonLength = 1000
+
+// Cancel closes the tender, withdraws its open bids and stores the reason in one transaction:
+// drafts become Canceled and published bids become Rejected.
+func (t TenderService) Cancel(ctx context.Context, username, tenderId, reason string) (*domain.TenderCancellationResp, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, domain.ErrCancelReasonRequired
+	}
+	if utf8.RuneCountInString(reason) > maxCancelReasonLength {
+		return nil, domain.ErrCancelReasonTooLong
+	}
+
+	isResponsible, err := t.tenderRep.UsernameBelongsToTenderOrg(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, domain.ErrUserNotResponsible
+	}
+
+	userId, err := t.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.txMan.CancelTransaction(ctx, func(ctx context.Context, tx CancelTransaction) error {
+		err := tx.CloseTenderIfOpen(ctx, tenderId)
+		if err != nil {
+			return err
+		}
+
+		err = tx.CloseOpenBids(ctx, tenderId)
+		if err != nil {
+			return err
+		}
+
+		err = tx.InsertCancellation(ctx, &model.TenderCancellation{
+			TenderId: tenderId,
+			Reason:   reason,
+			UserId:   userId,
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.InsertEvent(ctx, tenderEvent(model.EventTenderCanceled, tenderId, model.EventPayload{
+			Status:   string(model.TenderStatusClosed),
+			Reason:   reason,
+			Username: username,
+		}))
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Tender cancel")
+	}
+
+	return t.GetCancellation(ctx, username, tenderId)
+}
+
+func (t TenderService) GetCancellation(ctx context.Context, username, tenderId string) (*domain.TenderCancellationResp, error) {
+	canAccess, err := t.tenderRep.UsernameCanAccess(ctx, username, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, domain.ErrTenderNotAccessible
+	}
+
+	cancellation, err := t.tenderRep.GetCancellation(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TenderCancellationResp{
+		TenderId:   cancellation.TenderId,
+		Reason:     cancellation.Reason,
+		CanceledAt: cancellation.CreatedAt,
+	}, nil
+}
+
+// scheduleRep reads and updates the schedule of the tender, in the transaction or without it.
+type scheduleRep interface {
+	GetById(ctx context.Context, tenderId string) (*model.Tender, error)
+	SetSchedule(ctx context.Context, tenderId string, publishAt, closeAt *time.Time) error
+}
+
+// reschedule validates and stores the new publish and close moments of the tender.
+// With keepUnset the moments that are not specified stay as they were.
+func reschedule(ctx context.Context, rep scheduleRep, tenderId string, publishAt, closeAt *time.Time, keepUnset bool) error {
+	tender, err := rep.GetById(ctx, tenderId)
+	if err != nil {
+		return err
+	}
+
+	if keepUnset && publishAt == nil {
+		publishAt = tender.PublishAt
+	}
+	if keepUnset && closeAt == nil {
+		closeAt = tender.CloseAt
+	}
+
+	if err = validateSchedule(tender.Status, publishAt, closeAt); err != nil {
+		return err
+	}
+
+	err = rep.SetSchedule(ctx, tenderId, publishAt, closeAt)
+	if err != nil {
+		return errors.WithMessage(err, "Service.Tender set schedule")
+	}
+
+	return nil
+}
+
+// clearSchedule drops the scheduled publication of the tender moved out of Created and the scheduled
+// closing of the closed tender, the schedule must not outlive the manual status change.
+func clearSchedule(ctx context.Context, rep scheduleRep, tenderId string, status model.TenderStatus) error {
+	tender, err := rep.GetById(ctx, tenderId)
+	if err != nil {
+		return err
+	}
+
+	publishAt, closeAt := tender.PublishAt, tender.CloseAt
+	if status != model.TenderStatusCreated {
+		publishAt = nil
+	}
+	if status == model.TenderStatusClosed {
+		closeAt = nil
+	}
+	if publishAt == tender.PublishAt && closeAt == tender.CloseAt {
+		return nil
+	}
+
+	err = rep.SetSchedule(ctx, tenderId, publishAt, closeAt)
+	if err != nil {
+		return errors.WithMessage(err, "Service.Tender clear schedule")
+	}
+
+	return nil
+}
+
+func (t TenderService) scheduleResp(ctx context.Context, tenderId string) (*domain.ScheduleTenderResp, error) {
+	tenderUpdated, err := t.tenderRep.GetById(ctx, tenderId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Tender get tender")
+	}
+
+	return &domain.ScheduleTenderResp{
+		Id:          tenderUpdated.Id,
+		Name:        tenderUpdated.Name,
+		Description: tenderUpdated.Description,
+		Status:      tenderUpdated.Status,
+		Visibility:  tenderUpdated.Visibility,
+		PublishAt:   tenderUpdated.PublishAt,
+		CloseAt:     tenderUpdated.CloseAt,
+		Budget:      tenderUpdated.Budget,
+		TemplateId:  tenderUpdated.TemplateId,
+		Sealed:      tenderUpdated.Sealed,
+		BidDeadline: tenderUpdated.BidDeadline,
+		TwoStage:    tenderUpdated.TwoStage,
+		Stage:       tenderUpdated.Stage,
+		Anonymized:  tenderUpdated.Anonymized,
+		Framework:   tenderUpdated.Framework,
+		CreatedAt:   tenderUpdated.CreatedAt,
+		Version:     tenderUpdated.Version,
+		ServiceType: tenderUpdated.ServiceType,
+	}, nil
+}
+
+// insertCreatedEvents writes the events of the new tender, the tender created with
+// the Published status is published right away.
+func insertCreatedEvents(ctx context.Context, tx EventWriter, tenderId string, status model.TenderStatus, username string) error {
+	err := tx.InsertEvent(ctx, tenderEvent(model.EventTenderCreated, tenderId, model.EventPayload{
+		Status:   string(status),
+		Username: username,
+	}))
+	if err != nil || status != model.TenderStatusPublished {
+		return err
+	}
+
+	return tx.InsertEvent(ctx, tenderStatusEvent(tenderId, status, username))
+}
+
+// validateSchedule allows to schedule publication only for not yet published tenders
+// and requires the close moment to follow the publication.
+func validateSchedule(status model.TenderStatus, publishAt, closeAt *time.Time) error {
+	now := time.Now()
+
+	if publishAt != nil && (status != model.TenderStatusCreated || !publishAt.After(now)) {
+		return domain.ErrInvalidSchedule
+	}
+
+	if closeAt == nil {
+		return nil
+	}
+
+	if status == model.TenderStatusClosed || !closeAt.After(now) {
+		return domain.ErrInvalidSchedule
+	}
+	if publishAt != nil && !closeAt.After(*publishAt) {
+		return domain.ErrInvalidSchedule
+	}
+
+	return nil
+}
+
+// validateSeal checks that the bid deadline of the sealed tender is in the future and
+// does not come after the scheduled closing, otherwise the envelopes would never be opened.
+func validateSeal(sealed bool, bidDeadline, closeAt *time.Time) error {
+	if !sealed {
+		if bidDeadline != nil {
+			return domain.ErrInvalidSeal
+		}
+		return nil
+	}
+
+	if bidDeadline == nil || !bidDeadline.After(time.Now()) {
+		return domain.ErrInvalidSeal
+	}
+	if closeAt != nil && bidDeadline.After(*closeAt) {
+		return domain.ErrInvalidSeal
+	}
+
+	return nil
+}
+
+func isValidVisibility(visibility string) bool {
+	return visibility == string(model.TenderVisibilityPublic) || visibility == string(model.TenderVisibilityInviteOnly)
+}
+
+func (t TenderService) validateServiceType(ctx context.Context, serviceType model.TenderServiceType) error {
+	exists, err := t.serviceTypeRep.Exists(ctx, string(serviceType))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrInvalidServiceType
+	}
+
+	return nil
+}
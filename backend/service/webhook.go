@@ -0,0 +1,410 @@
+package service
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/log"
+	"avito/utils"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type WebhookRep interface {
+	InsertWebhook(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error)
+	GetWebhook(ctx context.Context, webhookId string) (*model.Webhook, error)
+	GetWebhooks(ctx context.Context, orgId string) ([]model.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookId string) error
+	InsertDeliveries(ctx context.Context, eventIds []int64) error
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDispatch, error)
+	MarkDelivered(ctx context.Context, deliveryId string, responseStatus int) error
+	MarkFailed(ctx context.Context, deliveryId string, responseStatus *int, lastError string, retryAfter time.Duration, dead bool) error
+	GetDelivery(ctx context.Context, deliveryId string) (*model.WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, webhookId string, status model.WebhookDeliveryStatus) ([]model.WebhookDelivery, error)
+	GetDeadLetters(ctx context.Context, orgId string) ([]model.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, deliveryId string) error
+}
+
+// webhookSecretMinLen is the minimal length of the secret signing the deliveries.
+const webhookSecretMinLen = 16
+
+// WebhookService manages the webhook subscriptions of the organizations and their delivery history.
+type WebhookService struct {
+	webhookRep WebhookRep
+	tenderRep  TenderRep
+	orgRep     OrganizationRep
+	master     utils.Cipher
+}
+
+func NewWebhookService(webhookRep WebhookRep, tenderRep TenderRep, orgRep OrganizationRep, master utils.Cipher) WebhookService {
+	return WebhookService{webhookRep: webhookRep, tenderRep: tenderRep, orgRep: orgRep, master: master}
+}
+
+func (s WebhookService) Create(ctx context.Context, username, orgId string, req *domain.CreateWebhookReq) (*domain.WebhookResp, error) {
+	if !validWebhook(req) {
+		return nil, domain.ErrInvalidWebhook
+	}
+
+	if err := s.checkOrg(ctx, username, orgId); err != nil {
+		return nil, err
+	}
+
+	userId, err := s.tenderRep.GetUserIdByName(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := s.master.Encrypt([]byte(req.Secret))
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Webhook encrypt secret")
+	}
+
+	webhook, err := s.webhookRep.InsertWebhook(ctx, &model.Webhook{
+		OrganizationId: orgId,
+		Url:            req.Url,
+		EventTypes:     req.EventTypes,
+		Secret:         secret,
+		UserId:         userId,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Webhook insert webhook")
+	}
+
+	return webhookResp(webhook), nil
+}
+
+func (s WebhookService) GetByOrganization(ctx context.Context, username, orgId string) ([]domain.WebhookResp, error) {
+	if err := s.checkOrg(ctx, username, orgId); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.webhookRep.GetWebhooks(ctx, orgId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Webhook get webhooks")
+	}
+
+	resp := make([]domain.WebhookResp, 0, len(webhooks))
+	for i := range webhooks {
+		resp = append(resp, *webhookResp(&webhooks[i]))
+	}
+
+	return resp, nil
+}
+
+func (s WebhookService) Delete(ctx context.Context, username, webhookId string) error {
+	if _, err := s.webhook(ctx, username, webhookId); err != nil {
+		return err
+	}
+
+	return s.webhookRep.DeleteWebhook(ctx, webhookId)
+}
+
+// Deliveries returns the delivery history of the webhook, optionally filtered by the status.
+func (s WebhookService) Deliveries(ctx context.Context, username, webhookId, status string) ([]domain.WebhookDeliveryResp, error) {
+	if _, err := s.webhook(ctx, username, webhookId); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.webhookRep.GetDeliveries(ctx, webhookId, model.WebhookDeliveryStatus(status))
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Webhook get deliveries")
+	}
+
+	return deliveriesResp(deliveries), nil
+}
+
+// DeadLetters lists the deliveries of the organization webhooks which ran out of attempts.
+func (s WebhookService) DeadLetters(ctx context.Context, username, orgId string) ([]domain.WebhookDeliveryResp, error) {
+	if err := s.checkOrg(ctx, username, orgId); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.webhookRep.GetDeadLetters(ctx, orgId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Webhook get dead letters")
+	}
+
+	return deliveriesResp(deliveries), nil
+}
+
+// Replay queues the dead delivery again, the dispatcher sends it on its next run.
+func (s WebhookService) Replay(ctx context.Context, username, deliveryId string) (*domain.WebhookDeliveryResp, error) {
+	delivery, err := s.webhookRep.GetDelivery(ctx, deliveryId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.webhook(ctx, username, delivery.WebhookId); err != nil {
+		return nil, err
+	}
+
+	if err = s.webhookRep.ReplayDelivery(ctx, deliveryId); err != nil {
+		return nil, errors.WithMessage(err, "Service.Webhook replay delivery")
+	}
+
+	delivery, err = s.webhookRep.GetDelivery(ctx, deliveryId)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Service.Webhook get delivery")
+	}
+
+	return deliveryResp(delivery), nil
+}
+
+func (s WebhookService) webhook(ctx context.Context, username, webhookId string) (*model.Webhook, error) {
+	webhook, err := s.webhookRep.GetWebhook(ctx, webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.checkOrg(ctx, username, webhook.OrganizationId); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s WebhookService) checkOrg(ctx context.Context, username, orgId string) error {
+	isResponsible, err := s.orgRep.EmpBelongs(ctx, username, orgId)
+	if err != nil {
+		return err
+	}
+	if !isResponsible {
+		return domain.ErrUserNotResponsible
+	}
+
+	return nil
+}
+
+func validWebhook(req *domain.CreateWebhookReq) bool {
+	target, err := url.Parse(req.Url)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return false
+	}
+
+	if len(req.Secret) < webhookSecretMinLen || len(req.EventTypes) == 0 {
+		return false
+	}
+
+	for _, eventType := range req.EventTypes {
+		switch eventType {
+		case model.EventTenderCreated, model.EventTenderPublished, model.EventTenderClosed, model.EventTenderEdited,
+			model.EventTenderCanceled, model.EventTenderInvited, model.EventBidCreated, model.EventBidSubmitted,
+			model.EventBidEdited, model.EventBidWithdrawn, model.EventBidResubmitted, model.EventBidApproved,
+			model.EventBidRejected, model.EventBidFeedback:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func webhookResp(webhook *model.Webhook) *domain.WebhookResp {
+	return &domain.WebhookResp{
+		Id:             webhook.Id,
+		OrganizationId: webhook.OrganizationId,
+		Url:            webhook.Url,
+		EventTypes:     webhook.EventTypes,
+		CreatedAt:      webhook.CreatedAt,
+	}
+}
+
+func deliveryResp(delivery *model.WebhookDelivery) *domain.WebhookDeliveryResp {
+	return &domain.WebhookDeliveryResp{
+		Id:             delivery.Id,
+		WebhookId:      delivery.WebhookId,
+		EventId:        delivery.EventId,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastError:      delivery.LastError,
+		ResponseStatus: delivery.ResponseStatus,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
+
+func deliveriesResp(deliveries []model.WebhookDelivery) []domain.WebhookDeliveryResp {
+	resp := make([]domain.WebhookDeliveryResp, 0, len(deliveries))
+	for i := range deliveries {
+		resp = append(resp, *deliveryResp(&deliveries[i]))
+	}
+
+	return resp
+}
+
+// WebhookSink queues the outbox events for the webhooks subscribed to them,
+// the WebhookDispatcher sends the queued deliveries.
+type WebhookSink struct {
+	webhookRep WebhookRep
+}
+
+func NewWebhookSink(webhookRep WebhookRep) WebhookSink {
+	return WebhookSink{webhookRep: webhookRep}
+}
+
+func (s WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s WebhookSink) Deliver(ctx context.Context, events []model.OutboxEvent) error {
+	eventIds := make([]int64, len(events))
+	for i := range events {
+		eventIds[i] = events[i].Id
+	}
+
+	return s.webhookRep.InsertDeliveries(ctx, eventIds)
+}
+
+const (
+	// webhookBatchSize is the number of deliveries claimed at once.
+	webhookBatchSize = 50
+	// webhookTimeout limits a single delivery request.
+	webhookTimeout = 10 * time.Second
+	// webhookLease keeps a claimed delivery from the other dispatchers while it is sent,
+	// it outlasts the batch sent one by one to the webhooks that do not respond.
+	webhookLease = webhookBatchSize*webhookTimeout + time.Minute
+)
+
+// WebhookDispatcher sends the due webhook deliveries signed with the webhook secrets. A failed
+// delivery is retried with the exponential backoff and goes to the dead letters after the last attempt.
+type WebhookDispatcher struct {
+	logger      log.Logger
+	webhookRep  WebhookRep
+	master      utils.Cipher
+	client      *http.Client
+	interval    time.Duration
+	backoff     time.Duration
+	maxAttempts int
+}
+
+func NewWebhookDispatcher(logger log.Logger, webhookRep WebhookRep, master utils.Cipher, interval, backoff time.Duration,
+	maxAttempts int) WebhookDispatcher {
+	return WebhookDispatcher{
+		logger:      logger,
+		webhookRep:  webhookRep,
+		master:      master,
+		client:      &http.Client{Timeout: webhookTimeout},
+		interval:    interval,
+		backoff:     backoff,
+		maxAttempts: maxAttempts,
+	}
+}
+
+func (d WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.DispatchDue(ctx); err != nil && ctx.Err() == nil {
+				d.logger.Error(ctx, fmt.Sprintf("dispatch webhook deliveries: %v", err))
+			}
+		}
+	}
+}
+
+// DispatchDue sends the deliveries due for an attempt and records the outcome of each of them.
+func (d WebhookDispatcher) DispatchDue(ctx context.Context) error {
+	dispatches, err := d.webhookRep.ClaimDueDeliveries(ctx, webhookBatchSize, webhookLease)
+	if err != nil {
+		return err
+	}
+
+	for i := range dispatches {
+		if err = d.dispatch(ctx, &dispatches[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d WebhookDispatcher) dispatch(ctx context.Context, dispatch *model.WebhookDispatch) error {
+	responseStatus, err := d.send(ctx, dispatch)
+	if err == nil {
+		return d.webhookRep.MarkDelivered(ctx, dispatch.DeliveryId, responseStatus)
+	}
+
+	var status *int
+	if responseStatus != 0 {
+		status = &responseStatus
+	}
+
+	attempt := dispatch.Attempts + 1
+	dead := attempt >= d.maxAttempts
+	deliveryCtx := log.AddKeyVal(ctx, "deliveryId", dispatch.DeliveryId)
+	if dead {
+		d.logger.Warn(deliveryCtx, fmt.Sprintf("webhook delivery is dead after %d attempts: %v", attempt, err))
+	}
+
+	return d.webhookRep.MarkFailed(ctx, dispatch.DeliveryId, status, err.Error(), retryAfter(d.backoff, attempt), dead)
+}
+
+// send posts the signed event to the webhook, any response outside 2xx is a failure.
+// The bidder events of the anonymized tender are sent without the bidder username.
+func (d WebhookDispatcher) send(ctx context.Context, dispatch *model.WebhookDispatch) (int, error) {
+	secret, err := d.master.Decrypt(dispatch.Secret)
+	if err != nil {
+		return 0, errors.WithMessage(err, "decrypt webhook secret")
+	}
+
+	payload := dispatch.Payload
+	if dispatch.Anonymized && bidderEvent(dispatch.Type) {
+		payload.Username = ""
+	}
+
+	body, err := json.Marshal(domain.WebhookEventBody{
+		Id:        dispatch.Id,
+		Type:      dispatch.Type,
+		TenderId:  dispatch.TenderId,
+		BidId:     dispatch.BidId,
+		Payload:   payload,
+		CreatedAt: dispatch.CreatedAt,
+	})
+	if err != nil {
+		return 0, errors.WithMessage(err, "marshal webhook event")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dispatch.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, errors.WithMessage(err, "create webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", string(dispatch.Type))
+	req.Header.Set("X-Webhook-Delivery", dispatch.DeliveryId)
+	req.Header.Set("X-Webhook-Signature", WebhookSignature(secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, errors.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// WebhookSignature is the value of the X-Webhook-Signature header: the hex HMAC-SHA256 of the body.
+func WebhookSignature(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
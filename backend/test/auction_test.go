@@ -0,0 +1,266 @@
+package test
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/test/basic"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestReverseAuction(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+	charlieOrg := basic.CreateOrgEmployee(test, "Charlie")
+
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	}
+	tender, resp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bidIds := basic.CreatePublishedBids(test, tender.Id, aliceOrg, bobOrg)
+
+	// ONLY THE TENDER ORGANIZATION CONFIGURES THE AUCTION
+	auctionReq := domain.SetAuctionReq{
+		StartAt:          time.Now().Add(-time.Second),
+		EndAt:            time.Now().Add(4 * time.Second),
+		MinStep:          10,
+		ExtensionSeconds: 1,
+	}
+	_, resp = basic.SetAuction(test, tender.Id, aliceOrg.Username, auctionReq)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	auction, resp := basic.SetAuction(test, tender.Id, martinOrg.Username, auctionReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Nil(auction.BestPrice)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// CHARLIE DOES NOT TAKE PART IN THE AUCTION
+	_, status := basic.AuctionStream(ctx, test, tender.Id, charlieOrg.Username)
+	test.Assertions.Equal(http.StatusForbidden, status)
+
+	states, status := basic.AuctionStream(ctx, test, tender.Id, bobOrg.Username)
+	test.Assertions.Equal(http.StatusOK, status)
+	state := <-states
+	test.Assertions.Nil(state.BestPrice)
+
+	_, resp = basic.PlaceAuctionPrice(test, bidIds[aliceOrg.Username], aliceOrg.Username, 100)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	state = <-states
+	test.Assertions.NotNil(state.BestPrice)
+	test.Assertions.InDelta(100, *state.BestPrice, 0.001)
+
+	// THE NEW PRICE MUST BEAT THE BEST ONE BY THE MINIMUM STEP
+	_, resp = basic.PlaceAuctionPrice(test, bidIds[bobOrg.Username], bobOrg.Username, 95)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.PlaceAuctionPrice(test, bidIds[bobOrg.Username], bobOrg.Username, 90)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.PlaceAuctionPrice(test, bidIds[aliceOrg.Username], bobOrg.Username, 50)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	// THE WINNER IS APPROVED ONLY AFTER THE AUCTION ENDS
+	_, resp = basic.SubmitDecisionBid(test, bidIds[bobOrg.Username], martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	time.Sleep(time.Until(auctionReq.EndAt))
+
+	// A PRICE PLACED IN THE LAST SECOND WOULD HAVE EXTENDED THE AUCTION, NOW IT IS OVER
+	_, resp = basic.PlaceAuctionPrice(test, bidIds[aliceOrg.Username], aliceOrg.Username, 70)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	ranking, resp := basic.GetAuctionRanking(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(ranking, 2)
+	test.Assertions.Equal(bidIds[bobOrg.Username], ranking[0].BidId)
+	test.Assertions.InDelta(90, ranking[0].Price, 0.001)
+
+	_, resp = basic.SubmitDecisionBid(test, bidIds[aliceOrg.Username], martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.SubmitDecisionBid(test, bidIds[bobOrg.Username], martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// THE CONTRACT IS AWARDED AT THE AUCTION PRICE
+	contract, resp := basic.GetContract(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.NotNil(contract.Price)
+	test.Assertions.InDelta(90, *contract.Price, 0.001)
+}
+
+func TestReverseAuctionAntiSniping(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	}
+	tender, resp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bid, resp := basic.CreateBid(test, domain.CreateBidReq{
+		Name:        "bid of Alice",
+		Description: "d1",
+		TenderId:    tender.Id,
+		AuthorType:  model.BidAuthorTypeUser,
+		AuthorId:    aliceOrg.EmployeeId,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SetBidStatus(test, bid.Id, aliceOrg.Username, model.BidStatusPublished)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	auctionReq := domain.SetAuctionReq{
+		StartAt:          time.Now().Add(-time.Second),
+		EndAt:            time.Now().Add(2 * time.Second),
+		MinStep:          1,
+		ExtensionSeconds: 60,
+	}
+	_, resp = basic.SetAuction(test, tender.Id, martinOrg.Username, auctionReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	placed, resp := basic.PlaceAuctionPrice(test, bid.Id, aliceOrg.Username, 100)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.True(placed.EndAt.After(auctionReq.EndAt.Add(time.Minute / 2)))
+
+	// THE AUCTION CAN NOT BE CHANGED AFTER THE FIRST PRICE
+	_, resp = basic.SetAuction(test, tender.Id, martinOrg.Username, auctionReq)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+}
+
+func TestReverseAuctionWithdrawnLeader(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	}
+	tender, resp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bidIds := basic.CreatePublishedBids(test, tender.Id, aliceOrg, bobOrg)
+
+	auctionReq := domain.SetAuctionReq{
+		StartAt:          time.Now().Add(-time.Second),
+		EndAt:            time.Now().Add(3 * time.Second),
+		MinStep:          10,
+		ExtensionSeconds: 0,
+	}
+	_, resp = basic.SetAuction(test, tender.Id, martinOrg.Username, auctionReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.PlaceAuctionPrice(test, bidIds[aliceOrg.Username], aliceOrg.Username, 100)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.PlaceAuctionPrice(test, bidIds[bobOrg.Username], bobOrg.Username, 50)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.WithdrawBid(test, bidIds[bobOrg.Username], bobOrg.Username, domain.WithdrawBidReq{Reason: "r1"})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// THE PRICE OF THE WITHDRAWN BID IS NOT THE ONE TO BEAT
+	_, resp = basic.PlaceAuctionPrice(test, bidIds[aliceOrg.Username], aliceOrg.Username, 80)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	time.Sleep(time.Until(auctionReq.EndAt))
+
+	ranking, resp := basic.GetAuctionRanking(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(ranking, 1)
+	test.Assertions.Equal(bidIds[aliceOrg.Username], ranking[0].BidId)
+	test.Assertions.InDelta(80, ranking[0].Price, 0.001)
+
+	_, resp = basic.SubmitDecisionBid(test, bidIds[aliceOrg.Username], martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+}
+
+func TestReverseAuctionTwoStage(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+		TwoStage:        true,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bidIds := make([]string, 0, 2)
+	for _, author := range []basic.EmployeeOrg{aliceOrg, bobOrg} {
+		bid, resp := basic.CreateBid(test, domain.CreateBidReq{
+			Name:        "bid of " + author.Username,
+			Description: "d1",
+			TenderId:    tender.Id,
+			AuthorType:  model.BidAuthorTypeUser,
+			AuthorId:    author.EmployeeId,
+			Commercial:  &domain.CommercialPartReq{Price: 100, Terms: "30 days"},
+		})
+		test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+		_, resp = basic.SetBidStatus(test, bid.Id, author.Username, model.BidStatusPublished)
+		test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+		bidIds = append(bidIds, bid.Id)
+	}
+
+	_, resp = basic.SetAuction(test, tender.Id, martinOrg.Username, domain.SetAuctionReq{
+		StartAt:          time.Now().Add(-time.Second),
+		EndAt:            time.Now().Add(time.Minute),
+		MinStep:          1,
+		ExtensionSeconds: 0,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// NO PRICES DURING THE TECHNICAL STAGE
+	_, resp = basic.PlaceAuctionPrice(test, bidIds[0], aliceOrg.Username, 90)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.Shortlist(test, tender.Id, martinOrg.Username, domain.ShortlistReq{BidIds: bidIds[:1]})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.PlaceAuctionPrice(test, bidIds[1], bobOrg.Username, 90)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.PlaceAuctionPrice(test, bidIds[0], aliceOrg.Username, 90)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+}
@@ -0,0 +1,102 @@
+package basic
+
+import (
+	"avito/domain"
+	"bufio"
+	"context"
+	"encoding/json"
+	"github.com/txix-open/isp-kit/http/httpcli"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+func SetAuction(test *Test, tenderId, username string, req domain.SetAuctionReq) (domain.AuctionResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var auction domain.AuctionResp
+	resp, err := test.Cli.Put(test.URL + "/api/tenders/" + tenderId + "/auction").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&req).
+		JsonResponseBody(&auction).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return auction, resp
+}
+
+func PlaceAuctionPrice(test *Test, bidId, username string, price float64) (domain.AuctionPriceResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var placed domain.AuctionPriceResp
+	resp, err := test.Cli.Put(test.URL + "/api/bids/" + bidId + "/auction_price").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&domain.PlacePriceReq{Price: price}).
+		JsonResponseBody(&placed).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return placed, resp
+}
+
+func GetAuctionRanking(test *Test, tenderId, username string) ([]domain.AuctionRankingResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var ranking []domain.AuctionRankingResp
+	resp, err := test.Cli.Get(test.URL + "/api/tenders/" + tenderId + "/auction/ranking").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&ranking).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return ranking, resp
+}
+
+// AuctionStream subscribes to the auction events of the tender and returns the decoded
+// states as they arrive. The stream is closed when the context is done.
+func AuctionStream(ctx context.Context, test *Test, tenderId, username string) (<-chan domain.AuctionResp, int) {
+	assert := test.Assertions
+
+	streamUrl := test.URL + "/api/tenders/" + tenderId + "/auction/stream?username=" + url.QueryEscape(username)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, streamUrl, nil)
+	assert.NoError(err)
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(err)
+
+	states := make(chan domain.AuctionResp)
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		close(states)
+		return states, resp.StatusCode
+	}
+
+	go func() {
+		defer close(states)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+			if !ok {
+				continue
+			}
+
+			var state domain.AuctionResp
+			if json.Unmarshal([]byte(data), &state) != nil {
+				return
+			}
+
+			select {
+			case states <- state:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return states, resp.StatusCode
+}
@@ -5,6 +5,7 @@ import (
 	"avito/domain"
 	"context"
 	"github.com/txix-open/isp-kit/http/httpcli"
+	"net/http"
 )
 
 func SetBidStatus(test *Test, bidId, username string, status model.BidStatus) (domain.SetStatusBidResp, *httpcli.Response) {
@@ -35,6 +36,28 @@ func CreateBid(test *Test, bidReq domain.CreateBidReq) (domain.CreateBidResp, *h
 	return bid, resp
 }
 
+// CreatePublishedBids creates and publishes a bid of every author on the tender,
+// it returns the bid ids by the author usernames.
+func CreatePublishedBids(test *Test, tenderId string, authors ...EmployeeOrg) map[string]string {
+	bidIds := make(map[string]string, len(authors))
+	for _, author := range authors {
+		bid, resp := CreateBid(test, domain.CreateBidReq{
+			Name:        "bid of " + author.Username,
+			Description: "d1",
+			TenderId:    tenderId,
+			AuthorType:  model.BidAuthorTypeUser,
+			AuthorId:    author.EmployeeId,
+		})
+		test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+		_, resp = SetBidStatus(test, bid.Id, author.Username, model.BidStatusPublished)
+		test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+		bidIds[author.Username] = bid.Id
+	}
+
+	return bidIds
+}
+
 func GetBidByUsername(test *Test, username string, offset, limit int) ([]domain.GetBidResp, *httpcli.Response) {
 	assert := test.Assertions
 
@@ -49,6 +72,20 @@ func GetBidByUsername(test *Test, username string, offset, limit int) ([]domain.
 	return bidsResp, resp
 }
 
+func GetBidsByTenderId(test *Test, tenderId, username string, offset, limit int) ([]domain.GetBidResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var bidsResp []domain.GetBidResp
+	resp, err := test.Cli.Get(test.URL + "/api/bids/" + tenderId + "/list").
+		QueryParams(map[string]any{"username": username, "offset": offset, "limit": limit}).
+		JsonResponseBody(&bidsResp).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return bidsResp, resp
+}
+
 func EditBid(test *Test, bidId, username string, req domain.EditBidReq) (domain.EditBidResp, *httpcli.Response) {
 	assert := test.Assertions
 
@@ -122,3 +159,60 @@ func ReviewBid(test *Test, tenderId, authorUsername, requesterUsername string, o
 
 	return reviewResp, resp
 }
+
+func GetConflictOverrides(test *Test, tenderId, username string) ([]domain.ConflictOverrideResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var overridesResp []domain.ConflictOverrideResp
+	resp, err := test.Cli.Get(test.URL + "/api/bids/" + tenderId + "/conflict_overrides").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&overridesResp).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return overridesResp, resp
+}
+
+func WithdrawBid(test *Test, bidId, username string, req domain.WithdrawBidReq) (domain.WithdrawBidResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var withdrawResp domain.WithdrawBidResp
+	resp, err := test.Cli.Put(test.URL + "/api/bids/" + bidId + "/withdraw").
+		JsonRequestBody(req).
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&withdrawResp).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return withdrawResp, resp
+}
+
+func ResubmitBid(test *Test, bidId, username string) (domain.ResubmitBidResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var resubmitResp domain.ResubmitBidResp
+	resp, err := test.Cli.Put(test.URL + "/api/bids/" + bidId + "/resubmit").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&resubmitResp).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return resubmitResp, resp
+}
+
+func GetBidCommercial(test *Test, bidId, username string) (domain.CommercialPartResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var part domain.CommercialPartResp
+	resp, err := test.Cli.Get(test.URL + "/api/bids/" + bidId + "/commercial").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&part).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return part, resp
+}
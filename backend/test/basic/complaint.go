@@ -0,0 +1,81 @@
+package basic
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"context"
+	"github.com/txix-open/isp-kit/http/httpcli"
+)
+
+func FileComplaint(test *Test, tenderId, username string, req domain.FileComplaintReq) (domain.ComplaintResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var complaint domain.ComplaintResp
+	resp, err := test.Cli.Post(test.URL + "/api/tenders/" + tenderId + "/complaints").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&req).
+		JsonResponseBody(&complaint).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return complaint, resp
+}
+
+func GetComplaints(test *Test, tenderId, username string) ([]domain.ComplaintResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var complaints []domain.ComplaintResp
+	resp, err := test.Cli.Get(test.URL + "/api/tenders/" + tenderId + "/complaints").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&complaints).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return complaints, resp
+}
+
+func GetComplaint(test *Test, complaintId, username string) (domain.ComplaintResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var complaint domain.ComplaintResp
+	resp, err := test.Cli.Get(test.URL + "/api/complaints/" + complaintId).
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&complaint).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return complaint, resp
+}
+
+func RespondComplaint(test *Test, complaintId, username, message string) (domain.ComplaintResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var complaint domain.ComplaintResp
+	resp, err := test.Cli.Post(test.URL + "/api/complaints/" + complaintId + "/responses").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(domain.ComplaintResponseReq{Message: message}).
+		JsonResponseBody(&complaint).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return complaint, resp
+}
+
+func SetComplaintStatus(test *Test, complaintId, username string, status model.ComplaintStatus, message string) (domain.ComplaintResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var complaint domain.ComplaintResp
+	resp, err := test.Cli.Put(test.URL + "/api/complaints/" + complaintId + "/status").
+		QueryParams(map[string]any{"username": username, "status": string(status)}).
+		JsonRequestBody(domain.ComplaintResponseReq{Message: message}).
+		JsonResponseBody(&complaint).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return complaint, resp
+}
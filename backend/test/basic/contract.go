@@ -0,0 +1,108 @@
+package basic
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"context"
+	"github.com/txix-open/isp-kit/http/httpcli"
+)
+
+func GetContract(test *Test, tenderId, username string) (domain.ContractResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var contract domain.ContractResp
+	resp, err := test.Cli.Get(test.URL + "/api/tenders/" + tenderId + "/contract").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&contract).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return contract, resp
+}
+
+func SignContract(test *Test, tenderId, username string, side model.ContractSide) (domain.ContractResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var contract domain.ContractResp
+	resp, err := test.Cli.Put(test.URL + "/api/tenders/" + tenderId + "/contract/sign").
+		QueryParams(map[string]any{"username": username, "side": string(side)}).
+		JsonResponseBody(&contract).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return contract, resp
+}
+
+func CreateMilestone(test *Test, tenderId, username string, req domain.CreateMilestoneReq) (domain.MilestoneResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var milestone domain.MilestoneResp
+	resp, err := test.Cli.Post(test.URL + "/api/tenders/" + tenderId + "/milestones").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&req).
+		JsonResponseBody(&milestone).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return milestone, resp
+}
+
+func GetMilestones(test *Test, tenderId, username string) ([]domain.MilestoneResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var milestones []domain.MilestoneResp
+	resp, err := test.Cli.Get(test.URL + "/api/tenders/" + tenderId + "/milestones").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&milestones).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return milestones, resp
+}
+
+func MoveMilestone(test *Test, milestoneId, username, action string) (domain.MilestoneResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var milestone domain.MilestoneResp
+	resp, err := test.Cli.Put(test.URL + "/api/milestones/" + milestoneId + "/" + action).
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&milestone).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return milestone, resp
+}
+
+func DisputeMilestone(test *Test, milestoneId, username, reason string) (domain.MilestoneResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var milestone domain.MilestoneResp
+	resp, err := test.Cli.Put(test.URL + "/api/milestones/" + milestoneId + "/dispute").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(domain.DisputeMilestoneReq{Reason: reason}).
+		JsonResponseBody(&milestone).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return milestone, resp
+}
+
+func GetOverdueMilestones(test *Test, orgId, username string) ([]domain.MilestoneResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var milestones []domain.MilestoneResp
+	resp, err := test.Cli.Get(test.URL + "/api/organizations/" + orgId + "/milestones/overdue").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&milestones).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return milestones, resp
+}
@@ -0,0 +1,49 @@
+package basic
+
+import (
+	"avito/domain"
+	"context"
+	"github.com/txix-open/isp-kit/http/httpcli"
+)
+
+func OpenEnvelopes(test *Test, tenderId, username string) (domain.EnvelopeOpeningResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var opening domain.EnvelopeOpeningResp
+	resp, err := test.Cli.Put(test.URL + "/api/tenders/" + tenderId + "/envelopes/open").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&opening).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return opening, resp
+}
+
+func GetEnvelopeOpening(test *Test, tenderId, username string) (domain.EnvelopeOpeningResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var opening domain.EnvelopeOpeningResp
+	resp, err := test.Cli.Get(test.URL + "/api/tenders/" + tenderId + "/envelopes").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&opening).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return opening, resp
+}
+
+func GetBidContent(test *Test, bidId, username string) (domain.BidContentResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var content domain.BidContentResp
+	resp, err := test.Cli.Get(test.URL + "/api/bids/" + bidId + "/content").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&content).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return content, resp
+}
@@ -0,0 +1,94 @@
+package basic
+
+import (
+	"avito/domain"
+	"context"
+	"github.com/txix-open/isp-kit/http/httpcli"
+)
+
+func SetCriteria(test *Test, tenderId, username string, req domain.SetCriteriaReq) ([]domain.CriterionResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var criteria []domain.CriterionResp
+	resp, err := test.Cli.Put(test.URL + "/api/tenders/" + tenderId + "/criteria").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&req).
+		JsonResponseBody(&criteria).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return criteria, resp
+}
+
+func ScoreBid(test *Test, bidId, username string, req domain.ScoreBidReq) ([]domain.BidScoreResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var scores []domain.BidScoreResp
+	resp, err := test.Cli.Put(test.URL + "/api/bids/" + bidId + "/scores").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&req).
+		JsonResponseBody(&scores).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return scores, resp
+}
+
+func GetRanking(test *Test, tenderId, username string) ([]domain.BidRankingResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var ranking []domain.BidRankingResp
+	resp, err := test.Cli.Get(test.URL + "/api/tenders/" + tenderId + "/ranking").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&ranking).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return ranking, resp
+}
+
+func GetCriteria(test *Test, tenderId, username string) ([]domain.CriterionResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var criteria []domain.CriterionResp
+	resp, err := test.Cli.Get(test.URL + "/api/tenders/" + tenderId + "/criteria").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&criteria).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return criteria, resp
+}
+
+func Shortlist(test *Test, tenderId, username string, req domain.ShortlistReq) ([]domain.ShortlistedBidResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var shortlist []domain.ShortlistedBidResp
+	resp, err := test.Cli.Put(test.URL + "/api/tenders/" + tenderId + "/shortlist").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&req).
+		JsonResponseBody(&shortlist).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return shortlist, resp
+}
+
+func GetShortlist(test *Test, tenderId, username string) ([]domain.ShortlistedBidResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var shortlist []domain.ShortlistedBidResp
+	resp, err := test.Cli.Get(test.URL + "/api/tenders/" + tenderId + "/shortlist").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&shortlist).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return shortlist, resp
+}
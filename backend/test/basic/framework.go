@@ -0,0 +1,36 @@
+package basic
+
+import (
+	"avito/domain"
+	"context"
+	"github.com/txix-open/isp-kit/http/httpcli"
+)
+
+func IssueCallOffOrder(test *Test, tenderId, username string, req domain.CallOffOrderReq) (domain.CallOffOrderResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var order domain.CallOffOrderResp
+	resp, err := test.Cli.Post(test.URL + "/api/tenders/" + tenderId + "/call_off_orders").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&req).
+		JsonResponseBody(&order).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return order, resp
+}
+
+func GetCallOffOrders(test *Test, tenderId, username string) ([]domain.CallOffOrderResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var orders []domain.CallOffOrderResp
+	resp, err := test.Cli.Get(test.URL + "/api/tenders/" + tenderId + "/call_off_orders").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&orders).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return orders, resp
+}
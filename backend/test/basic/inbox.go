@@ -0,0 +1,64 @@
+package basic
+
+import (
+	"avito/domain"
+	"context"
+	"github.com/txix-open/isp-kit/http/httpcli"
+)
+
+func GetNotifications(test *Test, username string, unreadOnly bool, offset, limit int) ([]domain.InboxNotificationResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var notifications []domain.InboxNotificationResp
+	resp, err := test.Cli.Get(test.URL + "/api/notifications").
+		QueryParams(map[string]any{"username": username, "unread": unreadOnly, "offset": offset, "limit": limit}).
+		JsonResponseBody(&notifications).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return notifications, resp
+}
+
+func GetUnreadCount(test *Test, username string) (domain.InboxUnreadResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var unread domain.InboxUnreadResp
+	resp, err := test.Cli.Get(test.URL + "/api/notifications/unread_count").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&unread).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return unread, resp
+}
+
+func MarkNotificationRead(test *Test, notificationId, username string) (string, *httpcli.Response) {
+	assert := test.Assertions
+
+	var id string
+	resp, err := test.Cli.Put(test.URL + "/api/notifications/" + notificationId + "/read").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&id).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return id, resp
+}
+
+func MarkNotificationsRead(test *Test, username string, req domain.InboxReadReq) (domain.InboxReadResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var read domain.InboxReadResp
+	resp, err := test.Cli.Put(test.URL + "/api/notifications/read").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&req).
+		JsonResponseBody(&read).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return read, resp
+}
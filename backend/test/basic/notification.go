@@ -0,0 +1,36 @@
+package basic
+
+import (
+	"avito/domain"
+	"context"
+	"github.com/txix-open/isp-kit/http/httpcli"
+)
+
+func SetNotificationPreference(test *Test, username string, req domain.NotificationPreferenceReq) (domain.NotificationPreferenceResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var preference domain.NotificationPreferenceResp
+	resp, err := test.Cli.Put(test.URL + "/api/users/notifications").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&req).
+		JsonResponseBody(&preference).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return preference, resp
+}
+
+func GetNotificationPreference(test *Test, username string) (domain.NotificationPreferenceResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var preference domain.NotificationPreferenceResp
+	resp, err := test.Cli.Get(test.URL + "/api/users/notifications").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&preference).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return preference, resp
+}
@@ -0,0 +1,34 @@
+package basic
+
+import (
+	"avito/db/model"
+	"context"
+)
+
+func GetOutboxEvents(test *Test, tenderId string) []model.OutboxEvent {
+	assert := test.Assertions
+
+	var events []model.OutboxEvent
+	err := test.DbCli.Select(context.Background(), &events,
+		`SELECT id, type, tender_id, bid_id, payload, created_at FROM `+test.Schema+`.outbox_event
+			  WHERE tender_id = $1 ORDER BY id`, tenderId)
+
+	assert.NoError(err)
+
+	return events
+}
+
+func GetOutboxOffset(test *Test, sink string) int64 {
+	assert := test.Assertions
+
+	var offsets []int64
+	err := test.DbCli.Select(context.Background(), &offsets,
+		`SELECT event_id FROM `+test.Schema+`.outbox_offset WHERE sink = $1`, sink)
+
+	assert.NoError(err)
+
+	if len(offsets) == 0 {
+		return 0
+	}
+	return offsets[0]
+}
@@ -0,0 +1,64 @@
+package basic
+
+import (
+	"avito/domain"
+	"context"
+	"github.com/txix-open/isp-kit/http/httpcli"
+)
+
+func CreateSavedSearch(test *Test, username string, req domain.CreateSavedSearchReq) (domain.SavedSearchResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var search domain.SavedSearchResp
+	resp, err := test.Cli.Post(test.URL + "/api/users/searches").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&req).
+		JsonResponseBody(&search).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return search, resp
+}
+
+func GetSavedSearches(test *Test, username string) ([]domain.SavedSearchResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var searches []domain.SavedSearchResp
+	resp, err := test.Cli.Get(test.URL + "/api/users/searches").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&searches).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return searches, resp
+}
+
+func DeleteSavedSearch(test *Test, searchId, username string) (string, *httpcli.Response) {
+	assert := test.Assertions
+
+	var id string
+	resp, err := test.Cli.Delete(test.URL + "/api/users/searches/" + searchId).
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&id).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return id, resp
+}
+
+func GetTenderAlerts(test *Test, username, searchId string, offset, limit int) ([]domain.TenderAlertResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var alerts []domain.TenderAlertResp
+	resp, err := test.Cli.Get(test.URL + "/api/users/alerts").
+		QueryParams(map[string]any{"username": username, "searchId": searchId, "offset": offset, "limit": limit}).
+		JsonResponseBody(&alerts).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return alerts, resp
+}
@@ -0,0 +1,63 @@
+package basic
+
+import (
+	"avito/domain"
+	"context"
+	"github.com/txix-open/isp-kit/http/httpcli"
+)
+
+func CreateServiceType(test *Test, username string, req domain.CreateServiceTypeReq) (domain.ServiceTypeResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var serviceType domain.ServiceTypeResp
+	resp, err := test.Cli.Post(test.URL + "/api/service_types").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&req).
+		JsonResponseBody(&serviceType).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return serviceType, resp
+}
+
+func UpdateServiceType(test *Test, code, username string, req domain.UpdateServiceTypeReq) (domain.ServiceTypeResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var serviceType domain.ServiceTypeResp
+	resp, err := test.Cli.Put(test.URL + "/api/service_types/" + code).
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&req).
+		JsonResponseBody(&serviceType).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return serviceType, resp
+}
+
+func DeleteServiceType(test *Test, code, username string) *httpcli.Response {
+	assert := test.Assertions
+
+	resp, err := test.Cli.Delete(test.URL + "/api/service_types/" + code).
+		QueryParams(map[string]any{"username": username}).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return resp
+}
+
+func GetPublishedTendersByType(test *Test, username, serviceType string) ([]domain.GetTendersResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var tendersResp []domain.GetTendersResp
+	resp, err := test.Cli.Get(test.URL + "/api/tenders").
+		QueryParams(map[string]any{"username": username, "service_type": serviceType}).
+		JsonResponseBody(&tendersResp).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return tendersResp, resp
+}
@@ -0,0 +1,137 @@
+package basic
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Email is the message received by the FakeSMTP server.
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// FakeSMTP is an in-process SMTP server keeping the received messages. It rejects
+// the messages while there are failures left, the failures are counted per message.
+type FakeSMTP struct {
+	Address string
+
+	listener net.Listener
+	mu       sync.Mutex
+	failures int
+	emails   []Email
+}
+
+func NewFakeSMTP(t *testing.T, failures int) *FakeSMTP {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := &FakeSMTP{Address: listener.Addr().String(), listener: listener, failures: failures}
+	go server.serve()
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	return server
+}
+
+// Emails returns the messages received by the recipient.
+func (s *FakeSMTP) Emails(to string) []Email {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	emails := make([]Email, 0)
+	for _, email := range s.emails {
+		if email.To == to {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+func (s *FakeSMTP) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.session(conn)
+	}
+}
+
+func (s *FakeSMTP) session(conn net.Conn) {
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 fake smtp ready")
+
+	var to string
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch command {
+		case "EHLO", "HELO":
+			_ = text.PrintfLine("250 fake smtp")
+		case "MAIL":
+			_ = text.PrintfLine("250 ok")
+		case "RCPT":
+			to = strings.Trim(strings.TrimPrefix(line[len("RCPT TO:"):], " "), "<>")
+			_ = text.PrintfLine("250 ok")
+		case "DATA":
+			_ = text.PrintfLine("354 end data with <CR><LF>.<CR><LF>")
+			data, err := io.ReadAll(text.DotReader())
+			if err != nil {
+				return
+			}
+			_ = text.PrintfLine(s.receive(to, string(data)))
+		case "RSET", "NOOP":
+			_ = text.PrintfLine("250 ok")
+		case "QUIT":
+			_ = text.PrintfLine("221 bye")
+			return
+		default:
+			_ = text.PrintfLine("502 command not implemented")
+		}
+	}
+}
+
+// receive keeps the message unless it has to fail, it returns the reply to the DATA command.
+func (s *FakeSMTP) receive(to, data string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+		return "451 try again later"
+	}
+
+	message, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(data)))
+	if err != nil {
+		return "554 malformed message"
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if err != nil {
+		return "554 malformed subject"
+	}
+	body, err := io.ReadAll(message.Body)
+	if err != nil {
+		return "554 malformed body"
+	}
+
+	s.emails = append(s.emails, Email{To: to, Subject: subject, Body: strings.ReplaceAll(string(body), "\r\n", "\n")})
+	return "250 queued"
+}
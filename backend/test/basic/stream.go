@@ -0,0 +1,60 @@
+package basic
+
+import (
+	"avito/domain"
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// EventStream subscribes to the event stream at the path, resuming after lastEventId when it is
+// not zero, and returns the decoded events as they arrive. The stream is closed when the context is done.
+func EventStream(ctx context.Context, test *Test, path, username string, lastEventId int64) (<-chan domain.StreamEventResp, int) {
+	assert := test.Assertions
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, test.URL+path+"?username="+url.QueryEscape(username), nil)
+	assert.NoError(err)
+	if lastEventId != 0 {
+		req.Header.Set("Last-Event-ID", strconv.FormatInt(lastEventId, 10))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(err)
+
+	events := make(chan domain.StreamEventResp)
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		close(events)
+		return events, resp.StatusCode
+	}
+
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+			if !ok {
+				continue
+			}
+
+			var event domain.StreamEventResp
+			if json.Unmarshal([]byte(data), &event) != nil {
+				return
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, resp.StatusCode
+}
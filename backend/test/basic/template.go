@@ -0,0 +1,65 @@
+package basic
+
+import (
+	"avito/domain"
+	"context"
+	"github.com/txix-open/isp-kit/http/httpcli"
+)
+
+func CreateTemplate(test *Test, orgId, username string, req domain.TemplateReq) (domain.TemplateResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var template domain.TemplateResp
+	resp, err := test.Cli.Post(test.URL + "/api/organizations/" + orgId + "/templates").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&req).
+		JsonResponseBody(&template).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return template, resp
+}
+
+func UpdateTemplate(test *Test, templateId, username string, req domain.TemplateReq) (domain.TemplateResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var template domain.TemplateResp
+	resp, err := test.Cli.Put(test.URL + "/api/templates/" + templateId).
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&req).
+		JsonResponseBody(&template).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return template, resp
+}
+
+func GetTemplates(test *Test, orgId, username string) ([]domain.TemplateResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var templates []domain.TemplateResp
+	resp, err := test.Cli.Get(test.URL + "/api/organizations/" + orgId + "/templates").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&templates).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return templates, resp
+}
+
+func CreateTenderFromTemplate(test *Test, templateId string, req domain.CreateTenderFromTemplateReq) (domain.CreateTenderResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var tender domain.CreateTenderResp
+	resp, err := test.Cli.Post(test.URL + "/api/templates/" + templateId + "/tenders").
+		JsonRequestBody(&req).
+		JsonResponseBody(&tender).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return tender, resp
+}
@@ -91,3 +91,76 @@ func RollbackTender(test *Test, tenderId, username, version string) (domain.Roll
 
 	return tenderRollbackResp, resp
 }
+
+func GetPublishedTenders(test *Test, username string, offset, limit int) ([]domain.GetTendersResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var tendersResp []domain.GetTendersResp
+	resp, err := test.Cli.Get(test.URL + "/api/tenders").
+		QueryParams(map[string]any{"username": username, "offset": offset, "limit": limit}).
+		JsonResponseBody(&tendersResp).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return tendersResp, resp
+}
+
+func InviteTender(test *Test, tenderId, username string, req domain.InviteTenderReq) (domain.TenderInvitationResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var invitationResp domain.TenderInvitationResp
+	resp, err := test.Cli.Post(test.URL + "/api/tenders/" + tenderId + "/invitations").
+		JsonRequestBody(req).
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&invitationResp).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return invitationResp, resp
+}
+
+func ScheduleTender(test *Test, tenderId, username string, req domain.ScheduleTenderReq) (domain.ScheduleTenderResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var scheduleResp domain.ScheduleTenderResp
+	resp, err := test.Cli.Put(test.URL + "/api/tenders/" + tenderId + "/schedule").
+		JsonRequestBody(req).
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&scheduleResp).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return scheduleResp, resp
+}
+
+func CancelTender(test *Test, tenderId, username string, req domain.CancelTenderReq) (domain.TenderCancellationResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var cancellationResp domain.TenderCancellationResp
+	resp, err := test.Cli.Put(test.URL + "/api/tenders/" + tenderId + "/cancel").
+		JsonRequestBody(req).
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&cancellationResp).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return cancellationResp, resp
+}
+
+func GetTenderCancellation(test *Test, tenderId, username string) (domain.TenderCancellationResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var cancellationResp domain.TenderCancellationResp
+	resp, err := test.Cli.Get(test.URL + "/api/tenders/" + tenderId + "/cancellation").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&cancellationResp).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return cancellationResp, resp
+}
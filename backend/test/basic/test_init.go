@@ -20,10 +20,12 @@ type Test struct {
 	Cli        *httpcli.Client
 	DbCli      db.DB
 	TestId     uint32
+	Schema     string
 	URL        string
 }
 
-func InitTest(t *testing.T) *Test {
+// InitTest starts the application in a new schema, the options change the test config.
+func InitTest(t *testing.T, options ...func(cfg *config.Config)) *Test {
 	var (
 		testId = rand.Uint32()
 	)
@@ -31,6 +33,9 @@ func InitTest(t *testing.T) *Test {
 	ctx := context.Background()
 
 	cfg := ConfigDefault().WithSchema("test_" + strconv.Itoa(int(testId)))
+	for _, option := range options {
+		option(cfg)
+	}
 	_, err := cfg.Validate(ctx)
 	assert.NoError(err)
 
@@ -45,6 +50,7 @@ func InitTest(t *testing.T) *Test {
 	assert.NoError(err)
 
 	t.Cleanup(func() {
+		_ = assembler.Close(ctx)
 		_ = dbCli.DropSchema(cfg.DbSchema)
 		srv.Close()
 		err := dbCli.Close()
@@ -56,6 +62,7 @@ func InitTest(t *testing.T) *Test {
 		Server:     srv,
 		DbCli:      dbCli,
 		TestId:     rand.Uint32(),
+		Schema:     cfg.DbSchema,
 		URL:        srv.URL,
 		Cli:        httpcli.New(),
 	}
@@ -0,0 +1,90 @@
+package basic
+
+import (
+	"avito/domain"
+	"context"
+	"github.com/txix-open/isp-kit/http/httpcli"
+)
+
+func CreateWebhook(test *Test, orgId, username string, req domain.CreateWebhookReq) (domain.WebhookResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var webhook domain.WebhookResp
+	resp, err := test.Cli.Post(test.URL + "/api/organizations/" + orgId + "/webhooks").
+		QueryParams(map[string]any{"username": username}).
+		JsonRequestBody(&req).
+		JsonResponseBody(&webhook).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return webhook, resp
+}
+
+func GetWebhooks(test *Test, orgId, username string) ([]domain.WebhookResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var webhooks []domain.WebhookResp
+	resp, err := test.Cli.Get(test.URL + "/api/organizations/" + orgId + "/webhooks").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&webhooks).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return webhooks, resp
+}
+
+func DeleteWebhook(test *Test, webhookId, username string) *httpcli.Response {
+	assert := test.Assertions
+
+	resp, err := test.Cli.Delete(test.URL + "/api/webhooks/" + webhookId).
+		QueryParams(map[string]any{"username": username}).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return resp
+}
+
+func GetWebhookDeliveries(test *Test, webhookId, username, status string) ([]domain.WebhookDeliveryResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var deliveries []domain.WebhookDeliveryResp
+	resp, err := test.Cli.Get(test.URL + "/api/webhooks/" + webhookId + "/deliveries").
+		QueryParams(map[string]any{"username": username, "status": status}).
+		JsonResponseBody(&deliveries).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return deliveries, resp
+}
+
+func GetDeadLetters(test *Test, orgId, username string) ([]domain.WebhookDeliveryResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var deliveries []domain.WebhookDeliveryResp
+	resp, err := test.Cli.Get(test.URL + "/api/organizations/" + orgId + "/webhooks/dead_letters").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&deliveries).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return deliveries, resp
+}
+
+func ReplayDelivery(test *Test, deliveryId, username string) (domain.WebhookDeliveryResp, *httpcli.Response) {
+	assert := test.Assertions
+
+	var delivery domain.WebhookDeliveryResp
+	resp, err := test.Cli.Put(test.URL + "/api/webhook_deliveries/" + deliveryId + "/replay").
+		QueryParams(map[string]any{"username": username}).
+		JsonResponseBody(&delivery).
+		Do(context.Background())
+
+	assert.NoError(err)
+
+	return delivery, resp
+}
@@ -33,9 +33,51 @@ func TestBidCreate(t *testing.T) {
 		AuthorType:  model.BidAuthorTypeOrganization,
 		AuthorId:    martinOrg.EmployeeId,
 	}
+	// TENDER IS NOT PUBLISHED YET
 	_, bidResp := basic.CreateBid(test, bidReq)
+	test.Assertions.Equal(http.StatusBadRequest, bidResp.StatusCode())
+
+	_, tenderResp = basic.SetTenderStatus(test, tender.Id, martinOrg.Username, model.TenderStatusPublished)
+	test.Assertions.Equal(http.StatusOK, tenderResp.StatusCode())
+
+	// TENDER DOES NOT EXIST
+	missingTenderReq := bidReq
+	missingTenderReq.TenderId = "00000000-0000-0000-0000-000000000000"
+	_, bidResp = basic.CreateBid(test, missingTenderReq)
+	test.Assertions.Equal(http.StatusBadRequest, bidResp.StatusCode())
+
+	// AUTHOR DOES NOT EXIST
+	missingAuthorReq := bidReq
+	missingAuthorReq.AuthorId = "00000000-0000-0000-0000-000000000000"
+	_, bidResp = basic.CreateBid(test, missingAuthorReq)
+	test.Assertions.Equal(http.StatusBadRequest, bidResp.StatusCode())
+
+	// BOB HAS NO ORGANIZATION SO HE CAN BID ONLY AS USER
+	bobId, userResp := basic.CreateUser(test, domain.SignupRequest{Username: "Bob", FirstName: "first", LastName: "last"})
+	test.Assertions.Equal(http.StatusOK, userResp.StatusCode())
+
+	bobReq := bidReq
+	bobReq.AuthorId = bobId
+	_, bidResp = basic.CreateBid(test, bobReq)
+	test.Assertions.Equal(http.StatusForbidden, bidResp.StatusCode())
+
+	bobReq.AuthorType = model.BidAuthorTypeUser
+	_, bidResp = basic.CreateBid(test, bobReq)
 	test.Assertions.Equal(http.StatusOK, bidResp.StatusCode())
 
+	// MARTIN IS RESPONSIBLE FOR THE TENDER ORGANIZATION SO HE NEEDS A JUSTIFICATION
+	_, bidResp = basic.CreateBid(test, bidReq)
+	test.Assertions.Equal(http.StatusForbidden, bidResp.StatusCode())
+
+	bidReq.ConflictJustification = "the only certified supplier in the region"
+	_, bidResp = basic.CreateBid(test, bidReq)
+	test.Assertions.Equal(http.StatusOK, bidResp.StatusCode())
+
+	overrides, overridesResp := basic.GetConflictOverrides(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, overridesResp.StatusCode())
+	test.Assertions.Len(overrides, 1)
+	test.Assertions.Equal(bidReq.ConflictJustification, overrides[0].Justification)
+
 	tenders, myResp := basic.GetBidByUsername(test, martinOrg.Username, 0, 3)
 	test.Assertions.Equal(http.StatusOK, myResp.StatusCode())
 	test.Assertions.Len(tenders, 1)
@@ -46,12 +88,13 @@ func TestBidChangeStatus(t *testing.T) {
 	test := basic.InitTest(t)
 
 	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
 
 	tenderReq := domain.CreateTenderReq{
 		Name:            "n1",
 		Description:     "d1",
 		ServiceType:     model.TenderServiceTypeConstruction,
-		Status:          model.TenderStatusCreated,
+		Status:          model.TenderStatusPublished,
 		OrganizationId:  martinOrg.OrgId,
 		CreatorUsername: martinOrg.Username,
 	}
@@ -63,28 +106,43 @@ func TestBidChangeStatus(t *testing.T) {
 		Description: "d1",
 		TenderId:    tender.Id,
 		AuthorType:  model.BidAuthorTypeOrganization,
-		AuthorId:    martinOrg.EmployeeId,
+		AuthorId:    aliceOrg.EmployeeId,
 	}
 	bidResp, resp := basic.CreateBid(test, bidReq)
 	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
 
-	tenders, myResp := basic.GetBidByUsername(test, martinOrg.Username, 0, 3)
+	tenders, myResp := basic.GetBidByUsername(test, aliceOrg.Username, 0, 3)
 	test.Assertions.Equal(http.StatusOK, myResp.StatusCode())
 	test.Assertions.Len(tenders, 1)
 
-	// MARTIN CAN CHANGE TO CANCELED
-	setStatusResp, resp := basic.SetBidStatus(test, bidResp.Id, martinOrg.Username, model.BidStatusCanceled)
+	// ALICE CAN PUBLISH AND MOVE THE BID BACK TO CREATED
+	setStatusResp, resp := basic.SetBidStatus(test, bidResp.Id, aliceOrg.Username, model.BidStatusPublished)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.BidStatusPublished, setStatusResp.Status)
+
+	setStatusResp, resp = basic.SetBidStatus(test, bidResp.Id, aliceOrg.Username, model.BidStatusCreated)
 	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
-	test.Assertions.Equal(setStatusResp.Status, model.BidStatusCanceled)
+	test.Assertions.Equal(model.BidStatusCreated, setStatusResp.Status)
 
 	// WE CAN NOT CHANGE TO REJECTED
-	_, resp = basic.SetBidStatus(test, bidResp.Id, martinOrg.Username, model.BidStatusRejected)
+	_, resp = basic.SetBidStatus(test, bidResp.Id, aliceOrg.Username, model.BidStatusRejected)
 	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
 
 	// ONLY AUTHOR CAN CHANGE STATUS
-	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	_, resp = basic.SetBidStatus(test, bidResp.Id, martinOrg.Username, model.BidStatusPublished)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	// CANCELING GOES THROUGH WITHDRAW
 	_, resp = basic.SetBidStatus(test, bidResp.Id, aliceOrg.Username, model.BidStatusCanceled)
 	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	withdrawn, resp := basic.WithdrawBid(test, bidResp.Id, aliceOrg.Username, domain.WithdrawBidReq{Reason: "r1"})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.BidStatusCanceled, withdrawn.Status)
+
+	// WITHDRAWN BID COMES BACK ONLY THROUGH RESUBMIT
+	_, resp = basic.SetBidStatus(test, bidResp.Id, aliceOrg.Username, model.BidStatusPublished)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
 }
 
 func TestBidEdit(t *testing.T) {
@@ -92,12 +150,13 @@ func TestBidEdit(t *testing.T) {
 	test := basic.InitTest(t)
 
 	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
 
 	tenderReq := domain.CreateTenderReq{
 		Name:            "n1",
 		Description:     "d1",
 		ServiceType:     model.TenderServiceTypeConstruction,
-		Status:          model.TenderStatusCreated,
+		Status:          model.TenderStatusPublished,
 		OrganizationId:  martinOrg.OrgId,
 		CreatorUsername: martinOrg.Username,
 	}
@@ -109,27 +168,26 @@ func TestBidEdit(t *testing.T) {
 		Description: "d1",
 		TenderId:    tender.Id,
 		AuthorType:  model.BidAuthorTypeOrganization,
-		AuthorId:    martinOrg.EmployeeId,
+		AuthorId:    aliceOrg.EmployeeId,
 	}
 	bidResp, resp := basic.CreateBid(test, bidReq)
 	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
 
-	tenders, myResp := basic.GetBidByUsername(test, martinOrg.Username, 0, 3)
+	tenders, myResp := basic.GetBidByUsername(test, aliceOrg.Username, 0, 3)
 	test.Assertions.Equal(http.StatusOK, myResp.StatusCode())
 	test.Assertions.Len(tenders, 1)
 
-	// MARTIN CAN EDIT BECAUSE HE IS AUTHOR
+	// ALICE CAN EDIT BECAUSE SHE IS AUTHOR
 	editBidReq := domain.EditBidReq{
 		Name:        "changed name",
 		Description: "changed description",
 	}
-	editResp, resp := basic.EditBid(test, bidResp.Id, martinOrg.Username, editBidReq)
+	editResp, resp := basic.EditBid(test, bidResp.Id, aliceOrg.Username, editBidReq)
 	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
 	test.Assertions.Equal(editResp.Name, editBidReq.Name)
 
-	// ALICE CAN NOT EDIT BECAUSE SHE IS ALICE
-	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
-	_, resp = basic.EditBid(test, bidResp.Id, aliceOrg.Username, editBidReq)
+	// MARTIN CAN NOT EDIT BECAUSE HE IS NOT AUTHOR
+	_, resp = basic.EditBid(test, bidResp.Id, martinOrg.Username, editBidReq)
 	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
 }
 
@@ -138,12 +196,13 @@ func TestBidRollback(t *testing.T) {
 	test := basic.InitTest(t)
 
 	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
 
 	tenderReq := domain.CreateTenderReq{
 		Name:            "n1",
 		Description:     "d1",
 		ServiceType:     model.TenderServiceTypeConstruction,
-		Status:          model.TenderStatusCreated,
+		Status:          model.TenderStatusPublished,
 		OrganizationId:  martinOrg.OrgId,
 		CreatorUsername: martinOrg.Username,
 	}
@@ -155,12 +214,12 @@ func TestBidRollback(t *testing.T) {
 		Description: "d1",
 		TenderId:    tender.Id,
 		AuthorType:  model.BidAuthorTypeOrganization,
-		AuthorId:    martinOrg.EmployeeId,
+		AuthorId:    aliceOrg.EmployeeId,
 	}
 	bidResp, resp := basic.CreateBid(test, bidReq)
 	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
 
-	tenders, myResp := basic.GetBidByUsername(test, martinOrg.Username, 0, 3)
+	tenders, myResp := basic.GetBidByUsername(test, aliceOrg.Username, 0, 3)
 	test.Assertions.Equal(http.StatusOK, myResp.StatusCode())
 	test.Assertions.Len(tenders, 1)
 
@@ -168,18 +227,17 @@ func TestBidRollback(t *testing.T) {
 		Name:        "changed name",
 		Description: "changed description",
 	}
-	editResp, resp := basic.EditBid(test, bidResp.Id, martinOrg.Username, editBidReq)
+	editResp, resp := basic.EditBid(test, bidResp.Id, aliceOrg.Username, editBidReq)
 	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
 	test.Assertions.Equal(editResp.Name, editBidReq.Name)
 
-	// MARTIN CAN ROLL IT BACK BECAUSE HE IS AUTHOR
-	rollBackResp, resp := basic.RollbackBid(test, bidResp.Id, martinOrg.Username, "1")
+	// ALICE CAN ROLL IT BACK BECAUSE SHE IS AUTHOR
+	rollBackResp, resp := basic.RollbackBid(test, bidResp.Id, aliceOrg.Username, "1")
 	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
 	test.Assertions.Equal(bidReq.Name, rollBackResp.Name)
 
-	// ALICE CAN NOT ROLL IT BACK BECAUSE SHE IS ALICE
-	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
-	_, resp = basic.RollbackBid(test, bidResp.Id, aliceOrg.Username, "1")
+	// MARTIN CAN NOT ROLL IT BACK BECAUSE HE IS NOT AUTHOR
+	_, resp = basic.RollbackBid(test, bidResp.Id, martinOrg.Username, "1")
 	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
 }
 
@@ -188,12 +246,13 @@ func TestBidSubmitDecision(t *testing.T) {
 	test := basic.InitTest(t)
 
 	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
 
 	tenderReq := domain.CreateTenderReq{
 		Name:            "n1",
 		Description:     "d1",
 		ServiceType:     model.TenderServiceTypeConstruction,
-		Status:          model.TenderStatusCreated,
+		Status:          model.TenderStatusPublished,
 		OrganizationId:  martinOrg.OrgId,
 		CreatorUsername: martinOrg.Username,
 	}
@@ -205,12 +264,12 @@ func TestBidSubmitDecision(t *testing.T) {
 		Description: "d1",
 		TenderId:    tender.Id,
 		AuthorType:  model.BidAuthorTypeOrganization,
-		AuthorId:    martinOrg.EmployeeId,
+		AuthorId:    aliceOrg.EmployeeId,
 	}
 	bidResp, resp := basic.CreateBid(test, bidReq)
 	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
 
-	tenders, myResp := basic.GetBidByUsername(test, martinOrg.Username, 0, 3)
+	tenders, myResp := basic.GetBidByUsername(test, aliceOrg.Username, 0, 3)
 	test.Assertions.Equal(http.StatusOK, myResp.StatusCode())
 	test.Assertions.Len(tenders, 1)
 
@@ -218,13 +277,12 @@ func TestBidSubmitDecision(t *testing.T) {
 	submitDecResp, resp := basic.SubmitDecisionBid(test, bidResp.Id, martinOrg.Username, "Approved")
 	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
 
-	// MARTIN CAN CHANGE TO PUBLISHED
-	setStatusResp, resp := basic.SetBidStatus(test, bidResp.Id, martinOrg.Username, model.BidStatusPublished)
+	// ALICE CAN CHANGE TO PUBLISHED
+	setStatusResp, resp := basic.SetBidStatus(test, bidResp.Id, aliceOrg.Username, model.BidStatusPublished)
 	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
 	test.Assertions.Equal(setStatusResp.Status, model.BidStatusPublished)
 
 	// ALICE CAN NOT APPROVE IT BECAUSE SHE IS NOT FROM ORGANIZATION OF THE TENDER
-	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
 	submitDecResp, resp = basic.SubmitDecisionBid(test, bidResp.Id, aliceOrg.Username, "Approved")
 	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
 
@@ -244,7 +302,7 @@ func TestBidSubmitFeedback(t *testing.T) {
 		Name:            "n1",
 		Description:     "d1",
 		ServiceType:     model.TenderServiceTypeConstruction,
-		Status:          model.TenderStatusCreated,
+		Status:          model.TenderStatusPublished,
 		OrganizationId:  martinOrg.OrgId,
 		CreatorUsername: martinOrg.Username,
 	}
@@ -257,6 +315,8 @@ func TestBidSubmitFeedback(t *testing.T) {
 		TenderId:    tender.Id,
 		AuthorType:  model.BidAuthorTypeOrganization,
 		AuthorId:    martinOrg.EmployeeId,
+
+		ConflictJustification: "bidding on our own tender to compare offers",
 	}
 	bidResp, resp := basic.CreateBid(test, bidReq)
 	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
@@ -284,3 +344,317 @@ func TestBidSubmitFeedback(t *testing.T) {
 	_, resp = basic.ReviewBid(test, tender.Id, martinOrg.Username, aliceOrg.Username, 0, 1)
 	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
 }
+
+func TestBidOrganizationAuthor(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	charlieOrg := basic.CreateOrgEmployee(test, "Charlie")
+
+	bobId, resp := basic.CreateUser(test, domain.SignupRequest{Username: "Bob", FirstName: "first", LastName: "last"})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	_, resp = basic.Bond(test, bobId, aliceOrg.OrgId)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	}
+	tender, resp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bidReq := domain.CreateBidReq{
+		Name:        "n1",
+		Description: "d1",
+		TenderId:    tender.Id,
+		AuthorType:  model.BidAuthorTypeOrganization,
+		AuthorId:    aliceOrg.EmployeeId,
+	}
+
+	// ALICE CAN NOT BID ON BEHALF OF CHARLIE'S ORGANIZATION
+	foreignReq := bidReq
+	foreignReq.OrganizationId = charlieOrg.OrgId
+	_, resp = basic.CreateBid(test, foreignReq)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	// THE ORGANIZATION IS SHOWN AS THE AUTHOR
+	bid, resp := basic.CreateBid(test, bidReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(aliceOrg.OrgId, bid.AuthorId)
+
+	// BOB MANAGES THE BID BECAUSE HE IS RESPONSIBLE FOR ALICE'S ORGANIZATION
+	bids, resp := basic.GetBidByUsername(test, "Bob", 0, 5)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(bids, 1)
+
+	editResp, resp := basic.EditBid(test, bid.Id, "Bob", domain.EditBidReq{Name: "changed", Description: "changed"})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal("changed", editResp.Name)
+
+	_, resp = basic.RollbackBid(test, bid.Id, "Bob", "1")
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SetBidStatus(test, bid.Id, "Bob", model.BidStatusPublished)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// CHARLIE CAN NOT BECAUSE HE IS FROM ANOTHER ORGANIZATION
+	_, resp = basic.EditBid(test, bid.Id, charlieOrg.Username, domain.EditBidReq{Name: "changed", Description: "changed"})
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+}
+
+func TestBidWithdrawResubmit(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	}
+	tender, resp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bidReq := domain.CreateBidReq{
+		Name:        "n1",
+		Description: "d1",
+		TenderId:    tender.Id,
+		AuthorType:  model.BidAuthorTypeUser,
+		AuthorId:    aliceOrg.EmployeeId,
+	}
+	bid, resp := basic.CreateBid(test, bidReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// ALICE CAN NOT PLACE A SECOND ACTIVE BID
+	_, resp = basic.CreateBid(test, bidReq)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	// REASON IS REQUIRED
+	_, resp = basic.WithdrawBid(test, bid.Id, aliceOrg.Username, domain.WithdrawBidReq{})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	// MARTIN CAN NOT WITHDRAW BECAUSE HE IS NOT AUTHOR
+	reason := "price has changed"
+	_, resp = basic.WithdrawBid(test, bid.Id, martinOrg.Username, domain.WithdrawBidReq{Reason: reason})
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	withdrawn, resp := basic.WithdrawBid(test, bid.Id, aliceOrg.Username, domain.WithdrawBidReq{Reason: reason})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.BidStatusCanceled, withdrawn.Status)
+	test.Assertions.Equal(reason, withdrawn.Reason)
+
+	// THE WITHDRAWN BID CAN NOT BE WITHDRAWN AGAIN
+	_, resp = basic.WithdrawBid(test, bid.Id, aliceOrg.Username, domain.WithdrawBidReq{Reason: reason})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	resubmitted, resp := basic.ResubmitBid(test, bid.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.BidStatusCreated, resubmitted.Status)
+	test.Assertions.Equal(withdrawn.Version+1, resubmitted.Version)
+	test.Assertions.Equal(bidReq.Name, resubmitted.Name)
+
+	// THE REOPENED BID IS ACTIVE AGAIN
+	_, resp = basic.ResubmitBid(test, bid.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.CreateBid(test, bidReq)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	// NOTHING CAN BE RESUBMITTED AFTER THE TENDER IS CLOSED
+	_, resp = basic.WithdrawBid(test, bid.Id, aliceOrg.Username, domain.WithdrawBidReq{Reason: reason})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SetTenderStatus(test, tender.Id, martinOrg.Username, model.TenderStatusClosed)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.ResubmitBid(test, bid.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+}
+
+func TestBidListByTender(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+	charlieOrg := basic.CreateOrgEmployee(test, "Charlie")
+
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	}
+	tender, resp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	for _, author := range []basic.EmployeeOrg{aliceOrg, bobOrg} {
+		_, resp = basic.CreateBid(test, domain.CreateBidReq{
+			Name:        "bid of " + author.Username,
+			Description: "d1",
+			TenderId:    tender.Id,
+			AuthorType:  model.BidAuthorTypeUser,
+			AuthorId:    author.EmployeeId,
+		})
+		test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	}
+
+	bids, resp := basic.GetBidByUsername(test, aliceOrg.Username, 0, 5)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	_, resp = basic.SetBidStatus(test, bids[0].Id, aliceOrg.Username, model.BidStatusPublished)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// MARTIN SEES ONLY THE PUBLISHED BID OF ALICE
+	bids, resp = basic.GetBidsByTenderId(test, tender.Id, martinOrg.Username, 0, 5)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(bids, 1)
+	test.Assertions.Equal(aliceOrg.EmployeeId, bids[0].AuthorId)
+
+	// BOB SEES HIS OWN DRAFT ONLY
+	bids, resp = basic.GetBidsByTenderId(test, tender.Id, bobOrg.Username, 0, 5)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(bids, 1)
+	test.Assertions.Equal(model.BidStatusCreated, bids[0].Status)
+
+	// CHARLIE HAS NOTHING TO DO WITH THE TENDER
+	_, resp = basic.GetBidsByTenderId(test, tender.Id, charlieOrg.Username, 0, 5)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	_, resp = basic.GetBidsByTenderId(test, "00000000-0000-0000-0000-000000000000", martinOrg.Username, 0, 5)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+}
+
+func TestBidConflictOverridesAnonymized(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+		Anonymized:      true,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.CreateBid(test, domain.CreateBidReq{
+		Name:                  "n1",
+		Description:           "d1",
+		TenderId:              tender.Id,
+		AuthorType:            model.BidAuthorTypeOrganization,
+		AuthorId:              martinOrg.EmployeeId,
+		ConflictJustification: "the only certified supplier in the region",
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// THE AUTHOR IS HIDDEN WHILE THE TENDER IS OPEN
+	overrides, resp := basic.GetConflictOverrides(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(overrides, 1)
+	test.Assertions.Equal("Bidder 1", overrides[0].UserId)
+	test.Assertions.Nil(overrides[0].OrganizationId)
+
+	_, resp = basic.SetTenderStatus(test, tender.Id, martinOrg.Username, model.TenderStatusClosed)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	overrides, resp = basic.GetConflictOverrides(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(overrides, 1)
+	test.Assertions.Equal(martinOrg.EmployeeId, overrides[0].UserId)
+	test.Assertions.Equal(martinOrg.OrgId, *overrides[0].OrganizationId)
+}
+
+func TestBidListAnonymized(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+	charlieOrg := basic.CreateOrgEmployee(test, "Charlie")
+
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+		Anonymized:      true,
+	}
+	tender, resp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.True(tender.Anonymized)
+
+	// CHARLIE BIDS ON BEHALF OF HIS ORGANIZATION
+	bidIds := make([]string, 0, 3)
+	authorTypes := []model.BidAuthorType{model.BidAuthorTypeUser, model.BidAuthorTypeUser, model.BidAuthorTypeOrganization}
+	for i, author := range []basic.EmployeeOrg{aliceOrg, bobOrg, charlieOrg} {
+		bid, resp := basic.CreateBid(test, domain.CreateBidReq{
+			Name:        "bid of " + author.Username,
+			Description: "d1",
+			TenderId:    tender.Id,
+			AuthorType:  authorTypes[i],
+			AuthorId:    author.EmployeeId,
+		})
+		test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+		_, resp = basic.SetBidStatus(test, bid.Id, author.Username, model.BidStatusPublished)
+		test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+		bidIds = append(bidIds, bid.Id)
+	}
+
+	// MARTIN SEES THE PSEUDONYMS ONLY
+	bids, resp := basic.GetBidsByTenderId(test, tender.Id, martinOrg.Username, 0, 5)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(bids, 3)
+	pseudonyms := make(map[string]string, len(bids))
+	for _, bid := range bids {
+		pseudonyms[bid.Id] = bid.AuthorId
+	}
+	test.Assertions.Equal("Bidder 1", pseudonyms[bidIds[0]])
+	test.Assertions.Equal("Bidder 2", pseudonyms[bidIds[1]])
+	test.Assertions.Equal("Bidder 3", pseudonyms[bidIds[2]])
+
+	feedback, resp := basic.SubmitFeedbackBid(test, bidIds[1], martinOrg.Username, "good")
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal("Bidder 2", feedback.AuthorId)
+
+	// THE AUTHORS STILL SEE THEIR OWN BIDS
+	bids, resp = basic.GetBidsByTenderId(test, tender.Id, aliceOrg.Username, 0, 5)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(bids, 1)
+	test.Assertions.Equal(aliceOrg.EmployeeId, bids[0].AuthorId)
+
+	// THE IDENTITIES ARE REVEALED ONCE THE TENDER IS CLOSED
+	decision, resp := basic.SubmitDecisionBid(test, bidIds[0], martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(aliceOrg.EmployeeId, decision.AuthorId)
+
+	bids, resp = basic.GetBidsByTenderId(test, tender.Id, martinOrg.Username, 0, 5)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	for _, bid := range bids {
+		test.Assertions.NotContains(bid.AuthorId, "Bidder")
+		if bid.Id == bidIds[2] {
+			test.Assertions.Equal(charlieOrg.OrgId, bid.AuthorId)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package test
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/test/basic"
+	"net/http"
+	"testing"
+)
+
+func TestComplaintFreezesAward(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bidIds := basic.CreatePublishedBids(test, tender.Id, aliceOrg, bobOrg)
+
+	// NO COMPLAINTS BEFORE THE DECISION
+	_, resp = basic.FileComplaint(test, tender.Id, bobOrg.Username, domain.FileComplaintReq{
+		BidId: bidIds[bobOrg.Username], Reason: "unfair", FreezeAward: true,
+	})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.SubmitDecisionBid(test, bidIds[aliceOrg.Username], martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// THE WINNER HAS NOTHING TO COMPLAIN ABOUT
+	_, resp = basic.FileComplaint(test, tender.Id, aliceOrg.Username, domain.FileComplaintReq{
+		BidId: bidIds[aliceOrg.Username], Reason: "unfair",
+	})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.FileComplaint(test, tender.Id, aliceOrg.Username, domain.FileComplaintReq{
+		BidId: bidIds[bobOrg.Username], Reason: "unfair",
+	})
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	complaint, resp := basic.FileComplaint(test, tender.Id, bobOrg.Username, domain.FileComplaintReq{
+		BidId: bidIds[bobOrg.Username], Reason: "unfair", FreezeAward: true,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.ComplaintStatusFiled, complaint.Status)
+	test.Assertions.Equal(bobOrg.EmployeeId, complaint.UserId)
+
+	_, resp = basic.FileComplaint(test, tender.Id, bobOrg.Username, domain.FileComplaintReq{
+		BidId: bidIds[bobOrg.Username], Reason: "still unfair",
+	})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	complaints, resp := basic.GetComplaints(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(complaints, 1)
+
+	complaints, resp = basic.GetComplaints(test, tender.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Empty(complaints)
+
+	_, resp = basic.GetComplaint(test, complaint.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	// THE AWARD IS FROZEN WHILE THE COMPLAINT IS OPEN
+	_, resp = basic.SignContract(test, tender.Id, aliceOrg.Username, model.ContractSideSupplier)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.SetComplaintStatus(test, complaint.Id, bobOrg.Username, model.ComplaintStatusUnderReview, "")
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	complaint, resp = basic.SetComplaintStatus(test, complaint.Id, martinOrg.Username, model.ComplaintStatusUnderReview, "")
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.ComplaintStatusUnderReview, complaint.Status)
+	test.Assertions.Empty(complaint.Responses)
+
+	_, resp = basic.RespondComplaint(test, complaint.Id, martinOrg.Username, "we are checking the scores")
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SetComplaintStatus(test, complaint.Id, martinOrg.Username, model.ComplaintStatusDismissed, "")
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	complaint, resp = basic.SetComplaintStatus(test, complaint.Id, martinOrg.Username, model.ComplaintStatusDismissed, "scores are correct")
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.ComplaintStatusDismissed, complaint.Status)
+	test.Assertions.NotNil(complaint.ResolvedAt)
+	test.Assertions.Len(complaint.Responses, 2)
+	test.Assertions.Nil(complaint.Responses[0].Status)
+	test.Assertions.Equal(model.ComplaintStatusDismissed, *complaint.Responses[1].Status)
+
+	complaint, resp = basic.GetComplaint(test, complaint.Id, bobOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(complaint.Responses, 2)
+
+	_, resp = basic.SetComplaintStatus(test, complaint.Id, martinOrg.Username, model.ComplaintStatusUpheld, "changed our mind")
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.SignContract(test, tender.Id, aliceOrg.Username, model.ContractSideSupplier)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+}
+
+func TestComplaintOnCanceledTender(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bidIds := basic.CreatePublishedBids(test, tender.Id, bobOrg)
+
+	_, resp = basic.CancelTender(test, tender.Id, martinOrg.Username, domain.CancelTenderReq{Reason: "budget cut"})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// THE CANCELLATION IS NOT A DECISION TO COMPLAIN ABOUT
+	_, resp = basic.FileComplaint(test, tender.Id, bobOrg.Username, domain.FileComplaintReq{
+		BidId: bidIds[bobOrg.Username], Reason: "unfair",
+	})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+}
+
+func TestComplaintOnRejectedBid(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bidIds := basic.CreatePublishedBids(test, tender.Id, bobOrg)
+
+	// THE REJECTION CLOSES THE TENDER WITHOUT A WINNER
+	_, resp = basic.SubmitDecisionBid(test, bidIds[bobOrg.Username], martinOrg.Username, string(model.Rejected))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	complaint, resp := basic.FileComplaint(test, tender.Id, bobOrg.Username, domain.FileComplaintReq{
+		BidId: bidIds[bobOrg.Username], Reason: "unfair",
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.ComplaintStatusFiled, complaint.Status)
+}
@@ -0,0 +1,164 @@
+package test
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/test/basic"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestContractSigning(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	}
+	tender, resp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bid, resp := basic.CreateBid(test, domain.CreateBidReq{
+		Name:        "bid of Alice",
+		Description: "d1",
+		TenderId:    tender.Id,
+		AuthorType:  model.BidAuthorTypeUser,
+		AuthorId:    aliceOrg.EmployeeId,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SetBidStatus(test, bid.Id, aliceOrg.Username, model.BidStatusPublished)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// NO CONTRACT BEFORE THE DECISION
+	_, resp = basic.GetContract(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.SubmitDecisionBid(test, bid.Id, martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	contract, resp := basic.GetContract(test, tender.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(bid.Id, contract.BidId)
+	test.Assertions.Positive(contract.BidVersion)
+	test.Assertions.Equal(martinOrg.OrgId, contract.BuyerOrganizationId)
+	test.Assertions.Equal(aliceOrg.EmployeeId, contract.SupplierId)
+	test.Assertions.Equal(model.ContractStatusPending, contract.Status)
+
+	// BOB IS NOT A PARTY OF THE CONTRACT
+	_, resp = basic.GetContract(test, tender.Id, bobOrg.Username)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	_, resp = basic.SignContract(test, tender.Id, aliceOrg.Username, model.ContractSideBuyer)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	contract, resp = basic.SignContract(test, tender.Id, aliceOrg.Username, model.ContractSideSupplier)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.ContractStatusPending, contract.Status)
+	test.Assertions.NotNil(contract.SupplierSignedAt)
+
+	_, resp = basic.SignContract(test, tender.Id, aliceOrg.Username, model.ContractSideSupplier)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	contract, resp = basic.SignContract(test, tender.Id, martinOrg.Username, model.ContractSideBuyer)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.ContractStatusSigned, contract.Status)
+	test.Assertions.Equal(martinOrg.EmployeeId, *contract.BuyerSignerId)
+}
+
+func TestContractMilestones(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeDelivery,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bid, resp := basic.CreateBid(test, domain.CreateBidReq{
+		Name:        "bid of Alice",
+		Description: "d1",
+		TenderId:    tender.Id,
+		AuthorType:  model.BidAuthorTypeUser,
+		AuthorId:    aliceOrg.EmployeeId,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SetBidStatus(test, bid.Id, aliceOrg.Username, model.BidStatusPublished)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SubmitDecisionBid(test, bid.Id, martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// ONLY THE BUYER PLANS MILESTONES
+	_, resp = basic.CreateMilestone(test, tender.Id, aliceOrg.Username, domain.CreateMilestoneReq{
+		Description: "first batch", DueDate: time.Now().Add(24 * time.Hour), Amount: 100,
+	})
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	first, resp := basic.CreateMilestone(test, tender.Id, martinOrg.Username, domain.CreateMilestoneReq{
+		Description: "first batch", DueDate: time.Now().Add(24 * time.Hour), Amount: 100,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.MilestoneStatusPending, first.Status)
+
+	late, resp := basic.CreateMilestone(test, tender.Id, martinOrg.Username, domain.CreateMilestoneReq{
+		Description: "second batch", DueDate: time.Now().Add(-24 * time.Hour), Amount: 50,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	milestones, resp := basic.GetMilestones(test, tender.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(milestones, 2)
+
+	overdue, resp := basic.GetOverdueMilestones(test, martinOrg.OrgId, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(overdue, 1)
+	test.Assertions.Equal(late.Id, overdue[0].Id)
+
+	// NOTHING TO ACCEPT BEFORE THE DELIVERY
+	_, resp = basic.MoveMilestone(test, first.Id, martinOrg.Username, "accept")
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.MoveMilestone(test, first.Id, martinOrg.Username, "deliver")
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	delivered, resp := basic.MoveMilestone(test, first.Id, aliceOrg.Username, "deliver")
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.MilestoneStatusDelivered, delivered.Status)
+	test.Assertions.NotNil(delivered.DeliveredAt)
+
+	_, resp = basic.DisputeMilestone(test, first.Id, martinOrg.Username, "")
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	disputed, resp := basic.DisputeMilestone(test, first.Id, martinOrg.Username, "broken packaging")
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.MilestoneStatusDisputed, disputed.Status)
+	test.Assertions.Equal("broken packaging", *disputed.DisputeReason)
+
+	_, resp = basic.MoveMilestone(test, first.Id, aliceOrg.Username, "deliver")
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	accepted, resp := basic.MoveMilestone(test, first.Id, martinOrg.Username, "accept")
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.MilestoneStatusAccepted, accepted.Status)
+	test.Assertions.NotNil(accepted.ResolvedAt)
+}
@@ -0,0 +1,161 @@
+package test
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/test/basic"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSealedBidsOpenedExplicitly(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+		Sealed:          true,
+	}
+
+	// SEALED TENDER NEEDS A DEADLINE
+	_, resp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	deadline := time.Now().Add(time.Hour)
+	tenderReq.BidDeadline = &deadline
+	tender, resp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.True(tender.Sealed)
+
+	bidReq := domain.CreateBidReq{
+		Name:        "secret offer",
+		Description: "secret price",
+		TenderId:    tender.Id,
+		AuthorType:  model.BidAuthorTypeUser,
+		AuthorId:    aliceOrg.EmployeeId,
+	}
+	bid, resp := basic.CreateBid(test, bidReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SetBidStatus(test, bid.Id, aliceOrg.Username, model.BidStatusPublished)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+	draft, resp := basic.CreateBid(test, domain.CreateBidReq{
+		Name:        "late offer",
+		Description: "late price",
+		TenderId:    tender.Id,
+		AuthorType:  model.BidAuthorTypeUser,
+		AuthorId:    bobOrg.EmployeeId,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// THE BIDS ARE NOT DECIDED ON BEFORE THE OPENING
+	_, resp = basic.SubmitDecisionBid(test, bid.Id, martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	// MARTIN SEES ONLY THE METADATA
+	bids, resp := basic.GetBidsByTenderId(test, tender.Id, martinOrg.Username, 0, 5)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(bids, 1)
+	test.Assertions.Empty(bids[0].Name)
+
+	_, resp = basic.GetBidContent(test, bid.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	// ALICE CAN READ HER OWN BID
+	content, resp := basic.GetBidContent(test, bid.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(bidReq.Name, content.Name)
+	test.Assertions.Equal(bidReq.Description, content.Description)
+
+	_, resp = basic.GetEnvelopeOpening(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	// ALICE CAN NOT OPEN BECAUSE SHE IS NOT FROM THE ORGANIZATION
+	_, resp = basic.OpenEnvelopes(test, tender.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	opening, resp := basic.OpenEnvelopes(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.False(opening.Automatic)
+	test.Assertions.Equal(martinOrg.EmployeeId, *opening.OpenedBy)
+
+	_, resp = basic.OpenEnvelopes(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	content, resp = basic.GetBidContent(test, bid.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(bidReq.Name, content.Name)
+
+	bids, resp = basic.GetBidsByTenderId(test, tender.Id, martinOrg.Username, 0, 5)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(bidReq.Name, bids[0].Name)
+
+	// BIDS CAN NOT BE CHANGED OR PUBLISHED AFTER THE OPENING
+	_, resp = basic.EditBid(test, bid.Id, aliceOrg.Username, domain.EditBidReq{Name: "better offer", Description: "d2"})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.SetBidStatus(test, draft.Id, bobOrg.Username, model.BidStatusPublished)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.SubmitDecisionBid(test, bid.Id, martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+}
+
+func TestSealedBidsOpenedAtDeadline(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	deadline := time.Now().Add(time.Second)
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+		Sealed:          true,
+		BidDeadline:     &deadline,
+	}
+	tender, resp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bidReq := domain.CreateBidReq{
+		Name:        "secret offer",
+		Description: "secret price",
+		TenderId:    tender.Id,
+		AuthorType:  model.BidAuthorTypeUser,
+		AuthorId:    aliceOrg.EmployeeId,
+	}
+	bid, resp := basic.CreateBid(test, bidReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	test.Assertions.Eventually(func() bool {
+		opening, resp := basic.GetEnvelopeOpening(test, tender.Id, martinOrg.Username)
+		return resp.StatusCode() == http.StatusOK && opening.Automatic
+	}, 5*time.Second, 100*time.Millisecond)
+
+	content, resp := basic.GetBidContent(test, bid.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(bidReq.Description, content.Description)
+
+	// NO MORE BIDS AFTER THE DEADLINE
+	_, resp = basic.WithdrawBid(test, bid.Id, aliceOrg.Username, domain.WithdrawBidReq{Reason: "changed my mind"})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.ResubmitBid(test, bid.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+}
@@ -0,0 +1,175 @@
+package test
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/test/basic"
+	"net/http"
+	"testing"
+)
+
+func TestEvaluationRanking(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	}
+	tender, tenderResp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, tenderResp.StatusCode())
+
+	// WEIGHTS MUST BE POSITIVE
+	_, resp := basic.SetCriteria(test, tender.Id, martinOrg.Username, domain.SetCriteriaReq{
+		Criteria: []domain.CriterionReq{{Name: "price", Weight: -1}},
+	})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	criteria, resp := basic.SetCriteria(test, tender.Id, martinOrg.Username, domain.SetCriteriaReq{
+		Criteria: []domain.CriterionReq{{Name: "price", Weight: 3}, {Name: "experience", Weight: 1}},
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(criteria, 2)
+	price, experience := criteria[0], criteria[1]
+
+	bidIds := basic.CreatePublishedBids(test, tender.Id, aliceOrg, bobOrg)
+
+	// ALICE CAN NOT SCORE BECAUSE SHE IS NOT FROM THE ORGANIZATION
+	_, resp = basic.ScoreBid(test, bidIds[bobOrg.Username], aliceOrg.Username, domain.ScoreBidReq{
+		Scores: []domain.ScoreReq{{CriterionId: price.Id, Score: 10}},
+	})
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	// A CRITERION CAN BE SCORED ONCE PER REQUEST
+	_, resp = basic.ScoreBid(test, bidIds[aliceOrg.Username], martinOrg.Username, domain.ScoreBidReq{
+		Scores: []domain.ScoreReq{{CriterionId: price.Id, Score: 4}, {CriterionId: price.Id, Score: 5}},
+	})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.ScoreBid(test, bidIds[aliceOrg.Username], martinOrg.Username, domain.ScoreBidReq{
+		Scores: []domain.ScoreReq{{CriterionId: price.Id, Score: 4}, {CriterionId: experience.Id, Score: 10}},
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	scores, resp := basic.ScoreBid(test, bidIds[bobOrg.Username], martinOrg.Username, domain.ScoreBidReq{
+		Scores: []domain.ScoreReq{{CriterionId: price.Id, Score: 8, Comment: "cheapest"}, {CriterionId: experience.Id, Score: 2}},
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(scores, 2)
+
+	// CRITERIA ARE LOCKED ONCE SCORING STARTED
+	_, resp = basic.SetCriteria(test, tender.Id, martinOrg.Username, domain.SetCriteriaReq{
+		Criteria: []domain.CriterionReq{{Name: "price", Weight: 1}},
+	})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	ranking, resp := basic.GetRanking(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(ranking, 2)
+	test.Assertions.Equal(bidIds[bobOrg.Username], ranking[0].BidId)
+	test.Assertions.InDelta(6.5, ranking[0].Score, 0.001)
+	test.Assertions.Equal(bidIds[aliceOrg.Username], ranking[1].BidId)
+	test.Assertions.InDelta(5.5, ranking[1].Score, 0.001)
+
+	// SCORES ARE IMMUTABLE AFTER THE TENDER IS CLOSED
+	_, resp = basic.SetTenderStatus(test, tender.Id, martinOrg.Username, model.TenderStatusClosed)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.ScoreBid(test, bidIds[aliceOrg.Username], martinOrg.Username, domain.ScoreBidReq{
+		Scores: []domain.ScoreReq{{CriterionId: price.Id, Score: 10}},
+	})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+}
+
+func TestTwoStageShortlist(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+
+	tender, tenderResp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+		TwoStage:        true,
+	})
+	test.Assertions.Equal(http.StatusOK, tenderResp.StatusCode())
+	test.Assertions.True(tender.TwoStage)
+
+	// COMMERCIAL PART IS REQUIRED BY THE TWO-STAGE TENDER
+	_, resp := basic.CreateBid(test, domain.CreateBidReq{
+		Name:        "bid of Alice",
+		Description: "d1",
+		TenderId:    tender.Id,
+		AuthorType:  model.BidAuthorTypeUser,
+		AuthorId:    aliceOrg.EmployeeId,
+	})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	bidIds := make([]string, 0, 2)
+	for i, author := range []basic.EmployeeOrg{aliceOrg, bobOrg} {
+		bid, resp := basic.CreateBid(test, domain.CreateBidReq{
+			Name:        "bid of " + author.Username,
+			Description: "d1",
+			TenderId:    tender.Id,
+			AuthorType:  model.BidAuthorTypeUser,
+			AuthorId:    author.EmployeeId,
+			Commercial:  &domain.CommercialPartReq{Price: float64(100 * (i + 1)), Terms: "30 days"},
+		})
+		test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+		_, resp = basic.SetBidStatus(test, bid.Id, author.Username, model.BidStatusPublished)
+		test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+		bidIds = append(bidIds, bid.Id)
+	}
+
+	// THE AUTHOR ALWAYS SEES THE COMMERCIAL PART
+	part, resp := basic.GetBidCommercial(test, bidIds[0], aliceOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.InDelta(100, part.Price, 0.001)
+
+	// THE TENDER ORGANIZATION DOES NOT SEE IT DURING THE TECHNICAL STAGE
+	_, resp = basic.GetBidCommercial(test, bidIds[0], martinOrg.Username)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	_, resp = basic.SubmitDecisionBid(test, bidIds[0], martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	shortlist, resp := basic.Shortlist(test, tender.Id, martinOrg.Username, domain.ShortlistReq{BidIds: bidIds[:1]})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(shortlist, 1)
+	test.Assertions.Equal(bidIds[0], shortlist[0].BidId)
+
+	// THE TECHNICAL STAGE IS CLOSED ONCE
+	_, resp = basic.Shortlist(test, tender.Id, martinOrg.Username, domain.ShortlistReq{BidIds: bidIds[1:]})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.EditBid(test, bidIds[1], bobOrg.Username, domain.EditBidReq{Name: "n2"})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	part, resp = basic.GetBidCommercial(test, bidIds[0], martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal("30 days", part.Terms)
+
+	_, resp = basic.GetBidCommercial(test, bidIds[1], martinOrg.Username)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	_, resp = basic.SubmitDecisionBid(test, bidIds[1], martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.SubmitDecisionBid(test, bidIds[0], martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+}
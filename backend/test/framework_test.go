@@ -0,0 +1,154 @@
+package test
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/test/basic"
+	"net/http"
+	"testing"
+)
+
+func TestFrameworkCallOffOrders(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+	carolOrg := basic.CreateOrgEmployee(test, "Carol")
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeDelivery,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+		Framework:       true,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.True(tender.Framework)
+
+	bidIds := basic.CreatePublishedBids(test, tender.Id, aliceOrg, bobOrg, carolOrg)
+
+	// SEVERAL SUPPLIERS ARE APPROVED AND THE FRAMEWORK STAYS OPEN
+	_, resp = basic.SubmitDecisionBid(test, bidIds[aliceOrg.Username], martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SubmitDecisionBid(test, bidIds[bobOrg.Username], martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SubmitDecisionBid(test, bidIds[carolOrg.Username], martinOrg.Username, string(model.Rejected))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	status, resp := basic.GetTenderStatus(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.TenderStatusPublished, status)
+
+	_, resp = basic.GetContract(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	// CALL-OFF ORDERS GO TO THE APPROVED SUPPLIERS ONLY
+	_, resp = basic.IssueCallOffOrder(test, tender.Id, martinOrg.Username, domain.CallOffOrderReq{
+		BidId: bidIds[carolOrg.Username], Description: "100 pallets", Amount: 1000,
+	})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.IssueCallOffOrder(test, tender.Id, aliceOrg.Username, domain.CallOffOrderReq{
+		BidId: bidIds[aliceOrg.Username], Description: "100 pallets", Amount: 1000,
+	})
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	order, resp := basic.IssueCallOffOrder(test, tender.Id, martinOrg.Username, domain.CallOffOrderReq{
+		BidId: bidIds[aliceOrg.Username], Description: "100 pallets", Amount: 1000,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(bidIds[aliceOrg.Username], order.BidId)
+	test.Assertions.Equal(martinOrg.EmployeeId, order.UserId)
+
+	// ORDERS ARE ISSUED AFTER THE FRAMEWORK IS CLOSED FOR NEW SUPPLIERS
+	_, resp = basic.SetTenderStatus(test, tender.Id, martinOrg.Username, model.TenderStatusClosed)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.IssueCallOffOrder(test, tender.Id, martinOrg.Username, domain.CallOffOrderReq{
+		BidId: bidIds[bobOrg.Username], Description: "50 pallets", Amount: 500,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	orders, resp := basic.GetCallOffOrders(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(orders, 2)
+
+	orders, resp = basic.GetCallOffOrders(test, tender.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(orders, 1)
+	test.Assertions.Equal(order.Id, orders[0].Id)
+
+	orders, resp = basic.GetCallOffOrders(test, tender.Id, carolOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Empty(orders)
+
+	// AN OPEN COMPLAINT FREEZES THE ORDERS
+	_, resp = basic.FileComplaint(test, tender.Id, carolOrg.Username, domain.FileComplaintReq{
+		BidId: bidIds[carolOrg.Username], Reason: "unfair", FreezeAward: true,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.IssueCallOffOrder(test, tender.Id, martinOrg.Username, domain.CallOffOrderReq{
+		BidId: bidIds[bobOrg.Username], Description: "50 pallets", Amount: 500,
+	})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+}
+
+func TestCallOffOrderCanceledFramework(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeDelivery,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+		Framework:       true,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bidIds := basic.CreatePublishedBids(test, tender.Id, aliceOrg)
+
+	_, resp = basic.SubmitDecisionBid(test, bidIds[aliceOrg.Username], martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.CancelTender(test, tender.Id, martinOrg.Username, domain.CancelTenderReq{Reason: "budget cut"})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// THE CANCELED FRAMEWORK ISSUES NO MORE ORDERS
+	_, resp = basic.IssueCallOffOrder(test, tender.Id, martinOrg.Username, domain.CallOffOrderReq{
+		BidId: bidIds[aliceOrg.Username], Description: "100 pallets", Amount: 1000,
+	})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+}
+
+func TestCallOffOrderRequiresFramework(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeDelivery,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.GetCallOffOrders(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+}
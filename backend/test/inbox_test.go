@@ -0,0 +1,180 @@
+package test
+
+import (
+	"avito/config"
+	"avito/db/model"
+	"avito/domain"
+	"avito/test/basic"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestInbox(t *testing.T) {
+	t.Parallel()
+
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "Office repair",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.InviteTender(test, tender.Id, martinOrg.Username, domain.InviteTenderReq{UserId: bobOrg.EmployeeId})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bid, resp := basic.CreateBid(test, domain.CreateBidReq{
+		Name:        "bid of Alice",
+		Description: "d1",
+		TenderId:    tender.Id,
+		AuthorType:  model.BidAuthorTypeUser,
+		AuthorId:    aliceOrg.EmployeeId,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SetBidStatus(test, bid.Id, aliceOrg.Username, model.BidStatusPublished)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SubmitFeedbackBid(test, bid.Id, martinOrg.Username, "Please lower the price")
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SubmitDecisionBid(test, bid.Id, martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// BOB IS INVITED, MARTIN GETS THE NEW BID, ALICE GETS THE FEEDBACK AND THE DECISION
+	test.Assertions.Eventually(func() bool {
+		unread, _ := basic.GetUnreadCount(test, aliceOrg.Username)
+		return unread.Unread == 2
+	}, 10*time.Second, 100*time.Millisecond)
+
+	notifications, resp := basic.GetNotifications(test, bobOrg.Username, false, 0, 0)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(notifications, 1)
+	test.Assertions.Equal(model.EventTenderInvited, notifications[0].Type)
+	test.Assertions.Equal("Office repair", notifications[0].TenderName)
+	test.Assertions.Equal(martinOrg.Username, notifications[0].Username)
+
+	notifications, resp = basic.GetNotifications(test, martinOrg.Username, false, 0, 0)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(notifications, 1)
+	test.Assertions.Equal(model.EventBidSubmitted, notifications[0].Type)
+	test.Assertions.Equal(bid.Id, *notifications[0].BidId)
+	test.Assertions.Equal(aliceOrg.Username, notifications[0].Username)
+
+	notifications, resp = basic.GetNotifications(test, aliceOrg.Username, false, 0, 0)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(notifications, 2)
+	types := []model.EventType{notifications[0].Type, notifications[1].Type}
+	test.Assertions.ElementsMatch([]model.EventType{model.EventBidFeedback, model.EventBidApproved}, types)
+	for _, notification := range notifications {
+		test.Assertions.False(notification.Read)
+		if notification.Type == model.EventBidFeedback {
+			test.Assertions.Equal("Please lower the price", notification.Feedback)
+		}
+	}
+
+	// MARTIN CAN NOT READ ALICE'S NOTIFICATION
+	_, resp = basic.MarkNotificationRead(test, notifications[0].Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.MarkNotificationRead(test, notifications[0].Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	unread, resp := basic.GetUnreadCount(test, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(1, unread.Unread)
+
+	notifications, resp = basic.GetNotifications(test, aliceOrg.Username, true, 0, 0)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(notifications, 1)
+
+	// BULK READ WITHOUT IDS READS EVERYTHING
+	read, resp := basic.MarkNotificationsRead(test, aliceOrg.Username, domain.InboxReadReq{})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(1, read.Read)
+
+	unread, resp = basic.GetUnreadCount(test, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Zero(unread.Unread)
+
+	_, resp = basic.GetUnreadCount(test, "nobody")
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+}
+
+func TestInboxRetention(t *testing.T) {
+	t.Parallel()
+
+	test := basic.InitTest(t, func(cfg *config.Config) {
+		cfg.InboxRetention = "3s"
+	})
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "Office repair",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.InviteTender(test, tender.Id, martinOrg.Username, domain.InviteTenderReq{OrganizationId: aliceOrg.OrgId})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	test.Assertions.Eventually(func() bool {
+		notifications, _ := basic.GetNotifications(test, aliceOrg.Username, false, 0, 0)
+		return len(notifications) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+
+	// THE EXPIRED NOTIFICATION IS REMOVED
+	test.Assertions.Eventually(func() bool {
+		notifications, _ := basic.GetNotifications(test, aliceOrg.Username, false, 0, 0)
+		return len(notifications) == 0
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+func TestInboxAnonymizedBidder(t *testing.T) {
+	t.Parallel()
+
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "Office repair",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+		Anonymized:      true,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	basic.CreatePublishedBids(test, tender.Id, aliceOrg)
+
+	// MARTIN LEARNS ABOUT THE NEW BID BUT NOT ABOUT ITS AUTHOR
+	test.Assertions.Eventually(func() bool {
+		unread, _ := basic.GetUnreadCount(test, martinOrg.Username)
+		return unread.Unread == 1
+	}, 10*time.Second, 100*time.Millisecond)
+
+	notifications, resp := basic.GetNotifications(test, martinOrg.Username, false, 0, 0)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(notifications, 1)
+	test.Assertions.Equal(model.EventBidSubmitted, notifications[0].Type)
+	test.Assertions.Empty(notifications[0].Username)
+}
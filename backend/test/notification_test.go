@@ -0,0 +1,106 @@
+package test
+
+import (
+	"avito/config"
+	"avito/db/model"
+	"avito/domain"
+	"avito/test/basic"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEmailNotifications(t *testing.T) {
+	t.Parallel()
+
+	// THE FIRST MESSAGE IS REJECTED AND SENT AGAIN
+	smtpServer := basic.NewFakeSMTP(t, 1)
+	test := basic.InitTest(t, func(cfg *config.Config) {
+		cfg.SmtpAddress = smtpServer.Address
+	})
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+	charlieOrg := basic.CreateOrgEmployee(test, "Charlie")
+
+	invalid := "alice"
+	_, resp := basic.SetNotificationPreference(test, aliceOrg.Username, domain.NotificationPreferenceReq{Email: &invalid})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	valid := "alice@example.com"
+	_, resp = basic.SetNotificationPreference(test, aliceOrg.Username, domain.NotificationPreferenceReq{Email: &valid, Locale: "de"})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	emails := map[string]string{
+		aliceOrg.Username:   "alice@example.com",
+		bobOrg.Username:     "bob@example.com",
+		charlieOrg.Username: "charlie@example.com",
+	}
+	preferences := map[string]domain.NotificationPreferenceReq{
+		aliceOrg.Username:   {Locale: model.LocaleEn},
+		bobOrg.Username:     {EmailOptOut: true},
+		charlieOrg.Username: {},
+	}
+	for username, req := range preferences {
+		email := emails[username]
+		req.Email = &email
+		_, resp = basic.SetNotificationPreference(test, username, req)
+		test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	}
+
+	preference, resp := basic.GetNotificationPreference(test, charlieOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.LocaleRu, preference.Locale)
+	test.Assertions.Equal(emails[charlieOrg.Username], *preference.Email)
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "Office repair",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bidIds := basic.CreatePublishedBids(test, tender.Id, aliceOrg, bobOrg, charlieOrg)
+
+	for _, username := range []string{bobOrg.Username, charlieOrg.Username} {
+		_, resp = basic.SubmitDecisionBid(test, bidIds[username], martinOrg.Username, string(model.Rejected))
+		test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	}
+
+	_, resp = basic.SubmitFeedbackBid(test, bidIds[aliceOrg.Username], martinOrg.Username, "Please lower the price")
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SubmitDecisionBid(test, bidIds[aliceOrg.Username], martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// ALICE GETS THE EMAILS IN ENGLISH
+	test.Assertions.Eventually(func() bool {
+		return len(smtpServer.Emails(emails[aliceOrg.Username])) == 2
+	}, 10*time.Second, 100*time.Millisecond)
+
+	aliceEmails := smtpServer.Emails(emails[aliceOrg.Username])
+	subjects := []string{aliceEmails[0].Subject, aliceEmails[1].Subject}
+	test.Assertions.ElementsMatch([]string{
+		`Feedback on your bid for the tender "Office repair"`,
+		`Your bid for the tender "Office repair" is approved`,
+	}, subjects)
+	for _, email := range aliceEmails {
+		if strings.HasPrefix(email.Subject, "Feedback") {
+			test.Assertions.Contains(email.Body, "Please lower the price")
+			test.Assertions.Contains(email.Body, martinOrg.Username)
+		}
+	}
+
+	// CHARLIE GETS THE EMAIL IN RUSSIAN, BOB OPTED OUT
+	test.Assertions.Eventually(func() bool {
+		return len(smtpServer.Emails(emails[charlieOrg.Username])) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+	test.Assertions.Equal("Ваше предложение по тендеру «Office repair» отклонено",
+		smtpServer.Emails(emails[charlieOrg.Username])[0].Subject)
+	test.Assertions.Empty(smtpServer.Emails(emails[bobOrg.Username]))
+}
@@ -0,0 +1,145 @@
+package test
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/test/basic"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestOutboxEvents(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bid, resp := basic.CreateBid(test, domain.CreateBidReq{
+		Name:        "bid of Alice",
+		Description: "d1",
+		TenderId:    tender.Id,
+		AuthorType:  model.BidAuthorTypeUser,
+		AuthorId:    aliceOrg.EmployeeId,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SetBidStatus(test, bid.Id, aliceOrg.Username, model.BidStatusPublished)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.EditBid(test, bid.Id, aliceOrg.Username, domain.EditBidReq{Name: "bid of Alice v2", Description: "d2"})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// A FAILED DECISION WRITES NO EVENTS
+	_, resp = basic.SubmitDecisionBid(test, bid.Id, aliceOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	_, resp = basic.SubmitDecisionBid(test, bid.Id, martinOrg.Username, string(model.Approved))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	events := basic.GetOutboxEvents(test, tender.Id)
+	types := make([]model.EventType, len(events))
+	for i := range events {
+		types[i] = events[i].Type
+	}
+	test.Assertions.Equal([]model.EventType{
+		model.EventTenderCreated,
+		model.EventTenderPublished,
+		model.EventBidCreated,
+		model.EventBidSubmitted,
+		model.EventBidEdited,
+		model.EventBidApproved,
+		model.EventTenderClosed,
+	}, types)
+
+	test.Assertions.Equal(bid.Id, *events[5].BidId)
+	test.Assertions.Equal(martinOrg.Username, events[5].Payload.Username)
+	test.Assertions.Equal(string(model.BidStatusApproved), events[5].Payload.Status)
+
+	// THE DISPATCHER DELIVERS THE EVENTS TO THE LOG SINK
+	lastId := events[len(events)-1].Id
+	test.Assertions.Eventually(func() bool {
+		return basic.GetOutboxOffset(test, "log") >= lastId
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestOutboxRollbackEvents(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bid, resp := basic.CreateBid(test, domain.CreateBidReq{
+		Name:        "bid of Alice",
+		Description: "d1",
+		TenderId:    tender.Id,
+		AuthorType:  model.BidAuthorTypeUser,
+		AuthorId:    aliceOrg.EmployeeId,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.EditBid(test, bid.Id, aliceOrg.Username, domain.EditBidReq{Name: "bid of Alice v2", Description: "d2"})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.RollbackBid(test, bid.Id, aliceOrg.Username, "1")
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.WithdrawBid(test, bid.Id, aliceOrg.Username, domain.WithdrawBidReq{Reason: "r1"})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.ResubmitBid(test, bid.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.EditTender(test, tender.Id, martinOrg.Username, domain.EditTenderReq{
+		Name:        "changed",
+		Description: "changed",
+		ServiceType: model.TenderServiceTypeConstruction,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.RollbackTender(test, tender.Id, martinOrg.Username, "1")
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	events := basic.GetOutboxEvents(test, tender.Id)
+	types := make([]model.EventType, len(events))
+	for i := range events {
+		types[i] = events[i].Type
+	}
+	test.Assertions.Equal([]model.EventType{
+		model.EventTenderCreated,
+		model.EventTenderPublished,
+		model.EventBidCreated,
+		model.EventBidEdited,
+		model.EventBidEdited,
+		model.EventBidWithdrawn,
+		model.EventBidResubmitted,
+		model.EventTenderEdited,
+		model.EventTenderEdited,
+	}, types)
+
+	test.Assertions.Equal(3, *events[4].BidVersion)
+	test.Assertions.Equal(string(model.BidStatusCreated), events[6].Payload.Status)
+	test.Assertions.Equal(3, *events[8].TenderVersion)
+}
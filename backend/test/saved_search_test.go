@@ -0,0 +1,119 @@
+package test
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/test/basic"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSavedSearchAlerts(t *testing.T) {
+	t.Parallel()
+
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	low, high := 1000.0, 5000.0
+	invalid := []domain.CreateSavedSearchReq{
+		{Name: " "},
+		{Name: "repairs", Keywords: []string{""}},
+		{Name: "repairs", BudgetMin: &high, BudgetMax: &low},
+		{Name: "repairs", ServiceTypes: []model.TenderServiceType{"Gardening"}},
+		{Name: "repairs", OrganizationId: &aliceOrg.EmployeeId},
+	}
+	for _, req := range invalid {
+		_, resp := basic.CreateSavedSearch(test, aliceOrg.Username, req)
+		test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+	}
+
+	repairs, resp := basic.CreateSavedSearch(test, aliceOrg.Username, domain.CreateSavedSearchReq{
+		Name:         "repairs",
+		ServiceTypes: []model.TenderServiceType{model.TenderServiceTypeConstruction},
+		Keywords:     []string{"REPAIR"},
+		BudgetMin:    &low,
+		BudgetMax:    &high,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	martin, resp := basic.CreateSavedSearch(test, aliceOrg.Username, domain.CreateSavedSearchReq{
+		Name:           "everything of Martin",
+		OrganizationId: &martinOrg.OrgId,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	searches, resp := basic.GetSavedSearches(test, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(searches, 2)
+	test.Assertions.Equal([]string{"REPAIR"}, searches[0].Keywords)
+
+	budget, bigBudget := 3000.0, 9000.0
+	tenders := []domain.CreateTenderReq{
+		{Name: "Office repair", ServiceType: model.TenderServiceTypeConstruction, Budget: &budget},
+		{Name: "Road repair", ServiceType: model.TenderServiceTypeDelivery, Budget: &budget},
+		{Name: "Bridge repair", ServiceType: model.TenderServiceTypeConstruction, Budget: &bigBudget},
+		{Name: "Secret repair", ServiceType: model.TenderServiceTypeConstruction, Budget: &budget,
+			Visibility: model.TenderVisibilityInviteOnly},
+	}
+	for _, req := range tenders {
+		req.Description = "d1"
+		req.Status = model.TenderStatusPublished
+		req.OrganizationId = martinOrg.OrgId
+		req.CreatorUsername = martinOrg.Username
+		_, resp = basic.CreateTender(test, req)
+		test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	}
+
+	// THE DRAFT MATCHES ONCE IT IS PUBLISHED
+	draft, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "Warehouse",
+		Description:     "Roof Repair",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusCreated,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+		Budget:          &budget,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SetTenderStatus(test, draft.Id, martinOrg.Username, model.TenderStatusPublished)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	test.Assertions.Eventually(func() bool {
+		alerts, _ := basic.GetTenderAlerts(test, aliceOrg.Username, repairs.Id, 0, 0)
+		return len(alerts) == 2
+	}, 10*time.Second, 100*time.Millisecond)
+
+	alerts, resp := basic.GetTenderAlerts(test, aliceOrg.Username, repairs.Id, 0, 0)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal("Warehouse", alerts[0].TenderName)
+	test.Assertions.Equal("Office repair", alerts[1].TenderName)
+	test.Assertions.Equal("repairs", alerts[1].SearchName)
+
+	// THE INVITE-ONLY TENDER IS HIDDEN FROM ALICE
+	alerts, resp = basic.GetTenderAlerts(test, aliceOrg.Username, martin.Id, 0, 0)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(alerts, 4)
+
+	alerts, resp = basic.GetTenderAlerts(test, aliceOrg.Username, "", 0, 0)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(alerts, 6)
+
+	alerts, resp = basic.GetTenderAlerts(test, martinOrg.Username, "", 0, 0)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Empty(alerts)
+
+	// MARTIN CAN NOT DELETE THE SEARCH OF ALICE
+	_, resp = basic.DeleteSavedSearch(test, martin.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.DeleteSavedSearch(test, martin.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	alerts, resp = basic.GetTenderAlerts(test, aliceOrg.Username, "", 0, 0)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(alerts, 2)
+}
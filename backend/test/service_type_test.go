@@ -0,0 +1,65 @@
+package test
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/test/basic"
+	"net/http"
+	"testing"
+)
+
+func TestServiceTypeCatalogue(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	admin := basic.CreateOrgEmployee(test, "Admin")
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+
+	parent := string(model.TenderServiceTypeConstruction)
+	roadsReq := domain.CreateServiceTypeReq{Code: "Construction.Roads", Name: "Road construction", ParentCode: &parent}
+
+	// ONLY ADMINISTRATORS MANAGE THE CATALOGUE
+	_, resp := basic.CreateServiceType(test, martinOrg.Username, roadsReq)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	roads, resp := basic.CreateServiceType(test, admin.Username, roadsReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(parent, *roads.ParentCode)
+
+	// A TYPE CAN NOT BECOME A CHILD OF ITS DESCENDANT
+	roadsCode := roads.Code
+	_, resp = basic.UpdateServiceType(test, parent, admin.Username, domain.UpdateServiceTypeReq{
+		Name:       parent,
+		ParentCode: &roadsCode,
+	})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     "Unknown",
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	}
+	_, resp = basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	tenderReq.ServiceType = model.TenderServiceType(roads.Code)
+	tender, resp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// FILTERING BY THE PARENT TYPE INCLUDES THE CHILD TYPES
+	tenders, resp := basic.GetPublishedTendersByType(test, martinOrg.Username, parent)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(tenders, 1)
+	test.Assertions.Equal(tender.Id, tenders[0].Id)
+
+	tenders, resp = basic.GetPublishedTendersByType(test, martinOrg.Username, string(model.TenderServiceTypeDelivery))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Empty(tenders)
+
+	// USED TYPES CAN NOT BE DELETED
+	resp = basic.DeleteServiceType(test, roads.Code, admin.Username)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+}
@@ -0,0 +1,131 @@
+package test
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/test/basic"
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestEventStream(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	bobOrg := basic.CreateOrgEmployee(test, "Bob")
+	charlieOrg := basic.CreateOrgEmployee(test, "Charlie")
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// ONLY THE TENDER ORGANIZATION AND THE BIDDERS FOLLOW THE TENDER
+	_, status := basic.EventStream(ctx, test, "/api/tenders/"+tender.Id+"/stream", charlieOrg.Username, 0)
+	test.Assertions.Equal(http.StatusForbidden, status)
+
+	_, status = basic.EventStream(ctx, test, "/api/organizations/"+martinOrg.OrgId+"/stream", aliceOrg.Username, 0)
+	test.Assertions.Equal(http.StatusForbidden, status)
+
+	orgEvents, status := basic.EventStream(ctx, test, "/api/organizations/"+martinOrg.OrgId+"/stream", martinOrg.Username, 0)
+	test.Assertions.Equal(http.StatusOK, status)
+
+	aliceCtx, aliceCancel := context.WithCancel(ctx)
+	aliceEvents, status := basic.EventStream(aliceCtx, test, "/api/bids/my/stream", aliceOrg.Username, 0)
+	test.Assertions.Equal(http.StatusOK, status)
+
+	bidIds := make([]string, 0, 2)
+	for _, org := range []basic.EmployeeOrg{aliceOrg, bobOrg} {
+		bid, resp := basic.CreateBid(test, domain.CreateBidReq{
+			Name:        "bid of " + org.Username,
+			Description: "d1",
+			TenderId:    tender.Id,
+			AuthorType:  model.BidAuthorTypeUser,
+			AuthorId:    org.EmployeeId,
+		})
+		test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+		_, resp = basic.SetBidStatus(test, bid.Id, org.Username, model.BidStatusPublished)
+		test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+		bidIds = append(bidIds, bid.Id)
+	}
+
+	// THE ORGANIZATION SEES ALL THE BIDS, ALICE ONLY HER OWN
+	for _, bidId := range bidIds {
+		event := <-orgEvents
+		test.Assertions.Equal(model.EventBidCreated, event.Type)
+		test.Assertions.Equal(bidId, *event.BidId)
+
+		event = <-orgEvents
+		test.Assertions.Equal(model.EventBidSubmitted, event.Type)
+		test.Assertions.Equal(bidId, *event.BidId)
+		test.Assertions.Equal(string(model.BidStatusPublished), event.Status)
+		test.Assertions.Equal(1, *event.BidVersion)
+	}
+
+	created := <-aliceEvents
+	test.Assertions.Equal(model.EventBidCreated, created.Type)
+
+	submitted := <-aliceEvents
+	test.Assertions.Equal(model.EventBidSubmitted, submitted.Type)
+	test.Assertions.Equal(bidIds[0], *submitted.BidId)
+
+	_, resp = basic.EditTender(test, tender.Id, martinOrg.Username, domain.EditTenderReq{
+		Name:        "changed",
+		Description: "changed",
+		ServiceType: model.TenderServiceTypeConstruction,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	event := <-orgEvents
+	test.Assertions.Equal(model.EventTenderEdited, event.Type)
+	test.Assertions.Equal(2, *event.TenderVersion)
+	test.Assertions.Nil(event.BidId)
+
+	// ALICE RECONNECTS AND RESUMES AFTER THE LAST RECEIVED EVENT
+	aliceCancel()
+
+	_, resp = basic.EditBid(test, bidIds[0], aliceOrg.Username, domain.EditBidReq{Name: "bid of Alice v2", Description: "d2"})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	aliceEvents, status = basic.EventStream(ctx, test, "/api/bids/my/stream", aliceOrg.Username, submitted.Id)
+	test.Assertions.Equal(http.StatusOK, status)
+
+	edited := <-aliceEvents
+	test.Assertions.Equal(model.EventBidEdited, edited.Type)
+	test.Assertions.Equal(bidIds[0], *edited.BidId)
+	test.Assertions.Equal(2, *edited.BidVersion)
+
+	// THE TENDER STREAM OF A BIDDER HIDES THE OTHER BIDS
+	outbox := basic.GetOutboxEvents(test, tender.Id)
+	tenderEvents, status := basic.EventStream(ctx, test, "/api/tenders/"+tender.Id+"/stream", aliceOrg.Username, outbox[0].Id)
+	test.Assertions.Equal(http.StatusOK, status)
+
+	types := make([]model.EventType, 0, 5)
+	for range 5 {
+		event := <-tenderEvents
+		test.Assertions.NotEqual(model.EventType(""), event.Type)
+		if event.BidId != nil {
+			test.Assertions.Equal(bidIds[0], *event.BidId)
+		}
+		types = append(types, event.Type)
+	}
+	test.Assertions.Equal([]model.EventType{
+		model.EventTenderPublished,
+		model.EventBidCreated,
+		model.EventBidSubmitted,
+		model.EventTenderEdited,
+		model.EventBidEdited,
+	}, types)
+}
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/test/basic"
+	"net/http"
+	"testing"
+)
+
+func TestTenderFromTemplate(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	budget := 150000.0
+	templateReq := domain.TemplateReq{
+		Name:        "monthly delivery",
+		Description: "office supplies delivery",
+		ServiceType: model.TenderServiceTypeDelivery,
+		Budget:      &budget,
+		Criteria:    []domain.CriterionReq{{Name: "price", Weight: 2}, {Name: "delivery time", Weight: 1}},
+	}
+
+	// ALICE CAN NOT CREATE TEMPLATES FOR MARTIN'S ORGANIZATION
+	_, resp := basic.CreateTemplate(test, martinOrg.OrgId, aliceOrg.Username, templateReq)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	// CRITERION NAMES CAN NOT BE BLANK
+	blankReq := templateReq
+	blankReq.Criteria = []domain.CriterionReq{{Name: " ", Weight: 1}}
+	_, resp = basic.CreateTemplate(test, martinOrg.OrgId, martinOrg.Username, blankReq)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	template, resp := basic.CreateTemplate(test, martinOrg.OrgId, martinOrg.Username, templateReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(template.Criteria, 2)
+
+	// TEMPLATE NAMES ARE UNIQUE WITHIN THE ORGANIZATION
+	_, resp = basic.CreateTemplate(test, martinOrg.OrgId, martinOrg.Username, templateReq)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	templateReq.Description = "office supplies and furniture delivery"
+	template, resp = basic.UpdateTemplate(test, template.Id, martinOrg.Username, templateReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(templateReq.Description, template.Description)
+
+	templates, resp := basic.GetTemplates(test, martinOrg.OrgId, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(templates, 1)
+
+	_, resp = basic.CreateTenderFromTemplate(test, template.Id, domain.CreateTenderFromTemplateReq{
+		CreatorUsername: aliceOrg.Username,
+	})
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	tender, resp := basic.CreateTenderFromTemplate(test, template.Id, domain.CreateTenderFromTemplateReq{
+		Name:            "september delivery",
+		Status:          model.TenderStatusPublished,
+		CreatorUsername: martinOrg.Username,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal("september delivery", tender.Name)
+	test.Assertions.Equal(templateReq.Description, tender.Description)
+	test.Assertions.Equal(model.TenderServiceTypeDelivery, tender.ServiceType)
+	test.Assertions.NotNil(tender.Budget)
+	test.Assertions.InDelta(budget, *tender.Budget, 0.001)
+	test.Assertions.NotNil(tender.TemplateId)
+	test.Assertions.Equal(template.Id, *tender.TemplateId)
+
+	criteria, resp := basic.GetCriteria(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(criteria, 2)
+}
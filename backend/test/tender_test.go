@@ -5,7 +5,9 @@ import (
 	"avito/domain"
 	"avito/test/basic"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestTenderCreate(t *testing.T) {
@@ -153,3 +155,210 @@ func TestTenderRollback(t *testing.T) {
 	_, resp = basic.RollbackTender(test, tender.Id, aliceOrg.Username, "1")
 	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
 }
+
+func TestTenderInviteOnly(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		Visibility:      model.TenderVisibilityInviteOnly,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	}
+	tender, tenderResp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, tenderResp.StatusCode())
+	test.Assertions.Equal(model.TenderVisibilityInviteOnly, tender.Visibility)
+
+	// MARTIN SEES THE TENDER BECAUSE HE IS FROM ITS ORGANIZATION
+	tenders, resp := basic.GetPublishedTenders(test, martinOrg.Username, 0, 5)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(tenders, 1)
+
+	// ALICE CAN NOT SEE THE TENDER BECAUSE SHE IS NOT INVITED
+	tenders, resp = basic.GetPublishedTenders(test, aliceOrg.Username, 0, 5)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Empty(tenders)
+
+	_, resp = basic.GetTenderStatus(test, tender.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	// ALICE CAN NOT BID ON THE TENDER OR SEE ITS BIDS
+	_, resp = basic.CreateBid(test, domain.CreateBidReq{
+		Name:        "b1",
+		Description: "d1",
+		TenderId:    tender.Id,
+		AuthorType:  model.BidAuthorTypeUser,
+		AuthorId:    aliceOrg.EmployeeId,
+	})
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	_, resp = basic.GetBidsByTenderId(test, tender.Id, aliceOrg.Username, 0, 5)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	// ALICE CAN NOT INVITE HERSELF
+	_, resp = basic.InviteTender(test, tender.Id, aliceOrg.Username, domain.InviteTenderReq{OrganizationId: aliceOrg.OrgId})
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	// MARTIN INVITES ALICE'S ORGANIZATION
+	_, resp = basic.InviteTender(test, tender.Id, martinOrg.Username, domain.InviteTenderReq{OrganizationId: aliceOrg.OrgId})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	tenders, resp = basic.GetPublishedTenders(test, aliceOrg.Username, 0, 5)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(tenders, 1)
+
+	status, resp := basic.GetTenderStatus(test, tender.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.TenderStatusPublished, status)
+}
+
+func TestTenderScheduledPublication(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusCreated,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	}
+	tender, tenderResp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, tenderResp.StatusCode())
+
+	// PUBLICATION IN THE PAST CAN NOT BE SCHEDULED
+	past := time.Now().Add(-time.Hour)
+	_, resp := basic.ScheduleTender(test, tender.Id, martinOrg.Username, domain.ScheduleTenderReq{PublishAt: &past})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	// ALICE CAN NOT SCHEDULE BECAUSE SHE IS NOT FROM THE ORGANIZATION
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+	publishAt := time.Now().Add(time.Second)
+	_, resp = basic.ScheduleTender(test, tender.Id, aliceOrg.Username, domain.ScheduleTenderReq{PublishAt: &publishAt})
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	scheduleResp, resp := basic.ScheduleTender(test, tender.Id, martinOrg.Username, domain.ScheduleTenderReq{PublishAt: &publishAt})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.NotNil(scheduleResp.PublishAt)
+
+	test.Assertions.Eventually(func() bool {
+		status, _ := basic.GetTenderStatus(test, tender.Id, martinOrg.Username)
+		return status == model.TenderStatusPublished
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestTenderManualPublicationClearsSchedule(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusCreated,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	}
+	tender, tenderResp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, tenderResp.StatusCode())
+
+	publishAt := time.Now().Add(time.Hour)
+	_, resp := basic.ScheduleTender(test, tender.Id, martinOrg.Username, domain.ScheduleTenderReq{PublishAt: &publishAt})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// THE MANUAL PUBLICATION DROPS THE SCHEDULED ONE
+	statusResp, resp := basic.SetTenderStatus(test, tender.Id, martinOrg.Username, model.TenderStatusPublished)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Nil(statusResp.PublishAt)
+
+	// THE PUBLISHED TENDER CAN BE EDITED AND SCHEDULED TO CLOSE
+	closeAt := time.Now().Add(2 * time.Hour)
+	editResp, resp := basic.EditTender(test, tender.Id, martinOrg.Username, domain.EditTenderReq{
+		Name:        "n2",
+		Description: "d2",
+		ServiceType: model.TenderServiceTypeConstruction,
+		CloseAt:     &closeAt,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal("n2", editResp.Name)
+	test.Assertions.NotNil(editResp.CloseAt)
+	test.Assertions.Nil(editResp.PublishAt)
+}
+
+func TestTenderCancel(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	tenderReq := domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	}
+	tender, tenderResp := basic.CreateTender(test, tenderReq)
+	test.Assertions.Equal(http.StatusOK, tenderResp.StatusCode())
+
+	bidReq := domain.CreateBidReq{
+		Name:        "n1",
+		Description: "d1",
+		TenderId:    tender.Id,
+		AuthorType:  model.BidAuthorTypeUser,
+		AuthorId:    aliceOrg.EmployeeId,
+	}
+	bid, resp := basic.CreateBid(test, bidReq)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SetBidStatus(test, bid.Id, aliceOrg.Username, model.BidStatusPublished)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// REASON IS REQUIRED
+	_, resp = basic.CancelTender(test, tender.Id, martinOrg.Username, domain.CancelTenderReq{})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.CancelTender(test, tender.Id, martinOrg.Username, domain.CancelTenderReq{Reason: strings.Repeat("a", 1001)})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	// ALICE CAN NOT CANCEL BECAUSE SHE IS NOT FROM THE ORGANIZATION
+	reason := "budget was withdrawn"
+	_, resp = basic.CancelTender(test, tender.Id, aliceOrg.Username, domain.CancelTenderReq{Reason: reason})
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	cancellation, resp := basic.CancelTender(test, tender.Id, martinOrg.Username, domain.CancelTenderReq{Reason: reason})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(reason, cancellation.Reason)
+
+	status, resp := basic.GetTenderStatus(test, tender.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.TenderStatusClosed, status)
+
+	// ALICE SEES HER BID REJECTED AND THE REASON
+	bids, resp := basic.GetBidByUsername(test, aliceOrg.Username, 0, 5)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(bids, 1)
+	test.Assertions.Equal(model.BidStatusRejected, bids[0].Status)
+
+	cancellation, resp = basic.GetTenderCancellation(test, tender.Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(reason, cancellation.Reason)
+
+	// TENDER CAN NOT BE CANCELED TWICE
+	_, resp = basic.CancelTender(test, tender.Id, martinOrg.Username, domain.CancelTenderReq{Reason: reason})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+}
@@ -0,0 +1,252 @@
+package test
+
+import (
+	"avito/db/model"
+	"avito/domain"
+	"avito/service"
+	"avito/test/basic"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// webhookReceiver records the deliveries with a valid signature and fails while failing is set.
+type webhookReceiver struct {
+	secret  string
+	failing atomic.Bool
+	mu      sync.Mutex
+	events  []domain.WebhookEventBody
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil || req.Header.Get("X-Webhook-Signature") != service.WebhookSignature([]byte(r.secret), body) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if r.failing.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	var event domain.WebhookEventBody
+	if err = json.Unmarshal(body, &event); err != nil || req.Header.Get("X-Webhook-Event") != string(event.Type) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	r.mu.Lock()
+	r.events = append(r.events, event)
+	r.mu.Unlock()
+}
+
+func (r *webhookReceiver) received(tenderId string) []model.EventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	types := make([]model.EventType, 0)
+	for _, event := range r.events {
+		if event.TenderId == tenderId {
+			types = append(types, event.Type)
+		}
+	}
+	return types
+}
+
+func (r *webhookReceiver) payloads(tenderId string) []model.EventPayload {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	payloads := make([]model.EventPayload, 0)
+	for _, event := range r.events {
+		if event.TenderId == tenderId {
+			payloads = append(payloads, event.Payload)
+		}
+	}
+	return payloads
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	receiver := &webhookReceiver{secret: "martin-webhook-secret"}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	// VALIDATION AND ACCESS
+	_, resp := basic.CreateWebhook(test, martinOrg.OrgId, martinOrg.Username, domain.CreateWebhookReq{
+		Url:        srv.URL,
+		EventTypes: []model.EventType{model.EventTenderPublished},
+		Secret:     "short",
+	})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.CreateWebhook(test, martinOrg.OrgId, martinOrg.Username, domain.CreateWebhookReq{
+		Url:        srv.URL,
+		EventTypes: []model.EventType{"TenderDeleted"},
+		Secret:     receiver.secret,
+	})
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	_, resp = basic.CreateWebhook(test, martinOrg.OrgId, aliceOrg.Username, domain.CreateWebhookReq{
+		Url:        srv.URL,
+		EventTypes: []model.EventType{model.EventTenderPublished},
+		Secret:     receiver.secret,
+	})
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	webhook, resp := basic.CreateWebhook(test, martinOrg.OrgId, martinOrg.Username, domain.CreateWebhookReq{
+		Url:        srv.URL,
+		EventTypes: []model.EventType{model.EventTenderPublished, model.EventBidSubmitted},
+		Secret:     receiver.secret,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	webhooks, resp := basic.GetWebhooks(test, martinOrg.OrgId, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(webhooks, 1)
+	test.Assertions.Equal(webhook.Id, webhooks[0].Id)
+
+	// SIGNED DELIVERIES OF THE SUBSCRIBED EVENTS ONLY
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bid, resp := basic.CreateBid(test, domain.CreateBidReq{
+		Name:        "bid of Alice",
+		Description: "d1",
+		TenderId:    tender.Id,
+		AuthorType:  model.BidAuthorTypeUser,
+		AuthorId:    aliceOrg.EmployeeId,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	_, resp = basic.SetBidStatus(test, bid.Id, aliceOrg.Username, model.BidStatusPublished)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	test.Assertions.Eventually(func() bool {
+		return len(receiver.received(tender.Id)) == 2
+	}, 5*time.Second, 100*time.Millisecond)
+	test.Assertions.Equal([]model.EventType{model.EventTenderPublished, model.EventBidSubmitted}, receiver.received(tender.Id))
+	test.Assertions.Equal(aliceOrg.Username, receiver.payloads(tender.Id)[1].Username)
+
+	deliveries, resp := basic.GetWebhookDeliveries(test, webhook.Id, martinOrg.Username, string(model.WebhookDeliveryDelivered))
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Len(deliveries, 2)
+	test.Assertions.Equal(1, deliveries[0].Attempts)
+	test.Assertions.Equal(http.StatusOK, *deliveries[0].ResponseStatus)
+
+	// A FAILING RECEIVER GETS THE RETRIES AND THEN THE DELIVERY IS DEAD
+	receiver.failing.Store(true)
+
+	_, resp = basic.EditBid(test, bid.Id, aliceOrg.Username, domain.EditBidReq{Name: "bid of Alice v2", Description: "d2"})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	tender2, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n2",
+		Description:     "d2",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	var dead []domain.WebhookDeliveryResp
+	test.Assertions.Eventually(func() bool {
+		dead, _ = basic.GetDeadLetters(test, martinOrg.OrgId, martinOrg.Username)
+		return len(dead) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+	test.Assertions.Equal(model.EventTenderPublished, dead[0].EventType)
+	test.Assertions.Equal(3, dead[0].Attempts)
+	test.Assertions.Equal(http.StatusServiceUnavailable, *dead[0].ResponseStatus)
+	test.Assertions.NotNil(dead[0].LastError)
+	test.Assertions.Empty(receiver.received(tender2.Id))
+
+	_, resp = basic.ReplayDelivery(test, dead[0].Id, aliceOrg.Username)
+	test.Assertions.Equal(http.StatusForbidden, resp.StatusCode())
+
+	_, resp = basic.ReplayDelivery(test, deliveries[0].Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusBadRequest, resp.StatusCode())
+
+	// THE REPLAYED DELIVERY REACHES THE FIXED RECEIVER
+	receiver.failing.Store(false)
+
+	replayed, resp := basic.ReplayDelivery(test, dead[0].Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Equal(model.WebhookDeliveryPending, replayed.Status)
+	test.Assertions.Equal(0, replayed.Attempts)
+
+	test.Assertions.Eventually(func() bool {
+		return len(receiver.received(tender2.Id)) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+
+	dead, resp = basic.GetDeadLetters(test, martinOrg.OrgId, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+	test.Assertions.Empty(dead)
+
+	resp = basic.DeleteWebhook(test, webhook.Id, martinOrg.Username)
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	webhooks, _ = basic.GetWebhooks(test, martinOrg.OrgId, martinOrg.Username)
+	test.Assertions.Empty(webhooks)
+}
+
+func TestWebhookAnonymizedBidder(t *testing.T) {
+	t.Parallel()
+	test := basic.InitTest(t)
+
+	martinOrg := basic.CreateOrgEmployee(test, "Martin")
+	aliceOrg := basic.CreateOrgEmployee(test, "Alice")
+
+	receiver := &webhookReceiver{secret: "martin-webhook-secret"}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	_, resp := basic.CreateWebhook(test, martinOrg.OrgId, martinOrg.Username, domain.CreateWebhookReq{
+		Url:        srv.URL,
+		EventTypes: []model.EventType{model.EventBidSubmitted, model.EventBidWithdrawn},
+		Secret:     receiver.secret,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	tender, resp := basic.CreateTender(test, domain.CreateTenderReq{
+		Name:            "n1",
+		Description:     "d1",
+		ServiceType:     model.TenderServiceTypeConstruction,
+		Status:          model.TenderStatusPublished,
+		OrganizationId:  martinOrg.OrgId,
+		CreatorUsername: martinOrg.Username,
+		Anonymized:      true,
+	})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	bidIds := basic.CreatePublishedBids(test, tender.Id, aliceOrg)
+
+	_, resp = basic.WithdrawBid(test, bidIds[aliceOrg.Username], aliceOrg.Username, domain.WithdrawBidReq{Reason: "r1"})
+	test.Assertions.Equal(http.StatusOK, resp.StatusCode())
+
+	// THE BIDDER EVENTS OF THE ANONYMIZED TENDER DO NOT NAME THE BIDDER
+	test.Assertions.Eventually(func() bool {
+		return len(receiver.received(tender.Id)) == 2
+	}, 5*time.Second, 100*time.Millisecond)
+	for _, payload := range receiver.payloads(tender.Id) {
+		test.Assertions.Empty(payload.Username)
+	}
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"github.com/pkg/errors"
+)
+
+// Cipher encrypts data with AES-256-GCM and prepends the random nonce to the ciphertext.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+func NewCipher(key []byte) (Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return Cipher{}, errors.WithMessage(err, "create aes cipher")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return Cipher{}, errors.WithMessage(err, "create gcm")
+	}
+
+	return Cipher{aead: aead}, nil
+}
+
+func (c Cipher) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.WithMessage(err, "generate nonce")
+	}
+
+	return c.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (c Cipher) Decrypt(ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < c.aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	nonce, data := ciphertext[:c.aead.NonceSize()], ciphertext[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, data, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "decrypt")
+	}
+
+	return plaintext, nil
+}
+
+// NewKey generates a random AES-256 key.
+func NewKey() ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.WithMessage(err, "generate key")
+	}
+
+	return key, nil
+}